@@ -13,9 +13,7 @@
 package argparser
 
 import (
-	"encoding/json"
 	"github.com/alecthomas/kingpin"
-	"log"
 )
 
 import (
@@ -42,6 +40,18 @@ type BosApiArgs struct {
 	storageClass  string
 	template      bool
 	canned        string
+	ruleId        string
+	algorithm     string
+	index         string
+	notFound      string
+	trashDir      string
+	output        string
+}
+
+// set the output format of bosapi get-* commands
+func (b *BosApiArgs) setOutputFormat(context *kingpin.ParseContext) error {
+	boscli.SetApiOutputFormat(b.output)
+	return nil
 }
 
 // Put ACL
@@ -58,6 +68,27 @@ func (b *BosApiArgs) getBucketAcl(context *kingpin.ParseContext) error {
 	return nil
 }
 
+// Put object ACL
+func (b *BosApiArgs) putObjectAcl(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.PutObjectAcl(b.srcPath, b.srcBosPath, b.srcBosKeyPath, b.canned)
+	return nil
+}
+
+// Get object ACL
+func (b *BosApiArgs) getObjectAcl(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.GetObjectAcl(b.srcBosPath, b.srcBosKeyPath)
+	return nil
+}
+
+// Delete object ACL
+func (b *BosApiArgs) deleteObjectAcl(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.DeleteObjectAcl(b.srcBosPath, b.srcBosKeyPath)
+	return nil
+}
+
 // Put lifecycle
 func (b *BosApiArgs) putLifecycle(context *kingpin.ParseContext) error {
 	initBosapiClient()
@@ -100,6 +131,118 @@ func (b *BosApiArgs) deleteLogging(context *kingpin.ParseContext) error {
 	return nil
 }
 
+// Put bucket CORS
+func (b *BosApiArgs) putBucketCors(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.PutBucketCors(b.srcPath, b.srcBosPath, b.template)
+	return nil
+}
+
+// Get bucket CORS
+func (b *BosApiArgs) getBucketCors(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.GetBucketCors(b.srcBosPath)
+	return nil
+}
+
+// Delete bucket CORS
+func (b *BosApiArgs) deleteBucketCors(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.DeleteBucketCors(b.srcBosPath)
+	return nil
+}
+
+// Put bucket replication
+func (b *BosApiArgs) putBucketReplication(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.PutBucketReplication(b.srcPath, b.srcBosPath, b.template)
+	return nil
+}
+
+// Get bucket replication
+func (b *BosApiArgs) getBucketReplication(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.GetBucketReplication(b.srcBosPath, b.ruleId)
+	return nil
+}
+
+// Delete bucket replication
+func (b *BosApiArgs) deleteBucketReplication(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.DeleteBucketReplication(b.srcBosPath, b.ruleId)
+	return nil
+}
+
+// Get progress of bucket replication
+func (b *BosApiArgs) getBucketReplicationProgress(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.GetBucketReplicationProgress(b.srcBosPath, b.ruleId)
+	return nil
+}
+
+// Put bucket encryption
+func (b *BosApiArgs) putBucketEncryption(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.PutBucketEncryption(b.srcBosPath, b.algorithm)
+	return nil
+}
+
+// Get bucket encryption
+func (b *BosApiArgs) getBucketEncryption(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.GetBucketEncryption(b.srcBosPath)
+	return nil
+}
+
+// Delete bucket encryption
+func (b *BosApiArgs) deleteBucketEncryption(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.DeleteBucketEncryption(b.srcBosPath)
+	return nil
+}
+
+// Put bucket static website
+func (b *BosApiArgs) putBucketStaticWebsite(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.PutBucketStaticWebsite(b.srcBosPath, b.index, b.notFound)
+	return nil
+}
+
+// Get bucket static website
+func (b *BosApiArgs) getBucketStaticWebsite(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.GetBucketStaticWebsite(b.srcBosPath)
+	return nil
+}
+
+// Delete bucket static website
+func (b *BosApiArgs) deleteBucketStaticWebsite(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.DeleteBucketStaticWebsite(b.srcBosPath)
+	return nil
+}
+
+// Put bucket trash
+func (b *BosApiArgs) putBucketTrash(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.PutBucketTrash(b.srcBosPath, b.trashDir)
+	return nil
+}
+
+// Get bucket trash
+func (b *BosApiArgs) getBucketTrash(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.GetBucketTrash(b.srcBosPath)
+	return nil
+}
+
+// Delete bucket trash
+func (b *BosApiArgs) deleteBucketTrash(context *kingpin.ParseContext) error {
+	initBosapiClient()
+	bosapiClient.DeleteBucketTrash(b.srcBosPath)
+	return nil
+}
+
 // Put storage class
 func (b *BosApiArgs) putBucketStorageClass(context *kingpin.ParseContext) error {
 	initBosapiClient()
@@ -114,14 +257,10 @@ func (b *BosApiArgs) getBucketStorageClass(context *kingpin.ParseContext) error
 	return nil
 }
 
+// Get object meta
 func (b *BosApiArgs) getObjectMeta(context *kingpin.ParseContext) error {
 	initBosapiClient()
-	result, err := bosapiClient.GetObjectMeta(b.srcBosPath, b.srcBosKeyPath)
-	if err != nil {
-		return err
-	}
-	tempByte, _ := json.Marshal(*result)
-	log.Println(string(tempByte))
+	bosapiClient.GetObjectMeta(b.srcBosPath, b.srcBosKeyPath)
 	return nil
 }
 
@@ -154,6 +293,59 @@ func buildGetBucketAclParser(getBucketAclCmd *kingpin.CmdClause, bosApiArgsValue
 		StringVar(&bosApiArgsValue.srcBosPath)
 }
 
+// build parser for put object acl
+func buildPutObjectAclParser(putObjectAclCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
+	putObjectAclCmd.Action(bosApiArgsValue.putObjectAcl)
+	putObjectAclCmd.Flag(
+		"acl-config-file",
+		"path to acl file in json format.").
+		StringVar(&bosApiArgsValue.srcPath)
+	putObjectAclCmd.Flag(
+		"bucket-name",
+		"bucket of the object you want to put acl for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+	putObjectAclCmd.Flag(
+		"object-name",
+		"object you want to put acl for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosKeyPath)
+	putObjectAclCmd.Flag(
+		"canned",
+		"set the canned acl of the given object, it can be: 'private' or 'public-read'").
+		StringVar(&bosApiArgsValue.canned)
+}
+
+// build parser for get object acl
+func buildGetObjectAclParser(getObjectAclCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
+	getObjectAclCmd.Action(bosApiArgsValue.getObjectAcl)
+	getObjectAclCmd.Flag(
+		"bucket-name",
+		"bucket of the object you want to get acl for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+	getObjectAclCmd.Flag(
+		"object-name",
+		"object you want to get acl for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosKeyPath)
+}
+
+// build parser for delete object acl
+func buildDelObjectAclParser(delObjectAclCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
+	delObjectAclCmd.Action(bosApiArgsValue.deleteObjectAcl)
+	delObjectAclCmd.Flag(
+		"bucket-name",
+		"bucket of the object you want to delete acl for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+	delObjectAclCmd.Flag(
+		"object-name",
+		"object you want to delete acl for, it will use the acl of its bucket.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosKeyPath)
+}
+
 // build parser for put lifecycle
 func buildPutLifecycleParser(putLifecycleCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
 	putLifecycleCmd.Action(bosApiArgsValue.putLifecycle)
@@ -191,6 +383,221 @@ func buildDelLifecycleParser(delLifecycleCmd *kingpin.CmdClause, bosApiArgsValue
 		StringVar(&bosApiArgsValue.srcBosPath)
 }
 
+// build parser for put bucket CORS
+func buildPutBucketCorsParser(putBucketCorsCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
+	putBucketCorsCmd.Action(bosApiArgsValue.putBucketCors)
+	putBucketCorsCmd.Flag(
+		"cors-config-file",
+		"path to CORS file in json format, use --template to get an template of the file.").
+		StringVar(&bosApiArgsValue.srcPath)
+	putBucketCorsCmd.Flag(
+		"bucket-name",
+		"bucket you want to put CORS config for.").
+		StringVar(&bosApiArgsValue.srcBosPath)
+	putBucketCorsCmd.Flag(
+		"template",
+		"generates a CORS config template.").
+		BoolVar(&bosApiArgsValue.template)
+}
+
+// build parser for get bucket CORS
+func buildGetBucketCorsParser(getBucketCorsCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
+	getBucketCorsCmd.Action(bosApiArgsValue.getBucketCors)
+	getBucketCorsCmd.Flag(
+		"bucket-name",
+		"bucket you want to get CORS config for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+}
+
+// build parser for delete bucket CORS
+func buildDelBucketCorsParser(delBucketCorsCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
+	delBucketCorsCmd.Action(bosApiArgsValue.deleteBucketCors)
+	delBucketCorsCmd.Flag(
+		"bucket-name",
+		"bucket you want to delete CORS config.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+}
+
+// build parser for put bucket replication
+func buildPutBucketReplicationParser(putBucketReplicationCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	putBucketReplicationCmd.Action(bosApiArgsValue.putBucketReplication)
+	putBucketReplicationCmd.Flag(
+		"replication-config-file",
+		"path to replication rule file in json format, use --template to get an template of the "+
+			"file.").
+		StringVar(&bosApiArgsValue.srcPath)
+	putBucketReplicationCmd.Flag(
+		"bucket-name",
+		"source bucket of the replication.").
+		StringVar(&bosApiArgsValue.srcBosPath)
+	putBucketReplicationCmd.Flag(
+		"template",
+		"generates a replication rule template.").
+		BoolVar(&bosApiArgsValue.template)
+}
+
+// build parser for get bucket replication
+func buildGetBucketReplicationParser(getBucketReplicationCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	getBucketReplicationCmd.Action(bosApiArgsValue.getBucketReplication)
+	getBucketReplicationCmd.Flag(
+		"bucket-name",
+		"bucket you want to get replication rules for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+	getBucketReplicationCmd.Flag(
+		"id",
+		"id of the replication rule, list all the rules of the bucket if it is not given.").
+		StringVar(&bosApiArgsValue.ruleId)
+}
+
+// build parser for delete bucket replication
+func buildDelBucketReplicationParser(delBucketReplicationCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	delBucketReplicationCmd.Action(bosApiArgsValue.deleteBucketReplication)
+	delBucketReplicationCmd.Flag(
+		"bucket-name",
+		"bucket you want to delete replication rule.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+	delBucketReplicationCmd.Flag(
+		"id",
+		"id of the replication rule.").
+		StringVar(&bosApiArgsValue.ruleId)
+}
+
+// build parser for get progress of bucket replication
+func buildGetBucketReplicationProgressParser(getProgressCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	getProgressCmd.Action(bosApiArgsValue.getBucketReplicationProgress)
+	getProgressCmd.Flag(
+		"bucket-name",
+		"bucket you want to get replication progress for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+	getProgressCmd.Flag(
+		"id",
+		"id of the replication rule.").
+		StringVar(&bosApiArgsValue.ruleId)
+}
+
+// build parser for put bucket encryption
+func buildPutBucketEncryptionParser(putBucketEncryptionCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	putBucketEncryptionCmd.Action(bosApiArgsValue.putBucketEncryption)
+	putBucketEncryptionCmd.Flag(
+		"bucket-name",
+		"bucket you want to put encryption for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+	putBucketEncryptionCmd.Flag(
+		"algorithm",
+		"server-side encryption algorithm of objects, only AES256 is supported.").
+		Default("AES256").
+		StringVar(&bosApiArgsValue.algorithm)
+}
+
+// build parser for get bucket encryption
+func buildGetBucketEncryptionParser(getBucketEncryptionCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	getBucketEncryptionCmd.Action(bosApiArgsValue.getBucketEncryption)
+	getBucketEncryptionCmd.Flag(
+		"bucket-name",
+		"bucket you want to get encryption for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+}
+
+// build parser for delete bucket encryption
+func buildDelBucketEncryptionParser(delBucketEncryptionCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	delBucketEncryptionCmd.Action(bosApiArgsValue.deleteBucketEncryption)
+	delBucketEncryptionCmd.Flag(
+		"bucket-name",
+		"bucket you want to delete encryption for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+}
+
+// build parser for put bucket static website
+func buildPutBucketStaticWebsiteParser(putWebsiteCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	putWebsiteCmd.Action(bosApiArgsValue.putBucketStaticWebsite)
+	putWebsiteCmd.Flag(
+		"bucket-name",
+		"bucket you want to put static website for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+	putWebsiteCmd.Flag(
+		"index",
+		"index document returned when a directory of the website is requested, e.g. index.html").
+		StringVar(&bosApiArgsValue.index)
+	putWebsiteCmd.Flag(
+		"not-found",
+		"document returned when the requested object doesn't exist, e.g. 404.html").
+		StringVar(&bosApiArgsValue.notFound)
+}
+
+// build parser for get bucket static website
+func buildGetBucketStaticWebsiteParser(getWebsiteCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	getWebsiteCmd.Action(bosApiArgsValue.getBucketStaticWebsite)
+	getWebsiteCmd.Flag(
+		"bucket-name",
+		"bucket you want to get static website for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+}
+
+// build parser for delete bucket static website
+func buildDelBucketStaticWebsiteParser(delWebsiteCmd *kingpin.CmdClause,
+	bosApiArgsValue *BosApiArgs) {
+	delWebsiteCmd.Action(bosApiArgsValue.deleteBucketStaticWebsite)
+	delWebsiteCmd.Flag(
+		"bucket-name",
+		"bucket you want to delete static website for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+}
+
+// build parser for put bucket trash
+func buildPutBucketTrashParser(putTrashCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
+	putTrashCmd.Action(bosApiArgsValue.putBucketTrash)
+	putTrashCmd.Flag(
+		"bucket-name",
+		"bucket you want to enable trash for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+	putTrashCmd.Flag(
+		"trash-dir",
+		"directory in the bucket where removed objects are kept").
+		Default(".trash").
+		StringVar(&bosApiArgsValue.trashDir)
+}
+
+// build parser for get bucket trash
+func buildGetBucketTrashParser(getTrashCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
+	getTrashCmd.Action(bosApiArgsValue.getBucketTrash)
+	getTrashCmd.Flag(
+		"bucket-name",
+		"bucket you want to get trash for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+}
+
+// build parser for delete bucket trash
+func buildDelBucketTrashParser(delTrashCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
+	delTrashCmd.Action(bosApiArgsValue.deleteBucketTrash)
+	delTrashCmd.Flag(
+		"bucket-name",
+		"bucket you want to disable trash for.").
+		Required().
+		StringVar(&bosApiArgsValue.srcBosPath)
+}
+
 // build parser for put logging parser
 func putLoggingParser(putLoggingCmd *kingpin.CmdClause, bosApiArgsValue *BosApiArgs) {
 	putLoggingCmd.Action(bosApiArgsValue.putLogging)
@@ -240,7 +647,7 @@ func putBucketStorageClassParser(putBucketStorageClassCmd *kingpin.CmdClause,
 		StringVar(&bosApiArgsValue.srcBosPath)
 	putBucketStorageClassCmd.Flag(
 		"storage-class",
-		"bucket storage class, should be STANDARD or STANDARD_IA or COLD.").
+		"bucket storage class, should be STANDARD, STANDARD_IA, COLD or ARCHIVE.").
 		Required().
 		StringVar(&bosApiArgsValue.storageClass)
 }
@@ -273,12 +680,29 @@ func getObjectMetaParser(getObjectMetaCmd *kingpin.CmdClause,
 func BuildBosApi(bosApi *kingpin.CmdClause) {
 	bosApiArgsValue := &BosApiArgs{}
 
+	bosApi.Flag(
+		"output",
+		"output format of get-* commands, one of json, yaml and table. the json output can be "+
+			"saved and passed to the matching put-* command.").
+		Default(boscli.OUTPUT_FORMAT_JSON).EnumVar(&bosApiArgsValue.output,
+		boscli.ApiOutputFormats...)
+	bosApi.PreAction(bosApiArgsValue.setOutputFormat)
+
 	putBucketAclCmd := bosApi.Command("put-bucket-acl", "put bucket ACL.")
 	buildPutBucketAclParser(putBucketAclCmd, bosApiArgsValue)
 
 	getBucketAclCmd := bosApi.Command("get-bucket-acl", "get bucket ACL.")
 	buildGetBucketAclParser(getBucketAclCmd, bosApiArgsValue)
 
+	putObjectAclCmd := bosApi.Command("put-object-acl", "put object ACL.")
+	buildPutObjectAclParser(putObjectAclCmd, bosApiArgsValue)
+
+	getObjectAclCmd := bosApi.Command("get-object-acl", "get object ACL.")
+	buildGetObjectAclParser(getObjectAclCmd, bosApiArgsValue)
+
+	delObjectAclCmd := bosApi.Command("delete-object-acl", "delete object ACL.")
+	buildDelObjectAclParser(delObjectAclCmd, bosApiArgsValue)
+
 	putLifecycleCmd := bosApi.Command("put-lifecycle", "put lifecycle.")
 	buildPutLifecycleParser(putLifecycleCmd, bosApiArgsValue)
 
@@ -297,8 +721,69 @@ func BuildBosApi(bosApi *kingpin.CmdClause) {
 	delLoggingCmd := bosApi.Command("delete-logging", "delete logging.")
 	delLoggingParser(delLoggingCmd, bosApiArgsValue)
 
+	putBucketCorsCmd := bosApi.Command("put-bucket-cors", "put bucket CORS config.")
+	buildPutBucketCorsParser(putBucketCorsCmd, bosApiArgsValue)
+
+	getBucketCorsCmd := bosApi.Command("get-bucket-cors", "get bucket CORS config.")
+	buildGetBucketCorsParser(getBucketCorsCmd, bosApiArgsValue)
+
+	delBucketCorsCmd := bosApi.Command("delete-bucket-cors", "delete bucket CORS config.")
+	buildDelBucketCorsParser(delBucketCorsCmd, bosApiArgsValue)
+
+	putBucketReplicationCmd := bosApi.Command("put-bucket-replication",
+		"put bucket replication rule.")
+	buildPutBucketReplicationParser(putBucketReplicationCmd, bosApiArgsValue)
+
+	getBucketReplicationCmd := bosApi.Command("get-bucket-replication",
+		"get bucket replication rules.")
+	buildGetBucketReplicationParser(getBucketReplicationCmd, bosApiArgsValue)
+
+	delBucketReplicationCmd := bosApi.Command("delete-bucket-replication",
+		"delete bucket replication rule.")
+	buildDelBucketReplicationParser(delBucketReplicationCmd, bosApiArgsValue)
+
+	getReplicationProgressCmd := bosApi.Command("get-bucket-replication-progress",
+		"get progress of bucket replication.")
+	buildGetBucketReplicationProgressParser(getReplicationProgressCmd, bosApiArgsValue)
+
+	putBucketEncryptionCmd := bosApi.Command("put-bucket-encryption",
+		"put bucket server-side encryption config.")
+	buildPutBucketEncryptionParser(putBucketEncryptionCmd, bosApiArgsValue)
+
+	getBucketEncryptionCmd := bosApi.Command("get-bucket-encryption",
+		"get bucket server-side encryption config.")
+	buildGetBucketEncryptionParser(getBucketEncryptionCmd, bosApiArgsValue)
+
+	delBucketEncryptionCmd := bosApi.Command("delete-bucket-encryption",
+		"delete bucket server-side encryption config.")
+	buildDelBucketEncryptionParser(delBucketEncryptionCmd, bosApiArgsValue)
+
+	putWebsiteCmd := bosApi.Command("put-bucket-static-website",
+		"put bucket static website config.")
+	buildPutBucketStaticWebsiteParser(putWebsiteCmd, bosApiArgsValue)
+
+	getWebsiteCmd := bosApi.Command("get-bucket-static-website",
+		"get bucket static website config.")
+	buildGetBucketStaticWebsiteParser(getWebsiteCmd, bosApiArgsValue)
+
+	delWebsiteCmd := bosApi.Command("delete-bucket-static-website",
+		"delete bucket static website config.")
+	buildDelBucketStaticWebsiteParser(delWebsiteCmd, bosApiArgsValue)
+
+	putTrashCmd := bosApi.Command("put-bucket-trash",
+		"enable bucket trash, removed objects are kept in the trash directory.")
+	buildPutBucketTrashParser(putTrashCmd, bosApiArgsValue)
+
+	getTrashCmd := bosApi.Command("get-bucket-trash",
+		"get bucket trash config.")
+	buildGetBucketTrashParser(getTrashCmd, bosApiArgsValue)
+
+	delTrashCmd := bosApi.Command("delete-bucket-trash",
+		"disable bucket trash.")
+	buildDelBucketTrashParser(delTrashCmd, bosApiArgsValue)
+
 	putBucketStorageClassCmd := bosApi.Command("put-bucket-storage-class",
-		"storage class configuration, should be STANDARD or STANDARD_IA or COLD.")
+		"storage class configuration, should be STANDARD, STANDARD_IA, COLD or ARCHIVE.")
 	putBucketStorageClassParser(putBucketStorageClassCmd, bosApiArgsValue)
 
 	getBucketStorageClassCmd := bosApi.Command("get-bucket-storage-class",
@@ -36,30 +36,63 @@ func initBoscliClient() {
 }
 
 type BosArgs struct {
-	bosPath       string
-	srcPath       string
-	dstPath       string
-	storageClass  string
-	syncType      string
-	region        string
-	downLoadTmp   string
-	exclude       []string
-	include       []string
-	excludeTime   []string
-	includeTime   []string
-	excludeDelete []string
-	expires       int
-	concurrency   int
-	all           bool
-	recursive     bool
-	summerize     bool
-	restart       bool
-	force         bool
-	yes           bool
-	dryrun        bool
-	del           bool
-	quiet         bool
-	disableBar    bool
+	bosPath        string
+	srcPath        string
+	dstPath        string
+	storageClass   string
+	syncType       string
+	region         string
+	downLoadTmp    string
+	marker         string
+	output         string
+	sortBy         string
+	byteRange      string
+	name           string
+	size           string
+	mtime          string
+	execCmd        string
+	restoreTier    string
+	olderThan      string
+	bosPaths       []string
+	exclude        []string
+	include        []string
+	excludeTime    []string
+	includeTime    []string
+	excludeDelete  []string
+	objectMeta     boscli.ObjectMetaOptions
+	sse            boscli.SseOptions
+	expires        int
+	concurrency    int
+	pageSize       int
+	maxItems       int
+	depth          int
+	restoreDays    int
+	tail           int64
+	all            bool
+	recursive      bool
+	summerize      bool
+	symlink        bool
+	restart        bool
+	force          bool
+	yes            bool
+	dryrun         bool
+	del            bool
+	quiet          bool
+	disableBar     bool
+	humanReadable  bool
+	decompress     bool
+	print0         bool
+	restoreArchive bool
+	noGuessMime    bool
+	preserveLinks  bool
+	async          bool
+	viaLocal       bool
+}
+
+// set the output format of all bos commands
+func (b *BosArgs) setOutputFormat(context *kingpin.ParseContext) error {
+	boscli.SetOutputFormat(b.output)
+	return nil
 }
 
 // Gen signed url
@@ -77,7 +110,37 @@ func (b *BosArgs) genSignedUrl(context *kingpin.ParseContext) error {
 // list buckets or objects
 func (b *BosArgs) bosList(context *kingpin.ParseContext) error {
 	initBoscliClient()
-	boscliClient.List(b.bosPath, b.all, b.recursive, b.summerize)
+	boscliClient.List(b.bosPath, b.marker, b.pageSize, b.maxItems, b.all, b.recursive,
+		b.summerize, b.symlink)
+	return nil
+}
+
+// show usage of prefixes
+func (b *BosArgs) diskUsage(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.DiskUsage(b.bosPath, b.depth, b.sortBy, b.humanReadable)
+	return nil
+}
+
+// show metadata of objects
+func (b *BosArgs) stat(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.Stat(b.bosPaths)
+	return nil
+}
+
+// search objects
+func (b *BosArgs) find(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.Find(b.bosPath, b.name, b.size, b.mtime, b.storageClass, b.execCmd, b.print0,
+		b.del, b.yes, b.quiet)
+	return nil
+}
+
+// show the content of an object
+func (b *BosArgs) cat(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.Cat(b.bosPath, b.byteRange, b.tail, b.decompress)
 	return nil
 }
 
@@ -95,6 +158,35 @@ func (b *BosArgs) rmoveBucket(context *kingpin.ParseContext) error {
 	return nil
 }
 
+// restore archived objects
+func (b *BosArgs) restore(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.Restore(b.bosPath, b.restoreTier, b.restoreDays, b.recursive, b.quiet)
+	return nil
+}
+
+// change storage class of objects
+func (b *BosArgs) setStorageClass(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.SetStorageClass(b.bosPath, b.storageClass, b.size, b.olderThan, b.include,
+		b.exclude, b.concurrency, b.recursive, b.dryrun, b.quiet)
+	return nil
+}
+
+// recover objects in the trash of bucket
+func (b *BosArgs) undelete(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.Undelete(b.bosPath, b.concurrency, b.recursive, b.dryrun, b.yes, b.quiet)
+	return nil
+}
+
+// create a symlink object
+func (b *BosArgs) link(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.Link(b.srcPath, b.dstPath, b.quiet)
+	return nil
+}
+
 // remove objects
 func (b *BosArgs) rmoveObject(context *kingpin.ParseContext) error {
 	initBoscliClient()
@@ -105,17 +197,41 @@ func (b *BosArgs) rmoveObject(context *kingpin.ParseContext) error {
 // upload, download or copy objects
 func (b *BosArgs) bosCopy(context *kingpin.ParseContext) error {
 	initBoscliClient()
-	boscliClient.Copy(b.srcPath, b.dstPath, b.storageClass, b.downLoadTmp, b.recursive, b.restart, b.quiet,
-		b.yes, b.disableBar)
+	boscliClient.Copy(b.srcPath, b.dstPath, b.storageClass, b.downLoadTmp, b.byteRange,
+		b.objectMeta, b.sse, b.concurrency, b.recursive, b.restart, b.quiet, b.yes,
+		b.disableBar, b.restoreArchive, b.noGuessMime, b.preserveLinks)
+	return nil
+}
+
+// append local file or stdin to an appendable object
+func (b *BosArgs) bosAppend(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.Append(b.srcPath, b.dstPath, b.quiet)
+	return nil
+}
+
+// fetch object from url into BOS
+func (b *BosArgs) bosFetch(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.Fetch(b.srcPath, b.dstPath, b.storageClass, b.async, b.viaLocal, b.quiet)
+	return nil
+}
+
+// move objects among local and BOS
+func (b *BosArgs) bosMove(context *kingpin.ParseContext) error {
+	initBoscliClient()
+	boscliClient.Move(b.srcPath, b.dstPath, b.storageClass, b.downLoadTmp, b.concurrency, b.recursive,
+		b.restart, b.dryrun, b.quiet, b.yes, b.disableBar, b.noGuessMime)
 	return nil
 }
 
 // sync
 func (b *BosArgs) bosSync(context *kingpin.ParseContext) error {
 	initBoscliClient()
-	boscliClient.Sync(b.srcPath, b.dstPath, b.storageClass, b.downLoadTmp, b.syncType, b.exclude, b.include,
-		b.excludeTime, b.includeTime, b.excludeDelete, b.concurrency, b.del, b.dryrun, b.yes, b.quiet, true,
-		b.restart)
+	boscliClient.Sync(b.srcPath, b.dstPath, b.storageClass, b.downLoadTmp, b.syncType,
+		b.objectMeta, b.sse, b.exclude, b.include, b.excludeTime, b.includeTime, b.excludeDelete,
+		b.concurrency, b.del, b.dryrun, b.yes, b.quiet, true, b.restart, b.restoreArchive,
+		b.noGuessMime, b.preserveLinks)
 	return nil
 }
 
@@ -140,15 +256,33 @@ func buildLsParser(lsCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
 	lsCmd.Action(bosArgsValue.bosList)
 	lsCmd.Arg(
 		"BOS_PATH",
-		"BOS path start with \"bos:/\". only 1000 objects would be listed "+
-			"if there are more objects in a bucket. ").
+		"BOS path start with \"bos:/\". only one page (1000 objects by default) would be "+
+			"listed if there are more objects in a bucket, unless --all or --max-items is set.").
 		Default("bos:/").StringVar(&bosArgsValue.bosPath)
 
 	lsCmd.Flag(
 		"all",
-		"list all objects and subdirs.").
+		"list all objects and subdirs, following the next marker across all pages.").
 		Short('a').BoolVar(&bosArgsValue.all)
 
+	lsCmd.Flag(
+		"page-size",
+		"the number of objects and subdirs requested in each page, range 1-1000, "+
+			"default value is 1000.").
+		IntVar(&bosArgsValue.pageSize)
+
+	lsCmd.Flag(
+		"max-items",
+		"the max number of objects and subdirs to list, pages are followed until it is reached. "+
+			"the next marker is printed if there are more.").
+		IntVar(&bosArgsValue.maxItems)
+
+	lsCmd.Flag(
+		"starting-marker",
+		"list objects and subdirs after this marker, use the next marker printed by a "+
+			"previous ls to resume listing.").
+		StringVar(&bosArgsValue.marker)
+
 	lsCmd.Flag(
 		"recursive",
 		"list objects under subdirs").
@@ -158,6 +292,129 @@ func buildLsParser(lsCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
 		"summerize",
 		"show summerization").
 		Short('s').BoolVar(&bosArgsValue.summerize)
+
+	lsCmd.Flag(
+		"symlink",
+		"mark symlink objects and show their targets, it sends a head request for each object.").
+		BoolVar(&bosArgsValue.symlink)
+}
+
+// build parser for du
+func buildDuParser(duCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	duCmd.Action(bosArgsValue.diskUsage)
+	duCmd.Arg(
+		"BOS_PATH",
+		"BOS path start with \"bos:/\", all objects under it are counted.").
+		Required().StringVar(&bosArgsValue.bosPath)
+
+	duCmd.Flag(
+		"depth",
+		"show the usage of sub prefixes up to this depth, 0 means only show the total usage.").
+		Default("1").IntVar(&bosArgsValue.depth)
+
+	duCmd.Flag(
+		"sort",
+		"sort sub prefixes by name, size or count, size and count are sorted in descending order.").
+		Default(boscli.DU_SORT_BY_NAME).EnumVar(&bosArgsValue.sortBy, boscli.DU_SORT_BY_NAME,
+		boscli.DU_SORT_BY_SIZE, boscli.DU_SORT_BY_COUNT)
+
+	duCmd.Flag(
+		"human-readable",
+		"print sizes in human readable format (e.g., 1.5K, 20.0M)").
+		Short('h').BoolVar(&bosArgsValue.humanReadable)
+}
+
+// build parser for stat
+func buildStatParser(statCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	statCmd.Action(bosArgsValue.stat)
+	statCmd.Arg(
+		"BOS_PATH",
+		"BOS paths of objects. when a path ends with \"/\" or only has bucket name, metadata "+
+			"of all objects under it would be shown.").
+		Required().StringsVar(&bosArgsValue.bosPaths)
+}
+
+// build parser for find
+func buildFindParser(findCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	findCmd.Action(bosArgsValue.find)
+	findCmd.Arg(
+		"BOS_PATH",
+		"BOS path start with \"bos:/\", objects under it are searched.").
+		Required().StringVar(&bosArgsValue.bosPath)
+
+	findCmd.Flag(
+		"name",
+		"pattern of object name (the last component of object key), e.g., '*.log'.").
+		StringVar(&bosArgsValue.name)
+
+	findCmd.Flag(
+		"size",
+		"size of object in format [+|-]N[B|K|M|G|T], +N means larger than N, -N means "+
+			"smaller than N, e.g., +100M.").
+		StringVar(&bosArgsValue.size)
+
+	findCmd.Flag(
+		"mtime",
+		"last modified time of object in format [+|-]N[s|m|h|d|w], +N means modified more "+
+			"than N ago, -N means modified in N, unit is day when it is omitted, e.g., -7d.").
+		StringVar(&bosArgsValue.mtime)
+
+	findCmd.Flag(
+		"storage-class",
+		"storage class of object, should be STANDARD, STANDARD_IA, COLD or ARCHIVE").
+		StringVar(&bosArgsValue.storageClass)
+
+	findCmd.Flag(
+		"exec",
+		"run command for each matched object, {} in command is replaced by the BOS path of "+
+			"object, e.g., --exec 'bcecmd bos stat {}'. command is split into arguments as "+
+			"sh does, e.g., --exec \"sh -c 'echo {} | wc -c'\".").
+		PlaceHolder("COMMAND").StringVar(&bosArgsValue.execCmd)
+
+	findCmd.Flag(
+		"print0",
+		"end each BOS path with a null character instead of new line, for xargs -0.").
+		BoolVar(&bosArgsValue.print0)
+
+	findCmd.Flag(
+		"delete",
+		"delete matched objects.").
+		BoolVar(&bosArgsValue.del)
+
+	findCmd.Flag(
+		"yes",
+		"delete without any prompt").
+		Short('y').BoolVar(&bosArgsValue.yes)
+
+	findCmd.Flag(
+		"quiet",
+		"do not display the deleted objects").
+		BoolVar(&bosArgsValue.quiet)
+}
+
+// build parser for cat
+func buildCatParser(catCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	catCmd.Action(bosArgsValue.cat)
+	catCmd.Arg(
+		"BOS_PATH",
+		"BOS path of the object to show.").
+		Required().StringVar(&bosArgsValue.bosPath)
+
+	catCmd.Flag(
+		"range",
+		"only show bytes in range START-END or START-, both START and END are offsets starting "+
+			"from 0 and END is included.").
+		StringVar(&bosArgsValue.byteRange)
+
+	catCmd.Flag(
+		"tail",
+		"only show the last N bytes of the object.").
+		PlaceHolder("N").Int64Var(&bosArgsValue.tail)
+
+	catCmd.Flag(
+		"decompress",
+		"decompress the object by gzip before showing it.").
+		BoolVar(&bosArgsValue.decompress)
 }
 
 // build parser for make bucket
@@ -219,19 +476,186 @@ func buildRmParser(rmCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
 		BoolVar(&bosArgsValue.quiet)
 }
 
+// build parser for restore
+func buildRestoreParser(restoreCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	restoreCmd.Action(bosArgsValue.restore)
+	restoreCmd.Arg(
+		"BOS_PATH",
+		"BOS path start with \"bos:/\"").
+		Required().StringVar(&bosArgsValue.bosPath)
+	restoreCmd.Flag(
+		"recursive",
+		"restore all archived objects under subdirs").
+		Short('r').BoolVar(&bosArgsValue.recursive)
+	restoreCmd.Flag(
+		"tier",
+		"restore tier, Standard or Expedited").
+		Default("Standard").StringVar(&bosArgsValue.restoreTier)
+	restoreCmd.Flag(
+		"days",
+		"how many days the restored objects can be read, between 1 and 30").
+		Default("7").IntVar(&bosArgsValue.restoreDays)
+	restoreCmd.Flag(
+		"quiet",
+		"do not display the operations performed from the specified command").
+		BoolVar(&bosArgsValue.quiet)
+}
+
+// build parser for set-storage-class
+func buildSetStorageClassParser(setStorageClassCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	setStorageClassCmd.Action(bosArgsValue.setStorageClass)
+	setStorageClassCmd.Arg(
+		"BOS_PATH",
+		"BOS path start with \"bos:/\"").
+		Required().StringVar(&bosArgsValue.bosPath)
+	setStorageClassCmd.Flag(
+		"storage-class",
+		"new storage class of objects, should be STANDARD, STANDARD_IA, COLD or ARCHIVE").
+		Required().StringVar(&bosArgsValue.storageClass)
+	setStorageClassCmd.Flag(
+		"recursive",
+		"change storage class of objects under subdirs").
+		Short('r').BoolVar(&bosArgsValue.recursive)
+	setStorageClassCmd.Flag(
+		"include",
+		"multiple patterns of objects to change, e.g: --include '*.log'; "+
+			"--include 'bos:/bucket/path/*'").
+		StringsVar(&bosArgsValue.include)
+	setStorageClassCmd.Flag(
+		"exclude",
+		"multiple patterns of objects to skip, e.g: --exclude '*.jpg'; "+
+			"--exclude 'bos:/bucket/path/*'").
+		StringsVar(&bosArgsValue.exclude)
+	setStorageClassCmd.Flag(
+		"older-than",
+		"only objects modified more than N[s|m|h|d|w] ago, e.g., 30d").
+		PlaceHolder("N").StringVar(&bosArgsValue.olderThan)
+	setStorageClassCmd.Flag(
+		"size",
+		"only objects whose size is [+|-]N[B|K|M|G|T], e.g., +100M is larger than 100M").
+		StringVar(&bosArgsValue.size)
+	setStorageClassCmd.Flag(
+		"concurrency",
+		"max concurrency for recursive change, default value is sync processing num").
+		IntVar(&bosArgsValue.concurrency)
+	setStorageClassCmd.Flag(
+		"dryrun",
+		"list objects whose storage class would be changed, without changing them").
+		BoolVar(&bosArgsValue.dryrun)
+	setStorageClassCmd.Flag(
+		"quiet",
+		"do not display the operations performed from the specified command").
+		BoolVar(&bosArgsValue.quiet)
+}
+
+// build parser for undelete
+func buildUndeleteParser(undeleteCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	undeleteCmd.Action(bosArgsValue.undelete)
+	undeleteCmd.Arg(
+		"BOS_PATH",
+		"BOS path start with \"bos:/\" in the trash directory, like bos:/bucket/.trash/key").
+		Required().StringVar(&bosArgsValue.bosPath)
+	undeleteCmd.Flag(
+		"recursive",
+		"recover all objects under the directory in the trash").
+		Short('r').BoolVar(&bosArgsValue.recursive)
+	undeleteCmd.Flag(
+		"concurrency",
+		"max concurrency for recursive undelete, default value is sync processing num").
+		IntVar(&bosArgsValue.concurrency)
+	undeleteCmd.Flag(
+		"dryrun",
+		"list objects which would be recovered, without copying them").
+		BoolVar(&bosArgsValue.dryrun)
+	undeleteCmd.Flag(
+		"yes",
+		"overwrite objects which exist at the original keys, they are skipped by default").
+		Short('y').BoolVar(&bosArgsValue.yes)
+	undeleteCmd.Flag(
+		"quiet",
+		"do not display the operations performed from the specified command").
+		BoolVar(&bosArgsValue.quiet)
+}
+
+// build parser for append
+func buildAppendParser(appendCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	appendCmd.Action(bosArgsValue.bosAppend)
+	appendCmd.Arg(
+		"SRC",
+		"local file to append, or '-' to append data read from standard input.").
+		Required().StringVar(&bosArgsValue.srcPath)
+	appendCmd.Arg(
+		"DST",
+		"BOS path of the appendable object, it is created if it doesn't exist.").
+		Required().StringVar(&bosArgsValue.dstPath)
+	appendCmd.Flag(
+		"quiet",
+		"do not display the operations performed from the specified command").
+		BoolVar(&bosArgsValue.quiet)
+}
+
+// build parser for fetch
+func buildFetchParser(fetchCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	fetchCmd.Action(bosArgsValue.bosFetch)
+	fetchCmd.Arg(
+		"URL",
+		"http or https url of the object to fetch.").
+		Required().StringVar(&bosArgsValue.srcPath)
+	fetchCmd.Arg(
+		"DST",
+		"BOS path of the fetched object, the file name in URL is used if it ends with '/'.").
+		Required().StringVar(&bosArgsValue.dstPath)
+	fetchCmd.Flag(
+		"async",
+		"BOS fetches the object in background, only the job id is printed").
+		BoolVar(&bosArgsValue.async)
+	fetchCmd.Flag(
+		"via-local",
+		"get the object by bcecmd and upload it with multipart upload, for urls which BOS "+
+			"can't reach").
+		BoolVar(&bosArgsValue.viaLocal)
+	fetchCmd.Flag(
+		"storage-class",
+		"storage class configuration, should be STANDARD, STANDARD_IA, COLD or ARCHIVE").
+		StringVar(&bosArgsValue.storageClass)
+	fetchCmd.Flag(
+		"quiet",
+		"do not display the operations performed from the specified command").
+		BoolVar(&bosArgsValue.quiet)
+}
+
+// build parser for ln
+func buildLnParser(lnCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	lnCmd.Action(bosArgsValue.link)
+	lnCmd.Arg(
+		"TARGET",
+		"BOS path of the object which the symlink points to.").
+		Required().StringVar(&bosArgsValue.srcPath)
+	lnCmd.Arg(
+		"LINK",
+		"BOS path of the symlink in the same bucket, the name of TARGET is used if it ends "+
+			"with '/'.").
+		Required().StringVar(&bosArgsValue.dstPath)
+	lnCmd.Flag(
+		"quiet",
+		"do not display the operations performed from the specified command").
+		BoolVar(&bosArgsValue.quiet)
+}
+
 // build parser for copy
 func buildCopyParser(cpCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
 
 	cpCmd.Action(bosArgsValue.bosCopy)
 	cpCmd.Arg(
 		"SRC",
-		"source path, could be either local or BOS path. When source path, "+
-			"could be either local or BOS path.").
+		"source path, could be either local or BOS path. When source path is '-', "+
+			"read data from standard input and upload it to BOS.").
 		Required().StringVar(&bosArgsValue.srcPath)
 
 	cpCmd.Arg(
 		"DST",
-		"destination path, could be either local or BOS path. ").
+		"destination path, could be either local or BOS path. When destination path is '-', "+
+			"download the object and write it to standard output.").
 		Required().StringVar(&bosArgsValue.dstPath)
 
 	cpCmd.Flag(
@@ -244,11 +668,22 @@ func buildCopyParser(cpCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
 		"restart upload object.").
 		BoolVar(&bosArgsValue.restart)
 
+	cpCmd.Flag(
+		"concurrency",
+		"max concurrency for recursive copy, default value is sync processing num").
+		IntVar(&bosArgsValue.concurrency)
+
 	cpCmd.Flag(
 		"storage-class",
-		"storage class configuration, should be STANDARD or STANDARD_IA or COLD").
+		"storage class configuration, should be STANDARD, STANDARD_IA, COLD or ARCHIVE").
 		StringVar(&bosArgsValue.storageClass)
 
+	cpCmd.Flag(
+		"range",
+		"only download bytes in range START-END or START- of an object, both START and END "+
+			"are offsets starting from 0 and END is included.").
+		StringVar(&bosArgsValue.byteRange)
+
 	cpCmd.Flag(
 		"download-tmp-path",
 		"the path of temporary folder that stores temporary files for breakpoint downloading").
@@ -268,6 +703,157 @@ func buildCopyParser(cpCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
 		"disable-bar",
 		"not display progress bar").
 		BoolVar(&bosArgsValue.disableBar)
+
+	cpCmd.Flag(
+		"restore-archive",
+		"request restore of archived source objects, they are skipped until restored").
+		BoolVar(&bosArgsValue.restoreArchive)
+
+	cpCmd.Flag(
+		"acl",
+		"canned acl of uploaded or copied objects, it can be: 'private' or 'public-read'").
+		StringVar(&bosArgsValue.objectMeta.CannedAcl)
+
+	cpCmd.Flag(
+		"preserve-symlinks",
+		"upload local symbolic links as symlink objects when a directory is uploaded, links "+
+			"to files out of the directory are still followed").
+		BoolVar(&bosArgsValue.preserveLinks)
+
+	buildObjectMetaFlags(cpCmd, bosArgsValue)
+	buildSseFlags(cpCmd, bosArgsValue)
+}
+
+// add flags of http headers and user metadata of uploaded or copied objects, used by cp and sync
+func buildObjectMetaFlags(cmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	cmd.Flag(
+		"content-type",
+		"the Content-Type of uploaded or copied objects").
+		StringVar(&bosArgsValue.objectMeta.ContentType)
+
+	cmd.Flag(
+		"no-guess-mime-type",
+		"don't guess the Content-Type of uploaded files from their extensions and contents.").
+		BoolVar(&bosArgsValue.noGuessMime)
+
+	cmd.Flag(
+		"cache-control",
+		"the Cache-Control of uploaded or copied objects, e.g. max-age=3600").
+		StringVar(&bosArgsValue.objectMeta.CacheControl)
+
+	cmd.Flag(
+		"content-disposition",
+		"the Content-Disposition of uploaded or copied objects").
+		StringVar(&bosArgsValue.objectMeta.ContentDisposition)
+
+	cmd.Flag(
+		"content-encoding",
+		"the Content-Encoding of uploaded or copied objects, e.g. gzip").
+		StringVar(&bosArgsValue.objectMeta.ContentEncoding)
+
+	cmd.Flag(
+		"expires",
+		"the Expires of uploaded or copied objects, in the format of http date, "+
+			"e.g. \"Thu, 01 Dec 2022 16:00:00 GMT\"").
+		StringVar(&bosArgsValue.objectMeta.Expires)
+
+	cmd.Flag(
+		"meta",
+		"user metadata of uploaded or copied objects in the format of key=value, "+
+			"can be repeated.").
+		PlaceHolder("KEY=VALUE").StringsVar(&bosArgsValue.objectMeta.Meta)
+
+	cmd.Flag(
+		"metadata-directive",
+		"copy or replace the metadata of source objects when copying between BOS, it is replace "+
+			"when any header or user metadata is set.").
+		PlaceHolder("copy|replace").StringVar(&bosArgsValue.objectMeta.MetadataDirective)
+}
+
+// add flags of server-side encryption of uploaded, copied or downloaded objects, used by cp and
+// sync
+func buildSseFlags(cmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+	cmd.Flag(
+		"sse",
+		"encrypt uploaded or copied objects by BOS with keys managed by BOS, only AES256 is "+
+			"supported").
+		PlaceHolder("AES256").StringVar(&bosArgsValue.sse.Algorithm)
+
+	cmd.Flag(
+		"sse-c-key-file",
+		"the file of the customer-provided key which encrypts uploaded or copied objects, or "+
+			"decrypts downloaded objects. It contains the 32 bytes key or its base64 encoding.").
+		StringVar(&bosArgsValue.sse.CustomerKeyFile)
+
+	cmd.Flag(
+		"sse-c-copy-source-key-file",
+		"the file of the customer-provided key of source objects when copying between BOS").
+		StringVar(&bosArgsValue.sse.CopySourceKeyFile)
+}
+
+// build parser for move
+func buildMoveParser(mvCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
+
+	mvCmd.Action(bosArgsValue.bosMove)
+	mvCmd.Arg(
+		"SRC",
+		"source path, could be either local or BOS path.").
+		Required().StringVar(&bosArgsValue.srcPath)
+
+	mvCmd.Arg(
+		"DST",
+		"destination path, could be either local or BOS path.").
+		Required().StringVar(&bosArgsValue.dstPath)
+
+	mvCmd.Flag(
+		"recursive",
+		"move objects under subdirs").
+		Short('r').BoolVar(&bosArgsValue.recursive)
+
+	mvCmd.Flag(
+		"restart",
+		"restart upload object.").
+		BoolVar(&bosArgsValue.restart)
+
+	mvCmd.Flag(
+		"concurrency",
+		"max concurrency for recursive move, default value is sync processing num").
+		IntVar(&bosArgsValue.concurrency)
+
+	mvCmd.Flag(
+		"storage-class",
+		"storage class configuration, should be STANDARD, STANDARD_IA, COLD or ARCHIVE").
+		StringVar(&bosArgsValue.storageClass)
+
+	mvCmd.Flag(
+		"download-tmp-path",
+		"the path of temporary folder that stores temporary files for breakpoint downloading").
+		StringVar(&bosArgsValue.downLoadTmp)
+
+	mvCmd.Flag(
+		"dryrun",
+		"list what will be moved, without moving or deleting anything").
+		BoolVar(&bosArgsValue.dryrun)
+
+	mvCmd.Flag(
+		"quiet",
+		"do not display the operations performed from the specified command").
+		BoolVar(&bosArgsValue.quiet)
+
+	mvCmd.Flag(
+		"yes",
+		"without any prompt").
+		Short('y').BoolVar(&bosArgsValue.yes)
+
+	mvCmd.Flag(
+		"disable-bar",
+		"not display progress bar").
+		BoolVar(&bosArgsValue.disableBar)
+
+	mvCmd.Flag(
+		"no-guess-mime-type",
+		"don't guess the Content-Type of uploaded files from their extensions and contents.").
+		BoolVar(&bosArgsValue.noGuessMime)
 }
 
 // build parser for sync
@@ -344,7 +930,7 @@ func buildSyncParser(syncCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
 
 	syncCmd.Flag(
 		"storage-class",
-		"storage class configuration, should be STANDARD or STANDARD_IA or COLD").
+		"storage class configuration, should be STANDARD, STANDARD_IA, COLD or ARCHIVE").
 		StringVar(&bosArgsValue.storageClass)
 
 	syncCmd.Flag(
@@ -377,29 +963,88 @@ func buildSyncParser(syncCmd *kingpin.CmdClause, bosArgsValue *BosArgs) {
 		"restart",
 		"don't transfer from breakpoint.").
 		BoolVar(&bosArgsValue.restart)
+
+	syncCmd.Flag(
+		"restore-archive",
+		"request restore of archived source objects, they are skipped until restored").
+		BoolVar(&bosArgsValue.restoreArchive)
+
+	syncCmd.Flag(
+		"preserve-symlinks",
+		"upload local symbolic links as symlink objects, links to files out of the source "+
+			"directory are still followed").
+		BoolVar(&bosArgsValue.preserveLinks)
+
+	buildObjectMetaFlags(syncCmd, bosArgsValue)
+	buildSseFlags(syncCmd, bosArgsValue)
 }
 
 func BuildBosParser(bos *kingpin.CmdClause) {
 	bosArgsValue := &BosArgs{}
 
+	bos.Flag(
+		"output",
+		"output format of ls, du, stat, find, cp, mv, sync, append, fetch, ln, rm, undelete, "+
+			"restore and set-storage-class, one of text, json, jsonl, csv and tsv. when it is "+
+			"not text, records are printed to stdout, and other messages to stderr. csv and "+
+			"tsv print summaries and next markers to stderr, so stdout is a single table.").
+		Default(boscli.OUTPUT_FORMAT_TEXT).EnumVar(&bosArgsValue.output, boscli.OutputFormats...)
+	bos.PreAction(bosArgsValue.setOutputFormat)
+
 	genCmd := bos.Command("gen_signed_url", "generate signed url with given BOS path.")
 	buildGenParser(genCmd, bosArgsValue)
 
 	lsCmd := bos.Command("ls", "list buckets or objects.").Alias("list")
 	buildLsParser(lsCmd, bosArgsValue)
 
+	duCmd := bos.Command("du", "show the number and size of objects under each prefix.")
+	buildDuParser(duCmd, bosArgsValue)
+
+	statCmd := bos.Command("stat", "show metadata of objects.")
+	buildStatParser(statCmd, bosArgsValue)
+
+	findCmd := bos.Command("find", "search objects by name, size, time and storage class.")
+	buildFindParser(findCmd, bosArgsValue)
+
+	catCmd := bos.Command("cat", "show the content of an object.")
+	buildCatParser(catCmd, bosArgsValue)
+
 	cpCmd := bos.Command("cp", "copy objects among local and BOS.").Alias("copy")
 	buildCopyParser(cpCmd, bosArgsValue)
 
+	mvCmd := bos.Command("mv", "move objects among local and BOS.").Alias("move")
+	buildMoveParser(mvCmd, bosArgsValue)
+
+	appendCmd := bos.Command("append", "append a local file or standard input to an "+
+		"appendable object.")
+	buildAppendParser(appendCmd, bosArgsValue)
+
+	fetchCmd := bos.Command("fetch", "fetch an object from http or https url into BOS.")
+	buildFetchParser(fetchCmd, bosArgsValue)
+
 	mbCmd := bos.Command("mb", "make bucket.").Alias("make-bucket")
 	buildMbParser(mbCmd, bosArgsValue)
 
 	rbCmd := bos.Command("rb", "remove bucket.").Alias("remove-bucket")
 	buildRbParser(rbCmd, bosArgsValue)
 
+	lnCmd := bos.Command("ln", "create a symlink object pointing to another object.")
+	buildLnParser(lnCmd, bosArgsValue)
+
 	rmCmd := bos.Command("rm", "remove objects.").Alias("remove-object")
 	buildRmParser(rmCmd, bosArgsValue)
 
+	undeleteCmd := bos.Command("undelete", "copy objects in the trash back to their "+
+		"original keys.")
+	buildUndeleteParser(undeleteCmd, bosArgsValue)
+
+	restoreCmd := bos.Command("restore", "restore archived objects.")
+	buildRestoreParser(restoreCmd, bosArgsValue)
+
+	setStorageClassCmd := bos.Command("set-storage-class", "change storage class of objects "+
+		"in place.")
+	buildSetStorageClassParser(setStorageClassCmd, bosArgsValue)
+
 	syncCmd := bos.Command("sync", "synchronize objects between local and BOS or between BOS and "+
 		"BOS.")
 	buildSyncParser(syncCmd, bosArgsValue)
@@ -51,6 +51,8 @@ const (
 	CODE_NO_SUCH_UPLOAD              = "NoSuchUpload"
 	CODE_INVALID_PART                = "InvalidPart"
 	CODE_INVALID_PART_ORDER          = "InvalidPartOrder"
+	CODE_OBJECT_UNAPPENDABLE         = "ObjectUnappendable"
+	CODE_OFFSET_INCORRECT            = "OffsetIncorrect"
 )
 
 const (
@@ -130,6 +132,10 @@ func initSuggetionChinese() {
 		"你要删除的Bucket 不为空!"
 	BosSuggetions[CODE_INVALID_ARGUMENT] =
 		"请检查你的输入的参数是否正确！"
+	BosSuggetions[CODE_OBJECT_UNAPPENDABLE] =
+		"只能向Appendable类型的object追加数据，普通上传、分块上传和复制生成的object不能追加！"
+	BosSuggetions[CODE_OFFSET_INCORRECT] =
+		"追加的位置与object当前的长度不一致，可能有其他程序同时在向这个object追加数据！"
 }
 
 func Suggetions(code BosErrorCode, err error) string {
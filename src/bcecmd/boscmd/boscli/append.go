@@ -0,0 +1,191 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+// This module appends the content of a local file or standard input to an appendable object.
+
+package boscli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+import (
+	"bcecmd/boscmd"
+	"bceconf"
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+	"utils/util"
+)
+
+type appendArgs struct {
+	srcPath    string
+	bucketName string
+	objectKey  string
+	chunkSize  int64 // max size of data sent by each append request
+}
+
+type appendResult struct {
+	offset     int64 // offset of the first appended byte
+	nextOffset int64
+	size       int64
+	etag       string
+}
+
+// append: add the content of a local file or standard input to the end of an appendable
+// object, the object is created when it doesn't exist.
+// PARAMS:
+//   srcPath: path of local file, "-" means standard input
+//   dstPath: bos path of the appendable object
+func (b *BosCli) Append(srcPath, dstPath string, quiet bool) {
+	defer finishRecords()
+	Quiet = quiet
+
+	args, retCode, err := b.appendPreProcess(srcPath, dstPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+
+	var src io.Reader = os.Stdin
+	if srcPath != "-" {
+		fd, err := os.Open(srcPath)
+		if err != nil {
+			bcecliAbnormalExistErr(err)
+		}
+		defer fd.Close()
+		src = fd
+	}
+
+	ret, retCode, err := b.appendExecute(args, src)
+	dstPath = BOS_PATH_PREFIX + args.bucketName + "/" + args.objectKey
+	if err != nil {
+		printOperationRecord(SYNC_OP_APPEND, OPERATION_STATUS_FAILED, srcPath, dstPath, err)
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	printIfNotQuiet("%s: %s to %s, %d bytes at offset %d, next offset %d, etag %s\n",
+		SYNC_OP_APPEND, srcPath, dstPath, ret.size, ret.offset, ret.nextOffset, ret.etag)
+	printOperationRecord(SYNC_OP_APPEND, OPERATION_STATUS_SUCCESS, srcPath, dstPath, nil)
+}
+
+// check the request of append
+func (b *BosCli) appendPreProcess(srcPath, dstPath string) (*appendArgs, BosCliErrorCode,
+	error) {
+
+	retCode, err := checkBosPath(dstPath)
+	if err != nil {
+		return nil, retCode, err
+	}
+	bucketName, objectKey := splitBosBucketKey(dstPath)
+	if bucketName == "" {
+		return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("The bucket name is empty")
+	}
+	if objectKey == "" || strings.HasSuffix(objectKey, boscmd.BOS_PATH_SEPARATOR) {
+		return nil, BOSCLI_APPEND_DST_IS_DIR, fmt.Errorf("Can not append to %s", dstPath)
+	}
+
+	if srcPath != "-" && util.DoesDirExist(srcPath) {
+		return nil, BOSCLI_APPEND_SRC_IS_DIR, fmt.Errorf("Cannot append directory %s.",
+			srcPath)
+	}
+
+	// data is sent in chunks of multi upload part size, so stdin is never read into memory
+	chunkSize := int64(PART_SIZE_BASE)
+	if partSize, ok := bceconf.ServerConfigProvider.GetMultiUploadPartSize(); ok &&
+		partSize > 0 {
+		chunkSize = partSize * (1 << 20)
+	}
+	return &appendArgs{
+		srcPath:    srcPath,
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		chunkSize:  chunkSize,
+	}, BOSCLI_OK, nil
+}
+
+// get the metadata of the object to append to, nil is returned when it doesn't exist
+func (b *BosCli) getAppendableStat(bucketName, objectKey string) (*objectStat,
+	BosCliErrorCode, error) {
+
+	stat, err := b.getObjectStat(bucketName, objectKey)
+	if err != nil {
+		if serverErr, ok := err.(*bce.BceServiceError); ok && serverErr.StatusCode == 404 {
+			return nil, BOSCLI_OK, nil
+		}
+		return nil, BOSCLI_EMPTY_CODE, err
+	}
+	if !stat.isAppendable {
+		return nil, BOSCLI_APPEND_OBJECT_NOT_APPENDABLE, fmt.Errorf("%s%s/%s is a %s object, "+
+			"it isn't appendable", BOS_PATH_PREFIX, bucketName, objectKey, stat.objectType)
+	}
+	return stat, BOSCLI_OK, nil
+}
+
+// append the content of src chunk by chunk, the next offset returned by each request must be
+// the offset of this request plus the size of chunk, otherwise another writer is appending to
+// the same object, and the content of object is not what we expect.
+func (b *BosCli) appendExecute(args *appendArgs, src io.Reader) (*appendResult,
+	BosCliErrorCode, error) {
+
+	stat, retCode, err := b.getAppendableStat(args.bucketName, args.objectKey)
+	if err != nil {
+		return nil, retCode, err
+	}
+
+	ret := &appendResult{}
+	if stat != nil {
+		ret.offset = stat.nextAppendOffset
+		ret.nextOffset = stat.nextAppendOffset
+		ret.etag = stat.etag
+	}
+	chunk := make([]byte, args.chunkSize)
+	for {
+		n, readErr := io.ReadFull(src, chunk)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return ret, BOSCLI_EMPTY_CODE, readErr
+		}
+		// an empty source still creates the object when it doesn't exist
+		if n == 0 && (stat != nil || ret.size > 0) {
+			break
+		}
+
+		body, err := bce.NewBodyFromBytes(chunk[:n])
+		if err != nil {
+			return ret, BOSCLI_EMPTY_CODE, err
+		}
+		result, err := b.bosClient.AppendObject(args.bucketName, args.objectKey, body,
+			&api.AppendObjectArgs{Offset: ret.nextOffset})
+		if err != nil {
+			if serverErr, ok := err.(*bce.BceServiceError); ok &&
+				serverErr.Code == boscmd.CODE_OFFSET_INCORRECT {
+				return ret, BOSCLI_APPEND_OFFSET_MISMATCH, fmt.Errorf("object is changed by "+
+					"another writer, data isn't appended at offset %d", ret.nextOffset)
+			}
+			return ret, BOSCLI_EMPTY_CODE, err
+		}
+		expected := ret.nextOffset + int64(n)
+		if result.NextAppendOffset != expected {
+			return ret, BOSCLI_APPEND_OFFSET_MISMATCH, fmt.Errorf("object is changed by "+
+				"another writer, next offset should be %d, but it is %d", expected,
+				result.NextAppendOffset)
+		}
+		ret.nextOffset = expected
+		ret.size += int64(n)
+		ret.etag = strings.Trim(result.ETag, "\"")
+
+		if readErr != nil {
+			break
+		}
+	}
+	return ret, BOSCLI_OK, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+package boscli
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+import (
+	"utils/util"
+)
+
+type appendPreProcessType struct {
+	srcPath    string
+	dstPath    string
+	bucketName string
+	objectKey  string
+	code       BosCliErrorCode
+}
+
+func TestAppendPreProcess(t *testing.T) {
+	if err := os.MkdirAll("./test_append_dir", 0755); err != nil {
+		t.Errorf("append.go pre: %s", err)
+		return
+	}
+	defer os.RemoveAll("./test_append_dir")
+
+	testCases := []appendPreProcessType{
+		//1
+		appendPreProcessType{
+			srcPath: "-",
+			dstPath: "/bucket/key",
+			code:    BOSCLI_BOSPATH_IS_INVALID,
+		},
+		//2
+		appendPreProcessType{
+			srcPath: "-",
+			dstPath: "bos:/",
+			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		//3
+		appendPreProcessType{
+			srcPath: "-",
+			dstPath: "bos:/bucket",
+			code:    BOSCLI_APPEND_DST_IS_DIR,
+		},
+		//4
+		appendPreProcessType{
+			srcPath: "-",
+			dstPath: "bos:/bucket/dir/",
+			code:    BOSCLI_APPEND_DST_IS_DIR,
+		},
+		//5
+		appendPreProcessType{
+			srcPath: "./test_append_dir",
+			dstPath: "bos:/bucket/key",
+			code:    BOSCLI_APPEND_SRC_IS_DIR,
+		},
+		//6
+		appendPreProcessType{
+			srcPath:    "-",
+			dstPath:    "bos:/bucket/dir/key",
+			bucketName: "bucket",
+			objectKey:  "dir/key",
+			code:       BOSCLI_OK,
+		},
+		//7 local file is checked when it is opened
+		appendPreProcessType{
+			srcPath:    "./test_append_file",
+			dstPath:    "bos:/bucket/key",
+			bucketName: "bucket",
+			objectKey:  "key",
+			code:       BOSCLI_OK,
+		},
+	}
+	for i, tCase := range testCases {
+		args, code, err := testBosCli.appendPreProcess(tCase.srcPath, tCase.dstPath)
+		util.ExpectEqual("append.go pre I", i+1, t.Errorf, tCase.code, code)
+		if code != BOSCLI_OK {
+			util.ExpectEqual("append.go pre II", i+1, t.Errorf, true, err != nil)
+			continue
+		}
+		util.ExpectEqual("append.go pre III", i+1, t.Errorf, tCase.srcPath, args.srcPath)
+		util.ExpectEqual("append.go pre IV", i+1, t.Errorf, tCase.bucketName, args.bucketName)
+		util.ExpectEqual("append.go pre V", i+1, t.Errorf, tCase.objectKey, args.objectKey)
+		util.ExpectEqual("append.go pre VI", i+1, t.Errorf, true, args.chunkSize > 0)
+	}
+}
+
+type appendExecuteType struct {
+	objectKey  string
+	content    string
+	appends    []string
+	offset     int64
+	nextOffset int64
+	size       int64
+	etag       string
+	code       BosCliErrorCode
+	isSuc      bool
+}
+
+func TestAppendExecute(t *testing.T) {
+	testCases := []appendExecuteType{
+		//1 create the object, data is sent in chunks
+		appendExecuteType{
+			objectKey:  "404",
+			content:    "0123456789",
+			appends:    []string{"404@0:4", "404@4:4", "404@8:2"},
+			nextOffset: 10,
+			size:       10,
+			etag:       "etag",
+			code:       BOSCLI_OK,
+			isSuc:      true,
+		},
+		//2
+		appendExecuteType{
+			objectKey:  "appendable",
+			content:    "01234567",
+			appends:    []string{"appendable@10:4", "appendable@14:4"},
+			offset:     10,
+			nextOffset: 18,
+			size:       8,
+			etag:       "etag",
+			code:       BOSCLI_OK,
+			isSuc:      true,
+		},
+		//3 nothing to append
+		appendExecuteType{
+			objectKey:  "appendable",
+			offset:     10,
+			nextOffset: 10,
+			etag:       "old",
+			code:       BOSCLI_OK,
+			isSuc:      true,
+		},
+		//4 an empty appendable object is created
+		appendExecuteType{
+			objectKey: "404",
+			appends:   []string{"404@0:0"},
+			etag:      "etag",
+			code:      BOSCLI_OK,
+			isSuc:     true,
+		},
+		//5 normal object isn't appendable
+		appendExecuteType{
+			objectKey: "a/b",
+			content:   "0123",
+			code:      BOSCLI_APPEND_OBJECT_NOT_APPENDABLE,
+		},
+		//6 another writer appended to the object
+		appendExecuteType{
+			objectKey:  "race",
+			content:    "01234567",
+			appends:    []string{"race@10:4"},
+			offset:     10,
+			nextOffset: 10,
+			code:       BOSCLI_APPEND_OFFSET_MISMATCH,
+		},
+		//7
+		appendExecuteType{
+			objectKey:  "offset-error",
+			content:    "0123",
+			offset:     10,
+			nextOffset: 10,
+			code:       BOSCLI_APPEND_OFFSET_MISMATCH,
+		},
+		//8
+		appendExecuteType{
+			objectKey:  "append-error",
+			content:    "0123",
+			offset:     10,
+			nextOffset: 10,
+			code:       BOSCLI_EMPTY_CODE,
+		},
+	}
+	fakeClient, ok := testBosCli.bosClient.(*fakeBosClientForBos)
+	if !ok {
+		t.Errorf("append.go exe: bos client is not fake")
+		return
+	}
+	for i, tCase := range testCases {
+		fakeClient.appends = nil
+		args := &appendArgs{
+			srcPath:    "-",
+			bucketName: "bucket",
+			objectKey:  tCase.objectKey,
+			chunkSize:  4,
+		}
+		ret, code, err := testBosCli.appendExecute(args, strings.NewReader(tCase.content))
+		util.ExpectEqual("append.go exe I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		util.ExpectEqual("append.go exe II", i+1, t.Errorf, tCase.code, code)
+		util.ExpectEqual("append.go exe III", i+1, t.Errorf, tCase.appends, fakeClient.appends)
+		if ret == nil {
+			continue
+		}
+		util.ExpectEqual("append.go exe IV", i+1, t.Errorf, tCase.offset, ret.offset)
+		util.ExpectEqual("append.go exe V", i+1, t.Errorf, tCase.nextOffset, ret.nextOffset)
+		util.ExpectEqual("append.go exe VI", i+1, t.Errorf, tCase.size, ret.size)
+		if tCase.isSuc {
+			util.ExpectEqual("append.go exe VII", i+1, t.Errorf, tCase.etag, ret.etag)
+		}
+	}
+}
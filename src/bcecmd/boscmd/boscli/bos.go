@@ -16,14 +16,19 @@ package boscli
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
 	"strings"
-	"sync"
-	"time"
 )
 
 import (
 	"bcecmd/boscmd"
 	"bceconf"
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
 	"utils/util"
 )
 
@@ -31,6 +36,13 @@ var (
 	Quiet                 bool
 	DisableBar            bool // display or not display progress bar
 	IsConcurrentOperation bool
+
+	// messages are printed to stderr when stdout is used to output data
+	msgOutput io.Writer = os.Stdout
+
+	// storage classes whose usage are shown by du
+	duStorageClasses = []string{api.STORAGE_CLASS_STANDARD, api.STORAGE_CLASS_STANDARD_IA,
+		api.STORAGE_CLASS_COLD, api.STORAGE_CLASS_ARCHIVE}
 )
 
 // Create new BosCli
@@ -54,6 +66,7 @@ func NewBosCli() *BosCli {
 type BosCli struct {
 	bosClient bosClientInterface
 	handler   handlerInterface
+	sse       *sseArgs // server-side encryption of cp and sync, set by withSse
 }
 
 type operateResult struct {
@@ -61,6 +74,15 @@ type operateResult struct {
 	failed    int
 }
 
+type listArgs struct {
+	bucketName string
+	objectKey  string
+	marker     string
+	pageSize   int
+	maxItems   int
+	symlink    bool // show the targets of symlink objects
+}
+
 type genSignedUrlArgs struct {
 	bucketName string
 	objectKey  string
@@ -111,84 +133,1526 @@ func (b *BosCli) genSignedUrlPreProcess(bosPath string, expires int, haveSetExpi
 
 // List buckets or objects
 // param: must have BOS_PATH attribute.
-func (b *BosCli) List(bosPath string, all bool, recursive bool, summary bool) {
+//   marker   : list objects after marker
+//   pageSize : the number of objects and pres requested in each page, 0 means 1000
+//   maxItems : the max number of objects and pres to list, 0 means no limit
+//   symlink  : show the targets of symlink objects, every listed object is headed to know
+//              whether it is a symlink, which costs a request per object
+func (b *BosCli) List(bosPath, marker string, pageSize, maxItems int, all, recursive,
+	summary, symlink bool) {
+
+	defer finishRecords()
+	args, retCode, err := b.listPreProcess(bosPath, marker, pageSize, maxItems)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	args.symlink = symlink
+
+	if args.bucketName == "" {
+		_, err = b.listBuckets(summary)
+	} else {
+		err = b.listObjects(args, all, recursive, summary)
+	}
+	if err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// request check and preprocessing for list
+func (b *BosCli) listPreProcess(bosPath, marker string, pageSize, maxItems int) (*listArgs,
+	BosCliErrorCode, error) {
+
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return nil, retCode, err
+	}
+
+	if pageSize == 0 {
+		pageSize = MAX_LIST_PAGE_SIZE
+	} else if pageSize < 0 || pageSize > MAX_LIST_PAGE_SIZE {
+		return nil, BOSCLI_LIST_PAGE_SIZE_INVALID, fmt.Errorf("page size must be in range "+
+			"1-%d", MAX_LIST_PAGE_SIZE)
+	}
+	if maxItems < 0 {
+		return nil, BOSCLI_LIST_MAX_ITEMS_LESS_ZERO, fmt.Errorf("max items can't be less than 0")
+	}
+
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" && (marker != "" || pageSize != MAX_LIST_PAGE_SIZE || maxItems != 0) {
+		return nil, BOSCLI_LIST_BUCKETS_NOT_SUPPORT_PAGING, fmt.Errorf("paging is not " +
+			"supported when listing buckets")
+	}
+
+	return &listArgs{
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		marker:     marker,
+		pageSize:   pageSize,
+		maxItems:   maxItems,
+	}, BOSCLI_OK, nil
+}
+
+// implement list bucket
+func (b *BosCli) listBuckets(sum bool) (int, error) {
+	buckets, err := b.bosClient.ListBuckets()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, bucket := range buckets.Buckets {
+		localTime, _ := util.TranUTCtoLocalTime(bucket.CreationDate, BOS_TIME_FORMT,
+			LOCAL_TIME_FROMT)
+		if isStructuredOutput() {
+			printBucketRecord(bucket.Name, bucket.Location, bucket.CreationDate)
+		} else {
+			fmt.Printf("  %s  %7s  %s\n", localTime, bucket.Location, bucket.Name)
+		}
+	}
+	bucketsNum := len(buckets.Buckets)
+	if sum {
+		if isStructuredOutput() {
+			printTrailerRecord(outputRecord{
+				{"type", RECORD_TYPE_SUMMARY},
+				{"buckets", bucketsNum},
+			})
+		} else {
+			fmt.Printf(" Total Buckets: %d\n", bucketsNum)
+		}
+	}
+	return bucketsNum, nil
+}
+
+// implement list objects
+// when max items is set, pages are followed until max items objects and pres are listed.
+func (b *BosCli) listObjects(args *listArgs, all, recursive, summary bool) error {
+	var (
+		preNum     int64
+		objectNum  int64
+		objectSize int64
+		lastPath   string
+	)
+
+	objectsList := NewObjectListIterator(b.bosClient, nil, args.bucketName, args.objectKey,
+		args.marker, all || args.maxItems > 0, recursive, true, false, args.pageSize)
+
+	// objects are printed in batches when --symlink is set, so the objects in a batch can be
+	// headed concurrently
+	var pending []*listFileResult
+	batchSize := 1
+	if args.symlink {
+		batchSize = LIST_SYMLINK_BATCH_SIZE
+	}
+	flush := func() {
+		if args.symlink {
+			b.setSymlinkTargets(args.bucketName, pending)
+		}
+		for _, listResult := range pending {
+			printListResult(args.bucketName, listResult, args.symlink)
+		}
+		pending = pending[:0]
+	}
+	defer flush()
+
+	for {
+		listResult, err := objectsList.next()
+		if err != nil {
+			return err
+		}
+		if listResult.ended {
+			flush()
+			if listResult.endInfo.isTruncated {
+				printListNextMarker(listResult.endInfo.nextMarker)
+			}
+			break
+		}
+		// there are more objects or pres, the last listed one is the marker to resume from
+		if args.maxItems > 0 && preNum+objectNum >= int64(args.maxItems) {
+			flush()
+			printListNextMarker(lastPath)
+			break
+		}
+		if listResult.isDir {
+			preNum++
+			lastPath = listResult.dir.path
+		} else {
+			objectSize += int64(listResult.file.size)
+			objectNum++
+			lastPath = listResult.file.path
+		}
+		if pending = append(pending, listResult); len(pending) >= batchSize {
+			flush()
+		}
+	}
+	// print summary
+	if summary && isStructuredOutput() {
+		printTrailerRecord(outputRecord{
+			{"type", RECORD_TYPE_SUMMARY},
+			{"prefixes", preNum},
+			{"objects", objectNum},
+			{"size", objectSize},
+		})
+	} else if summary {
+		fmt.Printf("Total PRE(s): %d\n", preNum)
+		fmt.Printf("Total Object(s): %d\n", objectNum)
+		fmt.Printf("Total Size Of Objects(byte): %d\n", objectSize)
+	}
+	return nil
+}
+
+// print a pre or an object listed by ls
+func printListResult(bucketName string, listResult *listFileResult, symlink bool) {
+	if listResult.isDir {
+		if isStructuredOutput() {
+			printPrefixRecord(bucketName, listResult.dir, symlink)
+		} else {
+			fmt.Printf("  %19s %11s  %15s  %s\n", "", "", "PRE", listResult.dir.key)
+		}
+		return
+	}
+	object := listResult.file
+	if isStructuredOutput() {
+		printObjectRecord(bucketName, object, symlink)
+		return
+	}
+	localTime := util.TranTimestamptoLocalTime(object.mtime, LOCAL_TIME_FROMT)
+	key := object.key
+	if object.linkTarget != "" {
+		key += " -> " + object.linkTarget
+	}
+	fmt.Printf("  %s %15d  %11s  %s\n", localTime, object.size, object.storageClass, key)
+}
+
+// get the targets of symlink objects by heading the listed objects concurrently.
+// An object which can't be headed is shown as a normal object, the error is printed unless
+// the object has been deleted after it is listed.
+func (b *BosCli) setSymlinkTargets(bucketName string, listResults []*listFileResult) {
+	executor := newConcurrentExecutor(LIST_SYMLINK_CONCURRENCY)
+	for _, listResult := range listResults {
+		if listResult.isDir {
+			continue
+		}
+		object := listResult.file
+		executor.execute(func() error {
+			stat, err := b.getObjectStat(bucketName, object.path)
+			if err != nil {
+				if serverErr, ok := err.(*bce.BceServiceError); !ok ||
+					serverErr.StatusCode != 404 {
+					fmt.Fprintf(msgOutput, "Error: stat %s%s/%s: %s\n", BOS_PATH_PREFIX,
+						bucketName, object.path, getErrorMsg(err))
+				}
+				return err
+			}
+			object.linkTarget = stat.symlinkTarget
+			return nil
+		})
+	}
+	executor.wait()
+}
+
+// print the marker which can be passed to --starting-marker to continue listing
+func printListNextMarker(marker string) {
+	if isStructuredOutput() {
+		printNextMarkerRecord(marker)
+		return
+	}
+	fmt.Println("more......")
+	fmt.Printf("Next marker: %s\n", marker)
+}
+
+type diskUsageArgs struct {
+	bucketName string
+	objectKey  string
+	depth      int
+	sortBy     string
+}
+
+// usage of the objects under a prefix
+type prefixUsage struct {
+	prefix       string
+	objects      int64
+	size         int64
+	classObjects map[string]int64
+	classSize    map[string]int64
+}
+
+func newPrefixUsage(prefix string) *prefixUsage {
+	return &prefixUsage{
+		prefix:       prefix,
+		classObjects: make(map[string]int64),
+		classSize:    make(map[string]int64),
+	}
+}
+
+func (p *prefixUsage) add(size int64, storageClass string) {
+	if storageClass == "" {
+		storageClass = DEFAULT_STORAGE_CLASS
+	}
+	p.objects++
+	p.size += size
+	p.classObjects[storageClass]++
+	p.classSize[storageClass] += size
+}
+
+// du: show the number of objects and the size of objects under each sub prefix
+// PARAMS:
+//   bosPath       : bos path, must have bucket name
+//   depth         : show the usage of sub prefixes whose depth is not greater than depth,
+//                   0 means only show the usage of bos path
+//   sortBy        : sort sub prefixes by name, size or count
+//   humanReadable : print sizes in human readable format (e.g., 1.5K, 20.0M)
+func (b *BosCli) DiskUsage(bosPath string, depth int, sortBy string, humanReadable bool) {
+	defer finishRecords()
+	args, retCode, err := b.diskUsagePreProcess(bosPath, depth, sortBy)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+
+	usages, total, err := b.diskUsageExecute(args)
+	if err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+	printDiskUsage(args.bucketName, usages, total, humanReadable)
+}
+
+// request check and preprocessing for du
+func (b *BosCli) diskUsagePreProcess(bosPath string, depth int, sortBy string) (
+	*diskUsageArgs, BosCliErrorCode, error) {
+
 	retCode, err := checkBosPath(bosPath)
 	if err != nil {
+		return nil, retCode, err
+	}
+
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("bucket name is empty")
+	}
+
+	if depth < 0 {
+		return nil, BOSCLI_DU_DEPTH_LESS_ZERO, fmt.Errorf("depth can't be less than 0")
+	}
+
+	switch sortBy {
+	case "":
+		sortBy = DU_SORT_BY_NAME
+	case DU_SORT_BY_NAME, DU_SORT_BY_SIZE, DU_SORT_BY_COUNT:
+	default:
+		return nil, BOSCLI_DU_SORT_TYPE_INVALID, fmt.Errorf("don't support sort type %s",
+			sortBy)
+	}
+
+	return &diskUsageArgs{
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		depth:      depth,
+		sortBy:     sortBy,
+	}, BOSCLI_OK, nil
+}
+
+// list all objects under bos path, and aggregate them by sub prefixes.
+// RETURN:
+//   usages of sub prefixes in sorted order, and usage of bos path.
+func (b *BosCli) diskUsageExecute(args *diskUsageArgs) ([]*prefixUsage, *prefixUsage, error) {
+	var (
+		total       = newPrefixUsage(args.objectKey)
+		usagesOfPre = make(map[string]*prefixUsage)
+	)
+
+	// sub prefixes start after the last separator of object key, the same as ls
+	baseDir := args.objectKey[:strings.LastIndex(args.objectKey, boscmd.BOS_PATH_SEPARATOR)+1]
+
+	objectsList := NewObjectListIterator(b.bosClient, nil, args.bucketName, args.objectKey,
+		"", true, true, true, false, MAX_LIST_PAGE_SIZE)
+	for {
+		listResult, err := objectsList.next()
+		if err != nil {
+			return nil, nil, err
+		}
+		if listResult.ended {
+			break
+		}
+		if listResult.isDir {
+			continue
+		}
+		object := listResult.file
+		total.add(object.size, object.storageClass)
+
+		// the last component is the name of object
+		components := strings.Split(object.key, boscmd.BOS_PATH_SEPARATOR)
+		prefix := baseDir
+		for i := 0; i < len(components)-1 && i < args.depth; i++ {
+			prefix += components[i] + boscmd.BOS_PATH_SEPARATOR
+			usage, ok := usagesOfPre[prefix]
+			if !ok {
+				usage = newPrefixUsage(prefix)
+				usagesOfPre[prefix] = usage
+			}
+			usage.add(object.size, object.storageClass)
+		}
+	}
+
+	usages := make([]*prefixUsage, 0, len(usagesOfPre))
+	for _, usage := range usagesOfPre {
+		usages = append(usages, usage)
+	}
+	sortPrefixUsages(usages, args.sortBy)
+	return usages, total, nil
+}
+
+// sort by name in ascending order, by size or count in descending order
+func sortPrefixUsages(usages []*prefixUsage, sortBy string) {
+	sort.Slice(usages, func(i, j int) bool {
+		switch sortBy {
+		case DU_SORT_BY_SIZE:
+			if usages[i].size != usages[j].size {
+				return usages[i].size > usages[j].size
+			}
+		case DU_SORT_BY_COUNT:
+			if usages[i].objects != usages[j].objects {
+				return usages[i].objects > usages[j].objects
+			}
+		}
+		return usages[i].prefix < usages[j].prefix
+	})
+}
+
+// print usages of sub prefixes, and print usage of bos path at last
+func printDiskUsage(bucketName string, usages []*prefixUsage, total *prefixUsage,
+	humanReadable bool) {
+
+	if isStructuredOutput() {
+		for _, usage := range usages {
+			printUsageRecord(RECORD_TYPE_USAGE, bucketName, usage)
+		}
+		printUsageRecord(RECORD_TYPE_SUMMARY, bucketName, total)
+		return
+	}
+
+	formatSize := func(size int64) string {
+		if humanReadable {
+			return getHumanReadableSize(size)
+		}
+		return strconv.FormatInt(size, 10)
+	}
+	printLine := func(objects, size string, classSize []string, prefix string) {
+		fmt.Printf("  %10s %15s", objects, size)
+		for _, val := range classSize {
+			fmt.Printf(" %15s", val)
+		}
+		fmt.Printf("  %s\n", prefix)
+	}
+
+	printLine("OBJECTS", "SIZE", duStorageClasses, "PREFIX")
+	for _, usage := range append(usages, total) {
+		classSize := make([]string, len(duStorageClasses))
+		for i, storageClass := range duStorageClasses {
+			classSize[i] = formatSize(usage.classSize[storageClass])
+		}
+		printLine(strconv.FormatInt(usage.objects, 10), formatSize(usage.size), classSize,
+			BOS_PATH_PREFIX+bucketName+boscmd.BOS_PATH_SEPARATOR+usage.prefix)
+	}
+}
+
+type findArgs struct {
+	bucketName string
+	objectKey  string
+	filter     *findFilter
+	execArgs   []string
+	print0     bool
+	del        bool
+}
+
+// find: search objects under bos path with predicates, matched objects are printed, deleted or
+// passed to a command.
+// PARAMS:
+//   name         : pattern of the last component of object key, e.g., '*.log'
+//   size         : [+|-]N[B|K|M|G|T], e.g., +100M means larger than 100M
+//   mtime        : [+|-]N[s|m|h|d|w], e.g., -7d means modified in 7 days
+//   storageClass : only find objects in this storage class
+//   execCmd      : run command for each object, {} in command is replaced by bos path of object,
+//                  it is split into arguments as sh does
+//   print0       : end the path of each object with '\0' instead of new line
+//   del          : delete matched objects
+func (b *BosCli) Find(bosPath, name, size, mtime, storageClass, execCmd string, print0, del,
+	yes, quiet bool) {
+
+	defer finishRecords()
+	Quiet = quiet
+	args, retCode, err := b.findPreProcess(bosPath, name, size, mtime, storageClass, execCmd,
+		print0, del)
+	if retCode != BOSCLI_OK {
 		bcecliAbnormalExistCodeErr(retCode, err)
 	}
 
-	bucketName, objectKey := splitBosBucketKey(bosPath)
-	if bucketName == "" {
-		_, err = b.listBuckets(summary)
-	} else {
-		err = b.listObjects(bucketName, objectKey, all, recursive, summary)
+	if args.del && !yes {
+		if !util.PromptConfirm("Do you really want to DELETE all matched objects in %s%s/%s?",
+			BOS_PATH_PREFIX, args.bucketName, args.objectKey) {
+			return
+		}
+	}
+
+	ret, err := b.findExecute(args)
+	if args.del {
+		printIfNotQuiet("[%d] objects removed on remote.\n", ret.successed)
+	}
+	if err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+	if ret.failed > 0 {
+		bcecliAbnormalExistCode(BOSCLI_EMPTY_CODE)
+	}
+}
+
+// request check and preprocessing for find
+func (b *BosCli) findPreProcess(bosPath, name, size, mtime, storageClass, execCmd string,
+	print0, del bool) (*findArgs, BosCliErrorCode, error) {
+
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return nil, retCode, err
+	}
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("bucket name is empty")
+	}
+
+	execArgs, err := splitCommandWords(execCmd)
+	if err != nil {
+		return nil, BOSCLI_FIND_EXEC_INVALID, err
+	}
+	if len(execArgs) > 0 && del {
+		return nil, BOSCLI_FIND_EXEC_DELETE_TOG, fmt.Errorf("--exec and --delete can not be " +
+			"used together")
+	}
+
+	filter, retCode, err := newFindFilter(name, size, mtime, storageClass)
+	if retCode != BOSCLI_OK {
+		return nil, retCode, err
+	}
+
+	return &findArgs{
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		filter:     filter,
+		execArgs:   execArgs,
+		print0:     print0,
+		del:        del,
+	}, BOSCLI_OK, nil
+}
+
+// list objects under bos path, and handle objects matched by filter one by one.
+// Objects are deleted in batch, so that deleting millions of objects is fast.
+func (b *BosCli) findExecute(args *findArgs) (*executeResult, error) {
+	var (
+		ret     = &executeResult{}
+		keyList = make([]string, 0, MAX_DELETE_NUM_EACH_TIME)
+	)
+
+	deleteKeys := func() error {
+		deleted, failed, err := b.handler.utilDeleteObjects(b.bosClient, args.bucketName,
+			keyList)
+		ret.successed += deleted
+		ret.failed += failed
+		keyList = keyList[:0]
+		return err
+	}
+
+	objectsList := NewObjectListIterator(b.bosClient, nil, args.bucketName, args.objectKey,
+		"", true, true, true, false, MAX_LIST_PAGE_SIZE)
+	for {
+		listResult, err := objectsList.next()
+		if err != nil {
+			return ret, err
+		}
+		if listResult.ended {
+			break
+		}
+		if listResult.isDir {
+			continue
+		}
+		object := listResult.file
+		matched, err := args.filter.match(object)
+		if err != nil {
+			return ret, err
+		}
+		if !matched {
+			continue
+		}
+
+		if args.del {
+			keyList = append(keyList, object.path)
+			if len(keyList) == MAX_DELETE_NUM_EACH_TIME {
+				if err := deleteKeys(); err != nil {
+					return ret, err
+				}
+			}
+		} else if len(args.execArgs) > 0 {
+			bosPath := BOS_PATH_PREFIX + args.bucketName + boscmd.BOS_PATH_SEPARATOR + object.path
+			if err := runFindExec(buildFindExecArgs(args.execArgs, bosPath)); err != nil {
+				fmt.Fprintf(msgOutput, "Error: exec %s: %s\n", bosPath, err)
+				ret.failed++
+			} else {
+				ret.successed++
+			}
+		} else {
+			printFoundObject(args.bucketName, object, args.print0)
+			ret.successed++
+		}
+	}
+
+	if len(keyList) > 0 {
+		if err := deleteKeys(); err != nil {
+			return ret, err
+		}
+	}
+	return ret, nil
+}
+
+// replace {} in arguments of --exec with bos path of object
+func buildFindExecArgs(execArgs []string, bosPath string) []string {
+	cmdArgs := make([]string, len(execArgs))
+	for i, arg := range execArgs {
+		cmdArgs[i] = strings.Replace(arg, "{}", bosPath, -1)
+	}
+	return cmdArgs
+}
+
+// run command of --exec, the command inherits stdout and stderr of bcecmd
+func runFindExec(cmdArgs []string) error {
+	cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func printFoundObject(bucketName string, object *fileDetail, print0 bool) {
+	if isStructuredOutput() {
+		printObjectRecord(bucketName, object, false)
+		return
+	}
+	end := "\n"
+	if print0 {
+		end = "\x00"
+	}
+	fmt.Print(BOS_PATH_PREFIX + bucketName + boscmd.BOS_PATH_SEPARATOR + object.path + end)
+}
+
+type statArgs struct {
+	bucketName string
+	objectKey  string
+	isPrefix   bool
+}
+
+// metadata of an object shown by stat
+type objectStat struct {
+	bucketName           string
+	key                  string
+	contentType          string
+	contentLength        int64
+	etag                 string
+	crc32                string
+	storageClass         string
+	restoreStatus        string // restore status of archived object
+	restoreExpiry        string
+	mtime                int64
+	serverSideEncryption string
+	objectType           string
+	symlinkTarget        string
+	isSymlink            bool
+	isMultipart          bool
+	isAppendable         bool
+	nextAppendOffset     int64 // only set for appendable object
+	userMeta             map[string]string
+}
+
+// stat: show the metadata of objects
+// PARAMS:
+//   bosPaths: bos paths of objects, metadata of all objects under a prefix is shown when the
+//             path ends with "/" or only has bucket name
+func (b *BosCli) Stat(bosPaths []string) {
+	defer finishRecords()
+	argsList, retCode, err := b.statPreProcess(bosPaths)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+
+	// show other objects when failed to stat one of them
+	failed := 0
+	for i, args := range argsList {
+		if err := b.statExecute(args); err != nil {
+			failed++
+			fmt.Fprintf(msgOutput, "Error: stat %s: %s\n", bosPaths[i], getErrorMsg(err))
+		}
+	}
+	if failed > 0 {
+		bcecliAbnormalExistCode(BOSCLI_EMPTY_CODE)
+	}
+}
+
+// check all bos paths before showing metadata
+func (b *BosCli) statPreProcess(bosPaths []string) ([]*statArgs, BosCliErrorCode, error) {
+	argsList := make([]*statArgs, 0, len(bosPaths))
+	for _, bosPath := range bosPaths {
+		retCode, err := checkBosPath(bosPath)
+		if err != nil {
+			return nil, retCode, err
+		}
+		bucketName, objectKey := splitBosBucketKey(bosPath)
+		if bucketName == "" {
+			return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("bucket name of %s is empty",
+				bosPath)
+		}
+		argsList = append(argsList, &statArgs{
+			bucketName: bucketName,
+			objectKey:  objectKey,
+			isPrefix: objectKey == "" ||
+				strings.HasSuffix(objectKey, boscmd.BOS_PATH_SEPARATOR),
+		})
+	}
+	return argsList, BOSCLI_OK, nil
+}
+
+// show the metadata of an object or all objects under a prefix
+func (b *BosCli) statExecute(args *statArgs) error {
+	if !args.isPrefix {
+		stat, err := b.getObjectStat(args.bucketName, args.objectKey)
+		if err != nil {
+			return err
+		}
+		printObjectStat(stat)
+		return nil
+	}
+
+	objectsList := NewObjectListIterator(b.bosClient, nil, args.bucketName, args.objectKey,
+		"", true, true, true, false, MAX_LIST_PAGE_SIZE)
+	for {
+		listResult, err := objectsList.next()
+		if err != nil {
+			return err
+		}
+		if listResult.ended {
+			break
+		}
+		if listResult.isDir {
+			continue
+		}
+		stat, err := b.getObjectStat(args.bucketName, listResult.file.path)
+		if err != nil {
+			return err
+		}
+		printObjectStat(stat)
+	}
+	return nil
+}
+
+// get the metadata of an object from the headers of head object
+func (b *BosCli) getObjectStat(bucketName, objectKey string) (*objectStat, error) {
+	headers, err := headObject(b.bosClient, bucketName, objectKey)
+	if err != nil {
+		if serverErr, ok := err.(*bce.BceServiceError); ok && serverErr.StatusCode == 404 {
+			serverErr.Code = boscmd.CODE_NO_SUCH_KEY
+			serverErr.Message = "Object don't exist!"
+		}
+		return nil, err
+	}
+	stat, err := parseObjectStat(bucketName, objectKey, headers)
+	if err != nil {
+		return nil, err
+	}
+	stat.symlinkTarget = b.getSymlinkTarget(stat)
+	return stat, nil
+}
+
+// parse headers of head object, the names of headers are case insensitive
+func parseObjectStat(bucketName, objectKey string, headers map[string]string) (*objectStat,
+	error) {
+
+	lowerHeaders := make(map[string]string, len(headers))
+	for name, val := range headers {
+		lowerHeaders[strings.ToLower(name)] = val
+	}
+
+	stat := &objectStat{
+		bucketName:           bucketName,
+		key:                  objectKey,
+		contentType:          lowerHeaders["content-type"],
+		etag:                 strings.Trim(lowerHeaders["etag"], "\""),
+		crc32:                lowerHeaders["x-bce-content-crc32"],
+		storageClass:         lowerHeaders["x-bce-storage-class"],
+		serverSideEncryption: lowerHeaders["x-bce-server-side-encryption"],
+		objectType:           lowerHeaders["x-bce-object-type"],
+		symlinkTarget:        lowerHeaders["x-bce-symlink-target"],
+		userMeta:             make(map[string]string),
+	}
+	if stat.storageClass == "" {
+		stat.storageClass = DEFAULT_STORAGE_CLASS
+	}
+	if stat.objectType == "" {
+		stat.objectType = OBJECT_TYPE_NORMAL
+	}
+	stat.restoreStatus, stat.restoreExpiry = parseRestoreStatus(lowerHeaders["x-bce-restore"])
+
+	if val, ok := lowerHeaders["content-length"]; ok {
+		size, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content length %s", val)
+		}
+		stat.contentLength = size
+	}
+	if val, ok := lowerHeaders["last-modified"]; ok {
+		mtime, err := util.TranUTCTimeStringToTimeStamp(val, BOS_HTTP_TIME_FORMT)
+		if err != nil {
+			return nil, err
+		}
+		stat.mtime = mtime
+	}
+	for name, val := range lowerHeaders {
+		if strings.HasPrefix(name, USER_META_HEADER_PREFIX) {
+			stat.userMeta[name[len(USER_META_HEADER_PREFIX):]] = val
+		}
+	}
+
+	stat.isSymlink = stat.symlinkTarget != "" ||
+		strings.EqualFold(stat.objectType, OBJECT_TYPE_SYMLINK)
+	// the etag of objects uploaded by multipart upload starts with "-"
+	stat.isMultipart = strings.EqualFold(stat.objectType, OBJECT_TYPE_MULTIPART) ||
+		strings.HasPrefix(stat.etag, "-")
+
+	// data is appended at the end of object if next append offset isn't returned
+	stat.isAppendable = strings.EqualFold(stat.objectType, OBJECT_TYPE_APPENDABLE)
+	if stat.isAppendable {
+		stat.nextAppendOffset = stat.contentLength
+		if val, ok := lowerHeaders["x-bce-next-append-offset"]; ok {
+			offset, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid next append offset %s", val)
+			}
+			stat.nextAppendOffset = offset
+		}
+	}
+	return stat, nil
+}
+
+func printObjectStat(stat *objectStat) {
+	if isStructuredOutput() {
+		printObjectStatRecord(stat)
+		return
+	}
+
+	yesOrNo := func(val bool) string {
+		if val {
+			return "yes"
+		}
+		return "no"
+	}
+	encryption := stat.serverSideEncryption
+	if encryption == "" {
+		encryption = "none"
+	}
+	symlink := yesOrNo(stat.isSymlink)
+	if stat.symlinkTarget != "" {
+		symlink += " -> " + stat.symlinkTarget
+	}
+	appendable := yesOrNo(stat.isAppendable)
+	if stat.isAppendable {
+		appendable += fmt.Sprintf(", next offset %d", stat.nextAppendOffset)
+	}
+	restore := stat.restoreStatus
+	if stat.restoreExpiry != "" {
+		restore += ", expires " + stat.restoreExpiry
+	}
+
+	fmt.Printf("%s%s/%s\n", BOS_PATH_PREFIX, stat.bucketName, stat.key)
+	fmt.Printf("  %-16s %s\n", "Content-Type:", stat.contentType)
+	fmt.Printf("  %-16s %d\n", "Content-Length:", stat.contentLength)
+	fmt.Printf("  %-16s %s\n", "ETag:", stat.etag)
+	fmt.Printf("  %-16s %s\n", "CRC32:", stat.crc32)
+	fmt.Printf("  %-16s %s\n", "Storage-Class:", stat.storageClass)
+	fmt.Printf("  %-16s %s\n", "Restore:", restore)
+	fmt.Printf("  %-16s %s\n", "Last-Modified:",
+		util.TranTimestamptoLocalTime(stat.mtime, LOCAL_TIME_FROMT))
+	fmt.Printf("  %-16s %s\n", "Encryption:", encryption)
+	fmt.Printf("  %-16s %s\n", "Object-Type:", stat.objectType)
+	fmt.Printf("  %-16s %s\n", "Symlink:", symlink)
+	fmt.Printf("  %-16s %s\n", "Multipart:", yesOrNo(stat.isMultipart))
+	fmt.Printf("  %-16s %s\n", "Appendable:", appendable)
+	if len(stat.userMeta) == 0 {
+		fmt.Printf("  %-16s %s\n", "User-Meta:", "none")
+		return
+	}
+	fmt.Printf("  %-16s\n", "User-Meta:")
+	for _, name := range sortedMetaNames(stat.userMeta) {
+		fmt.Printf("    %s: %s\n", name, stat.userMeta[name])
+	}
+}
+
+type catArgs struct {
+	bucketName string
+	objectKey  string
+	start      int64
+	end        int64 // -1 means reading to the end of object
+	tail       int64
+	decompress bool
+}
+
+// cat: write the content of an object to standard output
+// PARAMS:
+//   byteRange: only show bytes in range START-END or START-
+//   tail: only show the last tail bytes, 0 means not set
+func (b *BosCli) Cat(bosPath, byteRange string, tail int64, decompress bool) {
+	// the content of object is written to stdout, so messages must not be mixed into it
+	msgOutput = os.Stderr
+	defer finishRecords()
+
+	args, retCode, err := b.catPreProcess(bosPath, byteRange, tail, decompress)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	if err := b.catExecute(args, os.Stdout); err != nil {
+		bcecliAbnormalExistCodeErr(BOSCLI_EMPTY_CODE, err)
+	}
+}
+
+func (b *BosCli) catPreProcess(bosPath, byteRange string, tail int64, decompress bool) (
+	*catArgs, BosCliErrorCode, error) {
+
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return nil, retCode, err
+	}
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("The bucket name is empty")
+	}
+	if objectKey == "" || strings.HasSuffix(objectKey, boscmd.BOS_PATH_SEPARATOR) {
+		return nil, BOSCLI_CAT_OBJECT_IS_DIR, fmt.Errorf("Can not show the content of %s",
+			bosPath)
+	}
+	if byteRange != "" && tail != 0 {
+		return nil, BOSCLI_CAT_RANGE_TAIL_TOG, fmt.Errorf("--range and --tail can not be " +
+			"used together")
+	}
+	if tail < 0 {
+		return nil, BOSCLI_CAT_TAIL_LESS_ZERO, fmt.Errorf("--tail must not be less than 0")
+	}
+	if decompress && (byteRange != "" || tail != 0) {
+		return nil, BOSCLI_CAT_DECOMPRESS_WITH_RANGE, fmt.Errorf("--decompress can not be " +
+			"used with --range or --tail")
+	}
+
+	args := &catArgs{
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		end:        -1,
+		tail:       tail,
+		decompress: decompress,
+	}
+	if byteRange != "" {
+		args.start, args.end, err = parseByteRange(byteRange)
+		if err != nil {
+			return nil, BOSCLI_RANGE_INVALID, err
+		}
+	}
+	return args, BOSCLI_OK, nil
+}
+
+func (b *BosCli) catExecute(args *catArgs, stream io.Writer) error {
+	start := args.start
+	if args.tail > 0 {
+		objectMeta, err := getObjectMeta(b.bosClient, args.bucketName, args.objectKey)
+		if err != nil {
+			return err
+		}
+		// there is no range of an empty object
+		if objectMeta.size == 0 {
+			return nil
+		}
+		start = objectMeta.size - args.tail
+		if start < 0 {
+			start = 0
+		}
+	}
+	return b.handler.utilGetObjectRange(b.bosClient, args.bucketName, args.objectKey, start,
+		args.end, args.decompress, stream)
+}
+
+type restoreArgs struct {
+	bucketName string
+	objectKey  string
+	isDir      bool
+	tier       string
+	days       int
+}
+
+// restore: restore archived objects so that they can be read for some days
+// PARAMS:
+//   bosPath   : bos path of an object, or a prefix when recursive is true
+//   tier      : Standard or Expedited
+//   days      : how many days the restored objects can be read
+//   recursive : restore all archived objects under the prefix
+func (b *BosCli) Restore(bosPath, tier string, days int, recursive, quiet bool) {
+	defer finishRecords()
+	Quiet = quiet
+
+	args, retCode, err := b.restorePreProcess(bosPath, tier, days, recursive)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+
+	ret, err := b.restoreExecute(args)
+	if !args.isDir {
+		if err != nil {
+			bcecliAbnormalExistErr(err)
+		}
+		return
+	}
+	if err != nil {
+		printIfNotQuiet("Restore interrupted: %s, [%d] success, [%d] failure\n", bosPath,
+			ret.successed, ret.failed)
+		printOperationSummaryRecord(bosPath, "", ret, true)
+		bcecliAbnormalExistErr(err)
+	}
+	printIfNotQuiet("Restore done: %s, [%d] success, [%d] failure\n", bosPath, ret.successed,
+		ret.failed)
+	printOperationSummaryRecord(bosPath, "", ret, false)
+	if ret.failed > 0 {
+		bcecliAbnormalExistCode(BOSCLI_EMPTY_CODE)
+	}
+}
+
+// check the request of restore
+func (b *BosCli) restorePreProcess(bosPath, tier string, days int, recursive bool) (
+	*restoreArgs, BosCliErrorCode, error) {
+
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return nil, retCode, err
+	}
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("bucket name is empty")
+	}
+	if days < 1 || days > MAX_RESTORE_DAYS {
+		return nil, BOSCLI_RESTORE_DAYS_INVALID, fmt.Errorf("days of restore must be "+
+			"between 1 and %d", MAX_RESTORE_DAYS)
+	}
+	restoreTier, retCode := getRestoreTierFromStr(tier)
+	if retCode != BOSCLI_OK {
+		return nil, retCode, fmt.Errorf("unsupported restore tier %s", tier)
+	}
+
+	isDir := objectKey == "" || strings.HasSuffix(objectKey, boscmd.BOS_PATH_SEPARATOR)
+	if isDir && !recursive {
+		return nil, BOSCLI_RESTORE_DIR_MUST_USE_RECURSIVE, fmt.Errorf("Please use -r to "+
+			"restore objects under %s", bosPath)
+	}
+	return &restoreArgs{
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		isDir:      recursive,
+		tier:       restoreTier,
+		days:       days,
+	}, BOSCLI_OK, nil
+}
+
+// restore an object, or all archived objects under the prefix when args.isDir is true, objects
+// of other storage classes are ignored.
+func (b *BosCli) restoreExecute(args *restoreArgs) (*executeResult, error) {
+	ret := &executeResult{}
+	if !args.isDir {
+		if err := b.restoreObject(args, args.objectKey); err != nil {
+			ret.failed++
+			return ret, err
+		}
+		ret.successed++
+		return ret, nil
+	}
+
+	objectsList := NewObjectListIterator(b.bosClient, nil, args.bucketName, args.objectKey,
+		"", true, true, true, false, MAX_LIST_PAGE_SIZE)
+	for {
+		listResult, err := objectsList.next()
+		if err != nil {
+			return ret, err
+		}
+		if listResult.ended {
+			break
+		}
+		if listResult.isDir || listResult.file.storageClass != api.STORAGE_CLASS_ARCHIVE {
+			continue
+		}
+		if err := b.restoreObject(args, listResult.file.path); err != nil {
+			fmt.Fprintf(msgOutput, "Error occurs when restore object %s%s/%s: %s\n",
+				BOS_PATH_PREFIX, args.bucketName, listResult.file.path, getErrorMsg(err))
+			ret.failed++
+		} else {
+			ret.successed++
+		}
+	}
+	return ret, nil
+}
+
+func (b *BosCli) restoreObject(args *restoreArgs, objectKey string) error {
+	bosPath := BOS_PATH_PREFIX + args.bucketName + "/" + objectKey
+	err := b.bosClient.RestoreObject(args.bucketName, objectKey, args.days, args.tier)
+	if err != nil {
+		printOperationRecord(SYNC_OP_RESTORE, OPERATION_STATUS_FAILED, bosPath, "", err)
+		return err
+	}
+	printIfNotQuiet("%s: %s, tier %s, %d days\n", SYNC_OP_RESTORE, bosPath, args.tier,
+		args.days)
+	printOperationRecord(SYNC_OP_RESTORE, OPERATION_STATUS_SUCCESS, bosPath, "", nil)
+	return nil
+}
+
+// check whether an archived source object can be read by cp or sync. An archived object is
+// skipped until it is restored, and a restore is requested for it when restoreArchive is true.
+// PARAMS:
+//   storageClass: storage class of the object, it is got from metadata when it is empty
+// RETURN:
+//   true when the object is skipped
+func (b *BosCli) skipArchivedObject(bosClient bosClientInterface, opName, bucketName,
+	objectKey, storageClass, dst string, restoreArchive bool) bool {
+
+	if storageClass != "" && storageClass != api.STORAGE_CLASS_ARCHIVE {
+		return false
+	}
+	src := BOS_PATH_PREFIX + bucketName + "/" + objectKey
+
+	// the metadata is also needed by archived objects for their restore status
+	meta, err := getObjectMeta(bosClient, bucketName, objectKey)
+	if err != nil {
+		// let the transfer report this error
+		return false
+	}
+	if meta.storageClass != api.STORAGE_CLASS_ARCHIVE {
+		return false
+	}
+	status, _ := parseRestoreStatus(meta.restore)
+	if status == RESTORE_STATUS_RESTORED {
+		return false
+	}
+
+	var reason error
+	switch {
+	case status == RESTORE_STATUS_ONGOING:
+		reason = fmt.Errorf("archived object is being restored")
+	case !restoreArchive:
+		reason = fmt.Errorf("archived object must be restored first, use 'bos restore' " +
+			"or --restore-archive")
+	default:
+		err = bosClient.RestoreObject(bucketName, objectKey, DEFAULT_RESTORE_DAYS,
+			api.RESTORE_TIER_STANDARD)
+		if err != nil {
+			reason = fmt.Errorf("failed to restore archived object: %s", getErrorMsg(err))
+		} else {
+			reason = fmt.Errorf("restore of archived object is requested, retry after it " +
+				"is restored")
+			printOperationRecord(SYNC_OP_RESTORE, OPERATION_STATUS_SUCCESS, src, "", nil)
+		}
+	}
+	printIfNotQuiet("Skip %s: %s\n", src, reason.Error())
+	printOperationRecord(opName, OPERATION_STATUS_SKIPPED, src, dst, reason)
+	return true
+}
+
+type setStorageClassArgs struct {
+	bucketName    string
+	objectKey     string
+	isDir         bool
+	storageClass  string
+	patternFilter *bosFilter  // --include and --exclude
+	filter        *findFilter // --size and --older-than
+	concurrency   int
+	dryrun        bool
+}
+
+// set-storage-class: change the storage class of objects in place by copying them to themselves
+// PARAMS:
+//   size      : size of objects in the format of find, e.g., +100M
+//   olderThan : only objects modified before N[s|m|h|d|w], e.g., 30d
+//   include   : only objects match these patterns
+//   exclude   : skip objects match these patterns
+//   dryrun    : only show which objects would be changed
+func (b *BosCli) SetStorageClass(bosPath, storageClass, size, olderThan string, include,
+	exclude []string, concurrency int, recursive, dryrun, quiet bool) {
+
+	defer finishRecords()
+	Quiet = quiet
+
+	args, retCode, err := b.setStorageClassPreProcess(bosPath, storageClass, size, olderThan,
+		include, exclude, concurrency, recursive)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	args.dryrun = dryrun
+
+	// progress bars of concurrent multipart copies would be mixed up
+	if args.isDir && args.concurrency > 1 {
+		DisableBar = true
+	}
+
+	ret, err := b.setStorageClassExecute(args)
+	if err != nil {
+		printIfNotQuiet("Set storage class interrupted: %s to %s, [%d] success, [%d] failure\n",
+			bosPath, args.storageClass, ret.successed, ret.failed)
+		printOperationSummaryRecord(bosPath, args.storageClass, ret, true)
+		bcecliAbnormalExistErr(err)
+	}
+	if dryrun {
+		return
+	}
+	printIfNotQuiet("Set storage class done: %s to %s, [%d] success, [%d] failure\n", bosPath,
+		args.storageClass, ret.successed, ret.failed)
+	printOperationSummaryRecord(bosPath, args.storageClass, ret, false)
+	if ret.failed > 0 {
+		bcecliAbnormalExistCode(BOSCLI_EMPTY_CODE)
+	}
+}
+
+// check the request of set-storage-class and build filters of objects
+func (b *BosCli) setStorageClassPreProcess(bosPath, storageClass, size, olderThan string,
+	include, exclude []string, concurrency int, recursive bool) (*setStorageClassArgs,
+	BosCliErrorCode, error) {
+
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return nil, retCode, err
+	}
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("bucket name is empty")
+	}
+	isDir := objectKey == "" || strings.HasSuffix(objectKey, boscmd.BOS_PATH_SEPARATOR)
+	if isDir && !recursive {
+		return nil, BOSCLI_SET_STORAGE_CLASS_MUST_RECURSIVE, fmt.Errorf("Please use -r "+
+			"to change storage class of objects under %s", bosPath)
+	}
+
+	if storageClass == "" {
+		return nil, BOSCLI_STORAGE_CLASS_IS_EMPTY, fmt.Errorf("storage class is empty")
+	}
+	dstStorageClass, retCode := getStorageClassFromStr(storageClass)
+	if retCode != BOSCLI_OK {
+		return nil, retCode, fmt.Errorf("don't support storage-class %s", storageClass)
+	}
+
+	// objects older than N are the objects modified more than N ago
+	mtime := ""
+	if olderThan != "" {
+		if strings.HasPrefix(olderThan, "+") || strings.HasPrefix(olderThan, "-") {
+			return nil, BOSCLI_OLDER_THAN_INVALID, fmt.Errorf("Invalid --older-than %s",
+				olderThan)
+		}
+		mtime = "+" + olderThan
+	}
+	filter, retCode, err := newFindFilter("", size, mtime, "")
+	if retCode == BOSCLI_FIND_MTIME_INVALID {
+		return nil, BOSCLI_OLDER_THAN_INVALID, err
+	} else if retCode != BOSCLI_OK {
+		return nil, retCode, err
+	}
+
+	args := &setStorageClassArgs{
+		bucketName:   bucketName,
+		objectKey:    objectKey,
+		isDir:        recursive,
+		storageClass: dstStorageClass,
+		filter:       filter,
+	}
+	if len(include) > 0 || len(exclude) > 0 {
+		args.patternFilter, retCode, err = newSyncFilter(exclude, include, nil, nil, false)
+		if retCode != BOSCLI_OK {
+			return nil, retCode, err
+		}
+	}
+	if recursive {
+		if args.concurrency, retCode, err = getConcurrency(concurrency); err != nil {
+			return nil, retCode, err
+		}
+	}
+	return args, BOSCLI_OK, nil
+}
+
+// change storage class of the object or objects under the prefix, objects which already have the
+// storage class are ignored.
+func (b *BosCli) setStorageClassExecute(args *setStorageClassArgs) (*executeResult, error) {
+	objectsList := NewObjectListIterator(b.bosClient, nil, args.bucketName, args.objectKey,
+		"", true, true, args.isDir, false, MAX_LIST_PAGE_SIZE)
+	executor := newConcurrentExecutor(args.concurrency)
+
+	var (
+		listResult *listFileResult
+		err        error
+	)
+	for {
+		listResult, err = objectsList.next()
+		if err != nil || listResult.ended {
+			break
+		}
+		if listResult.isDir {
+			continue
+		}
+		object := listResult.file
+		if strings.HasSuffix(object.path, boscmd.BOS_PATH_SEPARATOR) {
+			continue
+		}
+
+		var matched bool
+		if matched, err = b.matchSetStorageClassFilters(args, object); err != nil {
+			break
+		} else if !matched {
+			continue
+		}
+
+		// copying an object to itself with the same storage class is invalid
+		if isTheSameBucketAndObject(args.bucketName, object.path, args.bucketName, object.path,
+			args.storageClass, object.storageClass) {
+			continue
+		}
+		oldStorageClass := object.storageClass
+		if oldStorageClass == "" {
+			oldStorageClass = DEFAULT_STORAGE_CLASS
+		}
+
+		bosPath := BOS_PATH_PREFIX + args.bucketName + "/" + object.path
+		if args.dryrun {
+			printIfNotQuiet("%s: %s from %s to %s\n", SYNC_OP_SET_STORAGE_CLASS, bosPath,
+				oldStorageClass, args.storageClass)
+			printOperationRecord(SYNC_OP_SET_STORAGE_CLASS, OPERATION_STATUS_DRYRUN, bosPath,
+				bosPath, nil)
+			continue
+		}
+		if b.skipArchivedObject(b.bosClient, SYNC_OP_SET_STORAGE_CLASS, args.bucketName,
+			object.path, object.storageClass, bosPath, false) {
+			executor.fail()
+			continue
+		}
+		executor.execute(func() error {
+			err := b.handler.utilSetStorageClass(b.bosClient, args.bucketName, object.path,
+				args.storageClass, object.size, object.mtime, object.gtime, false)
+			if err != nil {
+				fmt.Fprintf(msgOutput, "Error occurs when set storage class of %s: %s\n",
+					bosPath, getErrorMsg(err))
+				printOperationRecord(SYNC_OP_SET_STORAGE_CLASS, OPERATION_STATUS_FAILED,
+					bosPath, bosPath, err)
+			}
+			return err
+		})
+	}
+	return executor.wait(), err
+}
+
+// whether object matches --include, --exclude, --size and --older-than
+func (b *BosCli) matchSetStorageClassFilters(args *setStorageClassArgs,
+	object *fileDetail) (bool, error) {
+
+	if args.patternFilter != nil {
+		filtered, err := args.patternFilter.PatternFilter(args.bucketName +
+			boscmd.BOS_PATH_SEPARATOR + object.path)
+		if err != nil || filtered {
+			return false, err
+		}
+	}
+	return args.filter.match(object)
+}
+
+type undeleteArgs struct {
+	bucketName  string
+	objectKey   string
+	trashDir    string
+	isDir       bool
+	concurrency int
+	dryrun      bool
+	yes         bool // overwrite objects which exist at the original keys
+}
+
+// undelete: recover objects in the trash of bucket. When the trash is enabled, an object removed
+// from bos:/bucket/key is kept as bos:/bucket/<trash dir>/key, and it is copied back to key.
+// PARAMS:
+//   bosPath   : bos path of an object in the trash, or a prefix in the trash when recursive is true
+//   recursive : recover all objects under the prefix
+//   dryrun    : only show which objects would be recovered
+//   yes       : overwrite the objects at the original keys, they are skipped by default
+func (b *BosCli) Undelete(bosPath string, concurrency int, recursive, dryrun, yes,
+	quiet bool) {
+	defer finishRecords()
+	Quiet = quiet
+
+	args, retCode, err := b.undeletePreProcess(bosPath, concurrency, recursive)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	args.dryrun = dryrun
+	args.yes = yes
+
+	// progress bars of concurrent multipart copies would be mixed up
+	if args.isDir && args.concurrency > 1 {
+		DisableBar = true
+	}
+
+	ret, err := b.undeleteExecute(args)
+	if err != nil {
+		printIfNotQuiet("Undelete interrupted: %s, [%d] success, [%d] failure, [%d] skipped\n",
+			bosPath, ret.successed, ret.failed, ret.skipped)
+		printOperationSummaryRecord(bosPath, "", ret, true)
+		bcecliAbnormalExistErr(err)
+	}
+	if dryrun {
+		return
+	}
+	printIfNotQuiet("Undelete done: %s, [%d] success, [%d] failure, [%d] skipped\n", bosPath,
+		ret.successed, ret.failed, ret.skipped)
+	printOperationSummaryRecord(bosPath, "", ret, false)
+	if ret.failed > 0 {
+		bcecliAbnormalExistCode(BOSCLI_EMPTY_CODE)
+	}
+}
+
+// check the request of undelete, the path must be in the trash directory of bucket
+func (b *BosCli) undeletePreProcess(bosPath string, concurrency int, recursive bool) (
+	*undeleteArgs, BosCliErrorCode, error) {
+
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return nil, retCode, err
+	}
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("bucket name is empty")
+	}
+	isDir := objectKey == "" || strings.HasSuffix(objectKey, boscmd.BOS_PATH_SEPARATOR)
+	if isDir && !recursive {
+		return nil, BOSCLI_UNDELETE_DIR_MUST_USE_RECURSIVE, fmt.Errorf("Please use -r to "+
+			"undelete objects under %s", bosPath)
+	}
+
+	trashDir, err := b.getTrashDir(bucketName)
+	if err != nil {
+		return nil, BOSCLI_EMPTY_CODE, err
+	}
+	// both bos:/bucket and bos:/bucket/<trash dir> mean the whole trash
+	trashPrefix := trashDir + boscmd.BOS_PATH_SEPARATOR
+	if recursive && (objectKey == "" || objectKey == trashDir) {
+		objectKey = trashPrefix
+	}
+	if !strings.HasPrefix(objectKey, trashPrefix) {
+		return nil, BOSCLI_UNDELETE_NOT_IN_TRASH, fmt.Errorf("%s is not in the trash "+
+			"directory %s%s/%s", bosPath, BOS_PATH_PREFIX, bucketName, trashPrefix)
+	}
+
+	args := &undeleteArgs{
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		trashDir:   trashDir,
+		isDir:      recursive,
 	}
-	if err != nil {
-		bcecliAbnormalExistErr(err)
+	if recursive {
+		if args.concurrency, retCode, err = getConcurrency(concurrency); err != nil {
+			return nil, retCode, err
+		}
 	}
+	return args, BOSCLI_OK, nil
 }
 
-// implement list bucket
-func (b *BosCli) listBuckets(sum bool) (int, error) {
-	buckets, err := b.bosClient.ListBuckets()
+// get the trash directory of bucket. The default one is returned when the trash is disabled,
+// since objects removed before it was disabled are still there.
+func (b *BosCli) getTrashDir(bucketName string) (string, error) {
+	ret, err := b.bosClient.GetBucketTrash(bucketName)
 	if err != nil {
-		return 0, err
-	}
-
-	for _, bucket := range buckets.Buckets {
-		localTime, _ := util.TranUTCtoLocalTime(bucket.CreationDate, BOS_TIME_FORMT,
-			LOCAL_TIME_FROMT)
-		fmt.Printf("  %s  %7s  %s\n", localTime, bucket.Location, bucket.Name)
+		if serverErr, ok := err.(*bce.BceServiceError); ok && serverErr.StatusCode == 404 {
+			return DEFAULT_TRASH_DIR, nil
+		}
+		return "", err
 	}
-	bucketsNum := len(buckets.Buckets)
-	if sum {
-		fmt.Printf(" Total Buckets: %d\n", bucketsNum)
+	trashDir := strings.Trim(ret.TrashDir, boscmd.BOS_PATH_SEPARATOR)
+	if trashDir == "" {
+		return DEFAULT_TRASH_DIR, nil
 	}
-	return bucketsNum, nil
+	return trashDir, nil
 }
 
-// implement list objects
-func (b *BosCli) listObjects(bucketName, objectKey string, all, recursive, summary bool) error {
+// copy the object or objects under the prefix in trash back to their original keys
+func (b *BosCli) undeleteExecute(args *undeleteArgs) (*executeResult, error) {
+	objectsList := NewObjectListIterator(b.bosClient, nil, args.bucketName, args.objectKey,
+		"", true, true, args.isDir, false, MAX_LIST_PAGE_SIZE)
+	executor := newConcurrentExecutor(args.concurrency)
+	trashPrefix := args.trashDir + boscmd.BOS_PATH_SEPARATOR
+
 	var (
-		preNum     int64
-		objectNum  int64
-		objectSize int64
+		listResult *listFileResult
+		err        error
 	)
-
-	objectsList := NewObjectListIterator(b.bosClient, nil, bucketName, objectKey, "", all,
-		recursive, true, false, 1000)
 	for {
-		listResult, err := objectsList.next()
-		if err != nil {
-			return err
-		}
-		if listResult.ended {
-			if listResult.endInfo.isTruncated {
-				fmt.Println("more......")
-			}
+		listResult, err = objectsList.next()
+		if err != nil || listResult.ended {
 			break
 		}
 		if listResult.isDir {
-			// Print pre
-			preNum++
-			fmt.Printf("  %19s %11s  %15s  %s\n", "", "", "PRE", listResult.dir.key)
-		} else {
-			// Print objects
-			object := listResult.file
-			localTime := util.TranTimestamptoLocalTime(object.mtime, LOCAL_TIME_FROMT)
-			fmt.Printf("  %s %15d  %11s  %s\n", localTime, object.size, object.storageClass,
-				object.key)
-			objectSize += int64(object.size)
-			objectNum++
+			continue
+		}
+		object := listResult.file
+		if strings.HasSuffix(object.path, boscmd.BOS_PATH_SEPARATOR) ||
+			!strings.HasPrefix(object.path, trashPrefix) {
+			continue
+		}
+		objectKey := strings.TrimPrefix(object.path, trashPrefix)
+
+		srcPath := BOS_PATH_PREFIX + args.bucketName + "/" + object.path
+		dstPath := BOS_PATH_PREFIX + args.bucketName + "/" + objectKey
+		if args.dryrun {
+			printIfNotQuiet("%s: %s to %s\n", SYNC_OP_UNDELETE, srcPath, dstPath)
+			printOperationRecord(SYNC_OP_UNDELETE, OPERATION_STATUS_DRYRUN, srcPath, dstPath,
+				nil)
+			continue
 		}
+		if b.skipArchivedObject(b.bosClient, SYNC_OP_UNDELETE, args.bucketName, object.path,
+			object.storageClass, dstPath, false) {
+			executor.fail()
+			continue
+		}
+		executor.execute(func() error {
+			// the object at the original key may be uploaded again after it was removed
+			if !args.yes {
+				exist, err := b.doesObjectExist(args.bucketName, objectKey)
+				if err == nil && exist {
+					printIfNotQuiet("%s exists, skip: %s\n", dstPath, srcPath)
+					printOperationRecord(SYNC_OP_UNDELETE, OPERATION_STATUS_SKIPPED, srcPath,
+						dstPath, fmt.Errorf("destination exists, use -y to overwrite it"))
+					return errOperationSkipped
+				}
+				if err != nil {
+					fmt.Fprintf(msgOutput, "Error occurs when undelete %s: %s\n", srcPath,
+						getErrorMsg(err))
+					printOperationRecord(SYNC_OP_UNDELETE, OPERATION_STATUS_FAILED, srcPath,
+						dstPath, err)
+					return err
+				}
+			}
+			err := b.handler.utilUndeleteObject(b.bosClient, args.bucketName, object.path,
+				objectKey, object.storageClass, object.size, object.mtime, object.gtime)
+			if err != nil {
+				fmt.Fprintf(msgOutput, "Error occurs when undelete %s: %s\n", srcPath,
+					getErrorMsg(err))
+				printOperationRecord(SYNC_OP_UNDELETE, OPERATION_STATUS_FAILED, srcPath, dstPath,
+					err)
+			}
+			return err
+		})
 	}
-	// print summary
-	if summary {
-		fmt.Printf("Total PRE(s): %d\n", preNum)
-		fmt.Printf("Total Object(s): %d\n", objectNum)
-		fmt.Printf("Total Size Of Objects(byte): %d\n", objectSize)
+	return executor.wait(), err
+}
+
+// check whether an object exists by head object
+func (b *BosCli) doesObjectExist(bucketName, objectKey string) (bool, error) {
+	_, err := headObject(b.bosClient, bucketName, objectKey)
+	if err == nil {
+		return true, nil
 	}
-	return nil
+	if serverErr, ok := err.(*bce.BceServiceError); ok && serverErr.StatusCode == 404 {
+		return false, nil
+	}
+	return false, err
 }
 
 // Make bucket
@@ -342,6 +1806,7 @@ type removeObjectArgs struct {
 //   recursive : delete objects under subdirs.
 //   quit      : do not display the operations performed from the specified command
 func (b *BosCli) RemoveObject(bosPath string, yes, recursive, quiet bool) {
+	defer finishRecords()
 	Quiet = quiet
 	// preprocessing and check request
 	args, retCode := b.removeObjectPreProcess(bosPath, recursive)
@@ -399,6 +1864,11 @@ func (b *BosCli) removeObjectExecute(args *removeObjectArgs, yes bool) (int, err
 
 	// recursive delete
 	if args.isDir {
+		// the warning is shown even if -q is given, as removed objects are lost
+		if b.trashIsDisabled(args.bucketName) {
+			fmt.Fprintf(msgOutput, "Warning: trash of bucket %s is disabled, removed objects "+
+				"can't be recovered by undelete\n", args.bucketName)
+		}
 		if !yes {
 			yes = util.PromptConfirm("Do you really want to DELETE all objects in %s%s/%s?",
 				BOS_PATH_PREFIX, args.bucketName, args.objectKey)
@@ -425,29 +1895,69 @@ END:
 	return deleted, err
 }
 
+// whether the trash of bucket is disabled, BOS returns 404 when getting trash of such bucket.
+// Other errors are ignored, e.g., the user might have no permission to get the trash.
+func (b *BosCli) trashIsDisabled(bucketName string) bool {
+	_, err := b.bosClient.GetBucketTrash(bucketName)
+	serverErr, ok := err.(*bce.BceServiceError)
+	return ok && serverErr.StatusCode == 404
+}
+
 // cp : upload, download or copy
 // param args: Parsed args, must have SRC, DST, force, no_override
 // exception: Both SRC and DST are local path or stream
-func (b *BosCli) Copy(srcPath, dstPath, storageClass, downLoadTmp string, recursive, restart, quiet,
-	yes, disableBar bool) {
+// objectMeta: http headers and user metadata of uploaded or copied objects
+// sseOpts: server-side encryption of uploaded, copied or downloaded objects
+// noGuessMimeType: don't guess content type of uploaded files
+// preserveSymlinks: upload local symbolic links in the directory as symlink objects
+func (b *BosCli) Copy(srcPath, dstPath, storageClass, downLoadTmp, byteRange string,
+	objectMeta ObjectMetaOptions, sseOpts SseOptions, concurrency int, recursive, restart, quiet,
+	yes, disableBar, restoreArchive, noGuessMimeType, preserveSymlinks bool) {
 
 	var (
 		retCode BosCliErrorCode
 		err     error
 	)
 
+	defer finishRecords()
 	Quiet = quiet
 	DisableBar = disableBar
+	NoGuessMimeType = noGuessMimeType
 
 	isSourceRemotePath := strings.HasPrefix(srcPath, BOS_PATH_PREFIX)
 	isDestinationRemotePath := strings.HasPrefix(dstPath, BOS_PATH_PREFIX)
 
+	if byteRange != "" && (!isSourceRemotePath || isDestinationRemotePath) {
+		bcecliAbnormalExistCodeMsg(BOSCLI_RANGE_ONLY_FOR_DOWNLOAD, "--range can only be used "+
+			"when downloading an object")
+	}
+	if objectMeta.MetadataDirective != "" && !(isSourceRemotePath && isDestinationRemotePath) {
+		bcecliAbnormalExistCodeMsg(BOSCLI_METADATA_DIRECTIVE_ONLY_FOR_COPY,
+			"--metadata-directive can only be used when copying between BOS")
+	}
+	if preserveSymlinks && (isSourceRemotePath || !isDestinationRemotePath || !recursive) {
+		bcecliAbnormalExistCodeMsg(BOSCLI_PRESERVE_SYMLINKS_ONLY_FOR_UPLOAD,
+			"--preserve-symlinks can only be used when uploading a directory with -r")
+	}
+	meta, retCode, err := newObjectMetaArgs(objectMeta)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	sse, retCode, err := newSseArgs(sseOpts, isSourceRemotePath, isDestinationRemotePath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	b = b.withSse(sse)
+
 	if isSourceRemotePath && isDestinationRemotePath {
-		retCode, err = b.copyBetweenRemote(srcPath, dstPath, storageClass, recursive, restart)
+		retCode, err = b.copyBetweenRemote(srcPath, dstPath, storageClass, concurrency,
+			recursive, restart, false, false, restoreArchive, meta)
 	} else if isSourceRemotePath {
-		retCode, err = b.copyDownload(srcPath, dstPath, downLoadTmp, recursive, yes, restart)
+		retCode, err = b.copyDownload(srcPath, dstPath, downLoadTmp, byteRange, concurrency,
+			recursive, yes, restart, false, false, restoreArchive)
 	} else if isDestinationRemotePath {
-		retCode, err = b.copyUpload(srcPath, dstPath, storageClass, recursive, restart)
+		retCode, err = b.copyUpload(srcPath, dstPath, storageClass, concurrency, recursive,
+			restart, false, false, preserveSymlinks, meta)
 	} else {
 		bcecliAbnormalExistMsg("You can use cp/copy to copy files between local file system.")
 	}
@@ -456,32 +1966,158 @@ func (b *BosCli) Copy(srcPath, dstPath, storageClass, downLoadTmp string, recurs
 	}
 }
 
+// mv : move objects between local and BOS or between BOS and BOS.
+// the source is deleted only after it is copied to the destination successfully, large objects
+// are transferred by multipart and can be resumed like cp.
+// PARAMS:
+//   dryrun: only show what would be moved
+//   noGuessMimeType: don't guess content type of uploaded files
+func (b *BosCli) Move(srcPath, dstPath, storageClass, downLoadTmp string, concurrency int,
+	recursive, restart, dryrun, quiet, yes, disableBar, noGuessMimeType bool) {
+
+	var (
+		retCode BosCliErrorCode
+		err     error
+	)
+
+	defer finishRecords()
+	Quiet = quiet
+	DisableBar = disableBar
+	NoGuessMimeType = noGuessMimeType
+
+	if srcPath == "-" || dstPath == "-" {
+		bcecliAbnormalExistCodeMsg(BOSCLI_MOVE_NOT_SUPPORT_STREAM, "Can not move objects from "+
+			"or to stream")
+	}
+
+	isSourceRemotePath := strings.HasPrefix(srcPath, BOS_PATH_PREFIX)
+	isDestinationRemotePath := strings.HasPrefix(dstPath, BOS_PATH_PREFIX)
+
+	if isSourceRemotePath && isDestinationRemotePath {
+		retCode, err = b.copyBetweenRemote(srcPath, dstPath, storageClass, concurrency,
+			recursive, restart, true, dryrun, false, nil)
+	} else if isSourceRemotePath {
+		retCode, err = b.copyDownload(srcPath, dstPath, downLoadTmp, "", concurrency, recursive,
+			yes, restart, true, dryrun, false)
+	} else if isDestinationRemotePath {
+		retCode, err = b.copyUpload(srcPath, dstPath, storageClass, concurrency, recursive,
+			restart, true, dryrun, false, nil)
+	} else {
+		bcecliAbnormalExistMsg("You can use mv to move files between local file system.")
+	}
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+}
+
+// print the object or file that would be moved by mv --dryrun
+func printMoveDryrun(src, dst string) {
+	printIfNotQuiet("%s: %s to %s\n", SYNC_OP_MOVE, src, dst)
+	printOperationRecord(SYNC_OP_MOVE, OPERATION_STATUS_DRYRUN, src, dst, nil)
+}
+
+// delete the source object after it has been moved to the destination
+func (b *BosCli) deleteMovedObject(bosClient bosClientInterface, bucketName,
+	objectKey string) error {
+
+	err := b.handler.utilDeleteObject(bosClient, bucketName, objectKey)
+	if err != nil {
+		fmt.Fprintf(msgOutput, "Error occurs when delete moved object %s%s/%s: %s\n",
+			BOS_PATH_PREFIX, bucketName, objectKey, getErrorMsg(err))
+		printOperationRecord(SYNC_OP_REMOVE, OPERATION_STATUS_FAILED, "",
+			BOS_PATH_PREFIX+bucketName+"/"+objectKey, err)
+	}
+	return err
+}
+
+// delete the source file after it has been moved to BOS
+func (b *BosCli) deleteMovedFile(filePath string) error {
+	err := b.handler.utilDeleteLocalFile(filePath)
+	if err != nil {
+		fmt.Fprintf(msgOutput, "Error occurs when delete moved file %s: %s\n", filePath,
+			err.Error())
+		printOperationRecord(SYNC_OP_DELETE, OPERATION_STATUS_FAILED, "", filePath, err)
+	}
+	return err
+}
+
+// the name of the operation in summary, Copy/Upload/Download or Move
+func transferOpName(opName string, move bool) string {
+	if move {
+		return SYNC_OP_MOVE
+	}
+	return opName
+}
+
 type copyBetweenRemoteArgs struct {
-	srcBucketName string
-	srcObjectKey  string
-	dstBucketName string
-	dstObjectKey  string
-	srcIsDir      bool
+	srcBucketName  string
+	srcObjectKey   string
+	dstBucketName  string
+	dstObjectKey   string
+	srcIsDir       bool
+	concurrency    int
+	move           bool // delete source objects after they are copied
+	dryrun         bool
+	restoreArchive bool // request restore of archived source objects which are skipped
+	meta           *objectMetaArgs
 }
 
 // implement copy objects
-func (b *BosCli) copyBetweenRemote(srcPath, dstPath, storageClass string, recursive,
-	restart bool) (BosCliErrorCode, error) {
+func (b *BosCli) copyBetweenRemote(srcPath, dstPath, storageClass string, concurrency int,
+	recursive, restart, move, dryrun, restoreArchive bool, meta *objectMetaArgs) (
+	BosCliErrorCode, error) {
 	// preprocessing and check request
-	args, retCode, err := b.copyRemoteRequestPreProcess(srcPath, dstPath, storageClass, recursive)
+	args, retCode, err := b.copyRemoteRequestPreProcess(srcPath, dstPath, storageClass,
+		concurrency, recursive)
 	if err != nil {
 		return retCode, err
 	}
+	args.move = move
+	args.dryrun = dryrun
+	args.restoreArchive = restoreArchive
+	args.meta = meta
+
+	// moving objects to a prefix under themselves would never end
+	if move && args.srcIsDir && args.srcBucketName == args.dstBucketName &&
+		strings.HasPrefix(args.dstObjectKey, args.srcObjectKey) {
+		return BOSCLI_MOVE_DST_IN_SRC, fmt.Errorf("Can not move %s to its sub directory %s",
+			srcPath, dstPath)
+	}
+	// the source would be deleted after it is copied to itself
+	if move && !args.srcIsDir && args.srcBucketName == args.dstBucketName {
+		dstObjectKey, retCode, err := calcDstObjectKey(args.srcObjectKey, args.dstObjectKey)
+		if err != nil {
+			return retCode, err
+		}
+		if isSameObjectName(args.srcObjectKey, dstObjectKey) {
+			return BOSCLI_MOVE_TO_ITSELF, fmt.Errorf("Can not move %s to itself", srcPath)
+		}
+	}
+
+	// progress bars of concurrent copies would be mixed up
+	if args.srcIsDir && args.concurrency > 1 {
+		DisableBar = true
+	}
 
 	// execute copy between remote
 	ret, retCode, err := b.copyObjectExecute(args, storageClass, restart)
+	opName := transferOpName(SYNC_OP_COPY, move)
 
 	// print result
 	if err != nil {
-		bcecliAbnormalExistCodeErr(retCode, err)
+		if ret != nil {
+			printIfNotQuiet("%s interrupted: %s to %s, [%d] success, [%d] failure\n", opName,
+				srcPath, dstPath, ret.successed, ret.failed)
+			printOperationSummaryRecord(srcPath, dstPath, ret, true)
+		}
+		return retCode, err
 	}
-	// TODO need print failed number
-	printIfNotQuiet("[%d] objects remote copied.\n", ret.successed)
+	if dryrun {
+		return BOSCLI_OK, nil
+	}
+	printIfNotQuiet("%s done: %s to %s, [%d] success, [%d] failure\n", opName, srcPath,
+		dstPath, ret.successed, ret.failed)
+	printOperationSummaryRecord(srcPath, dstPath, ret, false)
 	if ret.failed > 0 {
 		return BOSCLI_EMPTY_CODE, nil
 	} else {
@@ -491,7 +2127,7 @@ func (b *BosCli) copyBetweenRemote(srcPath, dstPath, storageClass string, recurs
 
 // check whether the request of copy between is valid, and get request info
 func (b *BosCli) copyRemoteRequestPreProcess(srcPath, dstPath, storageClass string,
-	recursive bool) (*copyBetweenRemoteArgs, BosCliErrorCode, error) {
+	concurrency int, recursive bool) (*copyBetweenRemoteArgs, BosCliErrorCode, error) {
 
 	srcBucketName, srcObjectKey := splitBosBucketKey(srcPath)
 	dstBucketName, dstObjectKey := splitBosBucketKey(dstPath)
@@ -531,12 +2167,17 @@ func (b *BosCli) copyRemoteRequestPreProcess(srcPath, dstPath, storageClass stri
 				"object '%s'", srcBucketName, srcObjectKey, dstObjectKey)
 		}
 		if recursive {
+			concurrency, retCode, err := getConcurrency(concurrency)
+			if err != nil {
+				return nil, retCode, err
+			}
 			return &copyBetweenRemoteArgs{
 				srcBucketName: srcBucketName,
 				srcObjectKey:  srcObjectKey,
 				dstBucketName: dstBucketName,
 				dstObjectKey:  dstObjectKey,
 				srcIsDir:      true,
+				concurrency:   concurrency,
 			}, BOSCLI_OK, nil
 		} else {
 			return nil, BOSCLI_BATCH_COPY_SRCOBJECT_END, fmt.Errorf("Please use -r to copy " +
@@ -558,26 +2199,23 @@ func (b *BosCli) copyObjectExecute(args *copyBetweenRemoteArgs, storageClass str
 	*executeResult, BosCliErrorCode, error) {
 
 	var (
-		copied        int
-		failedNum     int
-		listResult    *listFileResult
-		srcObjectName string
-		dstObjectName string
-		srcBosClient  bosClientInterface
-		err           error
+		listResult   *listFileResult
+		srcBosClient bosClientInterface
+		err          error
 	)
 
 	// generate object list iterator
-	if srcBosClient, err = initBosClientForBucket("", "", args.srcBucketName); err != nil {
+	if srcBosClient, err = b.initSrcBosClient(args.srcBucketName, true); err != nil {
 		return nil, BOSCLI_EMPTY_CODE, err
 	}
 	objectLists := NewObjectListIterator(srcBosClient, nil, args.srcBucketName, args.srcObjectKey,
 		"", true, true, args.srcIsDir, false, 1000)
+	executor := newConcurrentExecutor(args.concurrency)
 
 	for {
 		listResult, err = objectLists.next()
 		if err != nil {
-			return &executeResult{successed: copied, failed: failedNum}, BOSCLI_EMPTY_CODE, err
+			break
 		}
 		if listResult.ended {
 			break
@@ -587,8 +2225,8 @@ func (b *BosCli) copyObjectExecute(args *copyBetweenRemoteArgs, storageClass str
 		}
 
 		object := listResult.file
-		srcObjectName = object.path
-		dstObjectName = object.key
+		srcObjectName := object.path
+		dstObjectName := object.key
 		if dstObjectName == "" {
 			continue
 		}
@@ -601,24 +2239,50 @@ func (b *BosCli) copyObjectExecute(args *copyBetweenRemoteArgs, storageClass str
 			}
 		}
 
+		// moving an object to itself deletes it, even if its storage class is changed
 		if isTheSameBucketAndObject(args.srcBucketName, srcObjectName, args.dstBucketName,
-			dstObjectName, storageClass, object.storageClass) {
-			failedNum++
+			dstObjectName, storageClass, object.storageClass) || (args.move &&
+			args.srcBucketName == args.dstBucketName &&
+			isSameObjectName(srcObjectName, dstObjectName)) {
+			executor.fail()
 			printIfNotQuiet("Can not cover object with same object, skip: %s\n", object.key)
+			printOperationRecord(SYNC_OP_COPY, OPERATION_STATUS_SKIPPED,
+				BOS_PATH_PREFIX+args.srcBucketName+"/"+srcObjectName,
+				BOS_PATH_PREFIX+args.dstBucketName+"/"+dstObjectName,
+				fmt.Errorf("can not cover object with same object"))
 			continue
 		}
-		err = b.handler.utilCopyObject(srcBosClient, b.bosClient, args.srcBucketName, srcObjectName,
-			args.dstBucketName, dstObjectName, storageClass, object.size, object.mtime,
-			object.gtime, restart)
-		if err == nil {
-			copied++
-		} else {
-			failedNum++
-			fmt.Printf("Error occurs when copy object %s%s/%s: %s\n", BOS_PATH_PREFIX,
-				args.srcBucketName, srcObjectName, getErrorMsg(err))
+		if args.dryrun {
+			printMoveDryrun(BOS_PATH_PREFIX+args.srcBucketName+"/"+srcObjectName,
+				BOS_PATH_PREFIX+args.dstBucketName+"/"+dstObjectName)
+			continue
+		}
+		if b.skipArchivedObject(srcBosClient, SYNC_OP_COPY, args.srcBucketName, srcObjectName,
+			object.storageClass, BOS_PATH_PREFIX+args.dstBucketName+"/"+dstObjectName,
+			args.restoreArchive) {
+			executor.fail()
+			continue
 		}
+		executor.execute(func() error {
+			err := b.handler.utilCopyObject(srcBosClient, b.bosClient, args.srcBucketName,
+				srcObjectName, args.dstBucketName, dstObjectName, storageClass, object.size,
+				object.mtime, object.gtime, restart, args.meta)
+			// errors are printed even if -q is given, otherwise a quiet copy fails silently
+			if err != nil {
+				fmt.Fprintf(msgOutput, "Error occurs when copy object %s%s/%s: %s\n",
+					BOS_PATH_PREFIX, args.srcBucketName, srcObjectName, getErrorMsg(err))
+				printOperationRecord(SYNC_OP_COPY, OPERATION_STATUS_FAILED,
+					BOS_PATH_PREFIX+args.srcBucketName+"/"+srcObjectName,
+					BOS_PATH_PREFIX+args.dstBucketName+"/"+dstObjectName, err)
+				return err
+			}
+			if args.move {
+				return b.deleteMovedObject(srcBosClient, args.srcBucketName, srcObjectName)
+			}
+			return nil
+		})
 	}
-	return &executeResult{successed: copied, failed: failedNum}, BOSCLI_EMPTY_CODE, err
+	return executor.wait(), BOSCLI_EMPTY_CODE, err
 }
 
 type copyDownloadArgs struct {
@@ -626,30 +2290,67 @@ type copyDownloadArgs struct {
 	srcObjectKey       string
 	srcIsDir           bool
 	isDownloadToStream bool
+	concurrency        int
+	move               bool // delete source objects after they are downloaded
+	dryrun             bool
+	restoreArchive     bool // request restore of archived source objects which are skipped
 }
 
 // implement downlaod object
-func (b *BosCli) copyDownload(srcPath, dstPath, downLoadTmp string, recursive, yes,
-	restart bool) (BosCliErrorCode, error) {
+// PARAMS:
+//   byteRange: only download bytes in range START-END or START- of a single object
+func (b *BosCli) copyDownload(srcPath, dstPath, downLoadTmp, byteRange string, concurrency int,
+	recursive, yes, restart, move, dryrun, restoreArchive bool) (BosCliErrorCode, error) {
 	// preprocessing request
-	args, retCode, err := b.copyDownloadPreProcess(srcPath, dstPath, recursive)
+	args, retCode, err := b.copyDownloadPreProcess(srcPath, dstPath, concurrency, recursive)
 	if err != nil {
 		return retCode, err
 	}
+	args.move = move
+	args.dryrun = dryrun
+	args.restoreArchive = restoreArchive
+
+	// download part of an object
+	if byteRange != "" {
+		return b.copyDownloadRange(args, srcPath, dstPath, byteRange, yes)
+	}
 
+	// download to stream
 	if args.isDownloadToStream {
-		return BOSCLI_EMPTY_CODE, fmt.Errorf("download to stream is not implement")
+		msgOutput = os.Stderr
+		if err := b.handler.utilDownloadToStream(b.bosClient, args.srcBucketName,
+			args.srcObjectKey, os.Stdout); err != nil {
+			printOperationRecord(SYNC_OP_DOWNLOAD, OPERATION_STATUS_FAILED, srcPath, "-", err)
+			return BOSCLI_EMPTY_CODE, err
+		}
+		printIfNotQuiet("[1] objects downloaded.\n")
+		return BOSCLI_OK, nil
+	}
+
+	// progress bars of concurrent downloads would be mixed up
+	if args.srcIsDir && args.concurrency > 1 {
+		DisableBar = true
 	}
 
 	// generate oplist and execute download
 	ret, retCode, err := b.copyDownloadExecute(args, dstPath, downLoadTmp, yes, restart)
+	opName := transferOpName(SYNC_OP_DOWNLOAD, move)
 
 	// print result
 	if err != nil {
+		if ret != nil && args.srcIsDir {
+			printIfNotQuiet("%s interrupted: %s to %s, [%d] success, [%d] failure\n", opName,
+				srcPath, dstPath, ret.successed, ret.failed)
+			printOperationSummaryRecord(srcPath, dstPath, ret, true)
+		}
 		return retCode, err
 	}
-	// TODO need print failed number
-	printIfNotQuiet("[%d] objects downloaded.\n", ret.successed)
+	if dryrun {
+		return BOSCLI_OK, nil
+	}
+	printIfNotQuiet("%s done: %s to %s, [%d] success, [%d] failure\n", opName, srcPath,
+		dstPath, ret.successed, ret.failed)
+	printOperationSummaryRecord(srcPath, dstPath, ret, false)
 	if ret.failed > 0 {
 		return BOSCLI_EMPTY_CODE, nil
 	} else {
@@ -657,12 +2358,44 @@ func (b *BosCli) copyDownload(srcPath, dstPath, downLoadTmp string, recursive, y
 	}
 }
 
+// download a byte range of a single object to local file or stream
+func (b *BosCli) copyDownloadRange(args *copyDownloadArgs, srcPath, dstPath, byteRange string,
+	yes bool) (BosCliErrorCode, error) {
+
+	if args.srcIsDir {
+		return BOSCLI_RANGE_ONLY_FOR_DOWNLOAD, fmt.Errorf("Can not download a range of "+
+			"objects under %s", srcPath)
+	}
+	start, end, err := parseByteRange(byteRange)
+	if err != nil {
+		return BOSCLI_RANGE_INVALID, err
+	}
+
+	if args.isDownloadToStream {
+		msgOutput = os.Stderr
+		err = b.handler.utilGetObjectRange(b.bosClient, args.srcBucketName, args.srcObjectKey,
+			start, end, false, os.Stdout)
+	} else {
+		err = b.handler.utilDownloadObjectRange(b.bosClient, args.srcBucketName,
+			args.srcObjectKey, dstPath, yes, start, end)
+	}
+	if err != nil {
+		printOperationRecord(SYNC_OP_DOWNLOAD, OPERATION_STATUS_FAILED, srcPath, dstPath, err)
+		return BOSCLI_EMPTY_CODE, err
+	}
+	if args.isDownloadToStream {
+		printIfNotQuiet("Download: %s to -\n", srcPath)
+		printOperationRecord(SYNC_OP_DOWNLOAD, OPERATION_STATUS_SUCCESS, srcPath, "-", nil)
+	}
+	return BOSCLI_OK, nil
+}
+
 // preprocessing from download files
 // RETURN:
 //    srcIsDir: whether srcPath is directory or file
 //    error code and error
-func (b *BosCli) copyDownloadPreProcess(srcPath, dstPath string, recursive bool) (*copyDownloadArgs,
-	BosCliErrorCode, error) {
+func (b *BosCli) copyDownloadPreProcess(srcPath, dstPath string, concurrency int,
+	recursive bool) (*copyDownloadArgs, BosCliErrorCode, error) {
 
 	srcBucketName, srcObjectKey := splitBosBucketKey(srcPath)
 	if srcBucketName == "" {
@@ -676,6 +2409,19 @@ func (b *BosCli) copyDownloadPreProcess(srcPath, dstPath string, recursive bool)
 			srcBucketName)
 	}
 
+	// check download to stream
+	if dstPath == "-" {
+		if srcObjectKey == "" || strings.HasSuffix(srcObjectKey, boscmd.BOS_PATH_SEPARATOR) {
+			return nil, BOSCLI_DOWNLOAD_DIR_TO_STREAM, fmt.Errorf("Can not download " +
+				"objects to stream")
+		}
+		return &copyDownloadArgs{
+			srcBucketName:      srcBucketName,
+			srcObjectKey:       srcObjectKey,
+			isDownloadToStream: true,
+		}, BOSCLI_OK, nil
+	}
+
 	if util.DoesDirExist(dstPath) && !util.IsDirWritable(dstPath) {
 		return nil, BOSCLI_DIR_IS_NOT_WRITABLE, fmt.Errorf("Directory %s is not writable!",
 			dstPath)
@@ -688,10 +2434,15 @@ func (b *BosCli) copyDownloadPreProcess(srcPath, dstPath string, recursive bool)
 				"to a file %s", dstPath)
 		}
 		if recursive {
+			concurrency, retCode, err := getConcurrency(concurrency)
+			if err != nil {
+				return nil, retCode, err
+			}
 			return &copyDownloadArgs{
 				srcBucketName: srcBucketName,
 				srcObjectKey:  srcObjectKey,
 				srcIsDir:      true,
+				concurrency:   concurrency,
 			}, BOSCLI_OK, nil
 		} else {
 			return nil, BOSCLI_BATCH_DOWNLOAD_SRCOBJECT_END, fmt.Errorf("Please use -r to " +
@@ -699,11 +2450,6 @@ func (b *BosCli) copyDownloadPreProcess(srcPath, dstPath string, recursive bool)
 		}
 	}
 
-	// cehck downlaod to stream
-	if dstPath == "-" {
-		return nil, BOSCLI_UNSUPPORT_METHOD, fmt.Errorf("CLI don't yet support Download to stream")
-	}
-
 	return &copyDownloadArgs{
 		srcBucketName: srcBucketName,
 		srcObjectKey:  srcObjectKey,
@@ -729,12 +2475,29 @@ func (b *BosCli) copyDownloadExecute(args *copyDownloadArgs, dstPath, downLoadTm
 
 	// download single object
 	if !args.srcIsDir {
+		if args.dryrun {
+			printMoveDryrun(BOS_PATH_PREFIX+args.srcBucketName+"/"+args.srcObjectKey, dstPath)
+			return &executeResult{}, BOSCLI_OK, nil
+		}
+		if b.skipArchivedObject(b.bosClient, SYNC_OP_DOWNLOAD, args.srcBucketName,
+			args.srcObjectKey, "", dstPath, args.restoreArchive) {
+			return &executeResult{failed: 1}, BOSCLI_OK, nil
+		}
 		err = b.handler.utilDownloadObject(b.bosClient, args.srcBucketName, args.srcObjectKey,
 			dstPath, downLoadTmp, yes, 0, 0, 0, restart)
+		if err == nil && args.move {
+			err = b.deleteMovedObject(b.bosClient, args.srcBucketName, args.srcObjectKey)
+			if err != nil {
+				return &executeResult{failed: 1}, retCode, err
+			}
+		}
 
 		if err == nil {
 			retCode = BOSCLI_OK
 			downloaded++
+		} else {
+			printOperationRecord(SYNC_OP_DOWNLOAD, OPERATION_STATUS_FAILED,
+				BOS_PATH_PREFIX+args.srcBucketName+"/"+args.srcObjectKey, dstPath, err)
 		}
 		return &executeResult{successed: downloaded, failed: failedNum}, retCode, err
 	}
@@ -742,6 +2505,7 @@ func (b *BosCli) copyDownloadExecute(args *copyDownloadArgs, dstPath, downLoadTm
 	// batch download
 	objectList := NewObjectListIterator(b.bosClient, nil, args.srcBucketName, args.srcObjectKey,
 		"", true, true, true, false, 1000)
+	executor := newConcurrentExecutor(args.concurrency)
 	for {
 		listResult, err = objectList.next()
 		if err != nil {
@@ -771,18 +2535,32 @@ func (b *BosCli) copyDownloadExecute(args *copyDownloadArgs, dstPath, downLoadTm
 			dstFileName += util.OsPathSeparator + tmpDstFileName
 		}
 
-		err = b.handler.utilDownloadObject(b.bosClient, args.srcBucketName, srcObjectName,
-			dstFileName, downLoadTmp, yes, object.size, object.mtime, object.gtime, restart)
-
-		if err == nil {
-			downloaded++
-		} else {
-			failedNum++
-			fmt.Printf("Error occurs when download object %s%s/%s: %s\n", BOS_PATH_PREFIX,
-				args.srcBucketName, srcObjectName, getErrorMsg(err))
+		if args.dryrun {
+			printMoveDryrun(BOS_PATH_PREFIX+args.srcBucketName+"/"+srcObjectName, dstFileName)
+			continue
+		}
+		if b.skipArchivedObject(b.bosClient, SYNC_OP_DOWNLOAD, args.srcBucketName,
+			srcObjectName, object.storageClass, dstFileName, args.restoreArchive) {
+			executor.fail()
+			continue
 		}
+		executor.execute(func() error {
+			err := b.handler.utilDownloadObject(b.bosClient, args.srcBucketName, srcObjectName,
+				dstFileName, downLoadTmp, yes, object.size, object.mtime, object.gtime, restart)
+			if err != nil {
+				fmt.Fprintf(msgOutput, "Error occurs when download object %s%s/%s: %s\n",
+					BOS_PATH_PREFIX, args.srcBucketName, srcObjectName, getErrorMsg(err))
+				printOperationRecord(SYNC_OP_DOWNLOAD, OPERATION_STATUS_FAILED,
+					BOS_PATH_PREFIX+args.srcBucketName+"/"+srcObjectName, dstFileName, err)
+				return err
+			}
+			if args.move {
+				return b.deleteMovedObject(b.bosClient, args.srcBucketName, srcObjectName)
+			}
+			return nil
+		})
 	}
-	return &executeResult{successed: downloaded, failed: failedNum}, retCode, err
+	return executor.wait(), retCode, err
 }
 
 type copyUploadArges struct {
@@ -791,29 +2569,65 @@ type copyUploadArges struct {
 	dstObjectKey     string
 	srcIsDir         bool
 	uploadFromStream bool
+	concurrency      int
+	move             bool // delete source files after they are uploaded
+	dryrun           bool
+	preserveSymlinks bool // upload symbolic links as symlink objects
+	meta             *objectMetaArgs
 }
 
-func (b *BosCli) copyUpload(srcPath, dstPath, storageClass string, recursive,
-	restart bool) (BosCliErrorCode, error) {
+func (b *BosCli) copyUpload(srcPath, dstPath, storageClass string, concurrency int, recursive,
+	restart, move, dryrun, preserveSymlinks bool, meta *objectMetaArgs) (BosCliErrorCode,
+	error) {
 	// preprocessing and check request
-	args, retCode, err := b.copyUploadRequestPreProcess(srcPath, dstPath, storageClass, recursive)
+	args, retCode, err := b.copyUploadRequestPreProcess(srcPath, dstPath, storageClass,
+		concurrency, recursive)
 	if err != nil {
 		return retCode, err
 	}
+	args.move = move
+	args.dryrun = dryrun
+	args.preserveSymlinks = preserveSymlinks
+	args.meta = meta
 
+	// upload from stream
 	if args.uploadFromStream {
-		return BOSCLI_EMPTY_CODE, fmt.Errorf("upload from stream is not implement")
+		if err := b.handler.utilUploadStream(b.bosClient, os.Stdin, args.dstBucketName,
+			args.dstObjectKey, storageClass, args.meta); err != nil {
+			printOperationRecord(SYNC_OP_UPLOAD, OPERATION_STATUS_FAILED, "-", dstPath, err)
+			return BOSCLI_EMPTY_CODE, err
+		}
+		objectPath := BOS_PATH_PREFIX + args.dstBucketName + "/" + args.dstObjectKey
+		printIfNotQuiet("Upload: - to %s\n", objectPath)
+		printOperationRecord(SYNC_OP_UPLOAD, OPERATION_STATUS_SUCCESS, "-", objectPath, nil)
+		printIfNotQuiet("[1] objects uploaded.\n")
+		return BOSCLI_OK, nil
+	}
+
+	// progress bars of concurrent uploads would be mixed up
+	if args.srcIsDir && args.concurrency > 1 {
+		DisableBar = true
 	}
 
 	// execute upload file to bos
 	ret, retCode, err := b.uploadFileExecute(args, srcPath, storageClass, restart)
+	opName := transferOpName(SYNC_OP_UPLOAD, move)
 
 	// print result
 	if err != nil {
+		if ret != nil {
+			printIfNotQuiet("%s interrupted: %s to %s, [%d] success, [%d] failure\n", opName,
+				srcPath, dstPath, ret.successed, ret.failed)
+			printOperationSummaryRecord(srcPath, dstPath, ret, true)
+		}
 		return retCode, err
 	}
-	// TODO need print failed number
-	printIfNotQuiet("[%d] objects uploaded.\n", ret.successed)
+	if dryrun {
+		return BOSCLI_OK, nil
+	}
+	printIfNotQuiet("%s done: %s to %s, [%d] success, [%d] failure\n", opName, srcPath,
+		dstPath, ret.successed, ret.failed)
+	printOperationSummaryRecord(srcPath, dstPath, ret, false)
 	if ret.failed > 0 {
 		return BOSCLI_EMPTY_CODE, nil
 	} else {
@@ -823,7 +2637,7 @@ func (b *BosCli) copyUpload(srcPath, dstPath, storageClass string, recursive,
 
 // preprocessing and check upload request
 func (b *BosCli) copyUploadRequestPreProcess(srcPath, dstPath, storageClass string,
-	recursive bool) (*copyUploadArges, BosCliErrorCode, error) {
+	concurrency int, recursive bool) (*copyUploadArges, BosCliErrorCode, error) {
 
 	dstBucketName, dstObjectKey := splitBosBucketKey(dstPath)
 	if dstBucketName == "" {
@@ -844,10 +2658,7 @@ func (b *BosCli) copyUploadRequestPreProcess(srcPath, dstPath, storageClass stri
 		if strings.HasSuffix(dstObjectKey, boscmd.BOS_PATH_SEPARATOR) {
 			return nil, BOSCLI_UPLOAD_STREAM_TO_DIR, fmt.Errorf("Can not upload stream to path")
 		}
-		return nil, BOSCLI_UNSUPPORT_METHOD, fmt.Errorf("CLI don't yet support upload from stream")
-	}
-
-	if !util.DoesPathExist(srcPath) {
+	} else if !util.DoesPathExist(srcPath) {
 		return nil, boscmd.LOCAL_PATH_NOT_EXIST, fmt.Errorf("Source path %s does not exist!",
 			srcPath)
 	}
@@ -861,17 +2672,32 @@ func (b *BosCli) copyUploadRequestPreProcess(srcPath, dstPath, storageClass stri
 			dstBucketName)
 	}
 
+	// upload from stream
+	if srcPath == "-" {
+		return &copyUploadArges{
+			srcPath:          srcPath,
+			dstBucketName:    dstBucketName,
+			dstObjectKey:     dstObjectKey,
+			uploadFromStream: true,
+		}, BOSCLI_OK, nil
+	}
+
 	// batch files
 	if recursive {
 		if !util.DoesFileExist(srcPath) {
 			if dstObjectKey != "" && !strings.HasSuffix(dstObjectKey, boscmd.BOS_PATH_SEPARATOR) {
 				dstObjectKey += boscmd.BOS_PATH_SEPARATOR
 			}
+			concurrency, retCode, err := getConcurrency(concurrency)
+			if err != nil {
+				return nil, retCode, err
+			}
 			return &copyUploadArges{
 				srcPath:       srcPath,
 				dstBucketName: dstBucketName,
 				dstObjectKey:  dstObjectKey,
 				srcIsDir:      true,
+				concurrency:   concurrency,
 			}, BOSCLI_OK, nil
 		}
 	}
@@ -894,8 +2720,6 @@ func (b *BosCli) uploadFileExecute(args *copyUploadArges, srcPath string, storag
 
 	var (
 		listResult *listFileResult
-		uploaded   int
-		failedNum  int
 		err        error
 	)
 
@@ -907,6 +2731,14 @@ func (b *BosCli) uploadFileExecute(args *copyUploadArges, srcPath string, storag
 	// generate object list iterator
 	absSrcPath, _ := util.Abs(srcPath)
 	filesList := NewLocalFileIterator(absSrcPath, nil, true)
+	executor := newConcurrentExecutor(args.concurrency)
+
+	var symlinks *symlinkMapper
+	if args.preserveSymlinks && args.srcIsDir {
+		if symlinks, err = newSymlinkMapper(absSrcPath); err != nil {
+			return nil, BOSCLI_EMPTY_CODE, err
+		}
+	}
 
 	// upload from file
 	for {
@@ -925,8 +2757,9 @@ func (b *BosCli) uploadFileExecute(args *copyUploadArges, srcPath string, storag
 		file := listResult.file
 
 		if file.err != nil {
-			failedNum++
+			executor.fail()
 			printIfNotQuiet("Failed Upload: %s. Receive error: %s\n", file.path, file.err.Error())
+			printOperationRecord(SYNC_OP_UPLOAD, OPERATION_STATUS_FAILED, file.path, "", file.err)
 			continue
 		}
 
@@ -934,19 +2767,42 @@ func (b *BosCli) uploadFileExecute(args *copyUploadArges, srcPath string, storag
 		finalObjectKey := getFinalObjectKeyFromLocalPath(absSrcPath, file.path, args.dstObjectKey,
 			args.srcIsDir)
 
-		//excute upload
-		err = b.handler.utilUploadFile(b.bosClient, file.path, file.realPath, args.dstBucketName,
-			finalObjectKey, storageClass, file.size, file.mtime, file.gtime, restart)
+		if args.dryrun {
+			printMoveDryrun(file.path, BOS_PATH_PREFIX+args.dstBucketName+"/"+finalObjectKey)
+			continue
+		}
 
-		if err != nil {
-			printIfNotQuiet("Failed Upload: %s to %s%s/%s. Receive error: %s\n", file.path,
-				BOS_PATH_PREFIX, args.dstBucketName, finalObjectKey, err.Error())
-			failedNum++
-		} else {
-			uploaded++
+		if targetKey, ok := symlinks.targetKey(file, finalObjectKey); ok {
+			executor.execute(func() error {
+				err := b.putSymlink(args.dstBucketName, targetKey, finalObjectKey)
+				if err != nil {
+					printIfNotQuiet("Failed Link: %s to %s%s/%s. Receive error: %s\n", file.path,
+						BOS_PATH_PREFIX, args.dstBucketName, finalObjectKey, err.Error())
+				}
+				return err
+			})
+			continue
 		}
+
+		//excute upload
+		executor.execute(func() error {
+			err := b.handler.utilUploadFile(b.bosClient, file.path, file.realPath,
+				args.dstBucketName, finalObjectKey, storageClass, file.size, file.mtime,
+				file.gtime, restart, args.meta)
+			if err != nil {
+				printIfNotQuiet("Failed Upload: %s to %s%s/%s. Receive error: %s\n", file.path,
+					BOS_PATH_PREFIX, args.dstBucketName, finalObjectKey, err.Error())
+				printOperationRecord(SYNC_OP_UPLOAD, OPERATION_STATUS_FAILED, file.path,
+					BOS_PATH_PREFIX+args.dstBucketName+"/"+finalObjectKey, err)
+				return err
+			}
+			if args.move {
+				return b.deleteMovedFile(file.path)
+			}
+			return nil
+		})
 	}
-	return &executeResult{successed: uploaded, failed: failedNum}, BOSCLI_EMPTY_CODE, err
+	return executor.wait(), BOSCLI_EMPTY_CODE, err
 }
 
 type syncArgs struct {
@@ -961,6 +2817,9 @@ type syncArgs struct {
 	syncType             string
 	syncProcessingNum    int
 	multiUploadThreadNum int64
+	restoreArchive       bool // request restore of archived source objects which are skipped
+	preserveSymlinks     bool // upload local symbolic links as symlink objects
+	meta                 *objectMetaArgs
 }
 
 // sync local folder to bos
@@ -969,8 +2828,14 @@ type syncArgs struct {
 // 3. compare and gen file list of src to be put to dst, and src to delete, if delete is defined
 // 4. if dryrun is defined, show list to be processed
 // param args: parsed args, must have SRC and DST explicitly defined
-func (b *BosCli) Sync(srcPath, dstPath, storageClass, downLoadTmp, syncType string, exclude, include, excludeTime,
-	includeTime, excludeDelete []string, concurrency int, del, dryrun, yes, quiet, disableBar, restart bool) {
+// objectMeta: http headers and user metadata of uploaded or copied objects
+// sseOpts: server-side encryption of uploaded, copied or downloaded objects
+// noGuessMimeType: don't guess content type of uploaded files
+// preserveSymlinks: upload local symbolic links as symlink objects
+func (b *BosCli) Sync(srcPath, dstPath, storageClass, downLoadTmp, syncType string,
+	objectMeta ObjectMetaOptions, sseOpts SseOptions, exclude, include, excludeTime, includeTime,
+	excludeDelete []string, concurrency int, del, dryrun, yes, quiet, disableBar, restart,
+	restoreArchive, noGuessMimeType, preserveSymlinks bool) {
 
 	var (
 		filter       *bosFilter = nil
@@ -978,8 +2843,10 @@ func (b *BosCli) Sync(srcPath, dstPath, storageClass, downLoadTmp, syncType stri
 		retCode      BosCliErrorCode
 		err          error
 	)
+	defer finishRecords()
 	Quiet = quiet
 	DisableBar = disableBar
+	NoGuessMimeType = noGuessMimeType
 	IsConcurrentOperation = true
 
 	// preprocessing for sync reques
@@ -988,6 +2855,25 @@ func (b *BosCli) Sync(srcPath, dstPath, storageClass, downLoadTmp, syncType stri
 	if err != nil {
 		bcecliAbnormalExistCodeErr(retCode, err)
 	}
+	args.restoreArchive = restoreArchive
+	if preserveSymlinks && (args.srcType != IS_LOCAL || args.dstType != IS_BOS) {
+		bcecliAbnormalExistCodeMsg(BOSCLI_PRESERVE_SYMLINKS_ONLY_FOR_UPLOAD,
+			"--preserve-symlinks can only be used when synchronizing from local to BOS")
+	}
+	args.preserveSymlinks = preserveSymlinks
+	if objectMeta.MetadataDirective != "" && (args.srcType != IS_BOS || args.dstType != IS_BOS) {
+		bcecliAbnormalExistCodeMsg(BOSCLI_METADATA_DIRECTIVE_ONLY_FOR_COPY,
+			"--metadata-directive can only be used when synchronizing between BOS")
+	}
+	args.meta, retCode, err = newObjectMetaArgs(objectMeta)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	sse, retCode, err := newSseArgs(sseOpts, args.srcType == IS_BOS, args.dstType == IS_BOS)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	b = b.withSse(sse)
 
 	//generate new filter
 	if len(exclude) > 0 || len(include) > 0 || len(excludeTime) > 0 || len(includeTime) > 0 {
@@ -1008,11 +2894,13 @@ func (b *BosCli) Sync(srcPath, dstPath, storageClass, downLoadTmp, syncType stri
 		if result != nil {
 			printIfNotQuiet("Sync interrupted: %s to %s, [%d] success, [%d] failure\n",
 				args.srcPath, args.dstPath, result.successed, result.failed)
+			printOperationSummaryRecord(args.srcPath, args.dstPath, result, true)
 		}
 		bcecliAbnormalExistCodeErr(retCode, err)
 	}
 	printIfNotQuiet("Sync done: %s to %s, [%d] success, [%d] failure\n", args.srcPath, args.dstPath,
 		result.successed, result.failed)
+	printOperationSummaryRecord(args.srcPath, args.dstPath, result, false)
 	if result.failed > 0 {
 		bcecliAbnormalExistCode(BOSCLI_EMPTY_CODE)
 	}
@@ -1120,11 +3008,11 @@ func (b *BosCli) syncExecute(filter, deleteFilter *bosFilter, args *syncArgs, st
 
 	var (
 		srcBosClient bosClientInterface
+		symlinks     *symlinkMapper
 		srcFiles     fileListIterator
 		dstFiles     fileListIterator
 		atBothSide   syncStrategyInfterface
 		notAtSrc     syncStrategyInfterface
-		opSync       sync.WaitGroup
 		retErr       error
 		err          error
 	)
@@ -1135,9 +3023,15 @@ func (b *BosCli) syncExecute(filter, deleteFilter *bosFilter, args *syncArgs, st
 			return nil, BOSCLI_EMPTY_CODE, err
 		} else {
 			srcFiles = NewLocalFileIterator(absSrcPath, filter, true)
+			if args.preserveSymlinks {
+				if symlinks, err = newSymlinkMapper(absSrcPath); err != nil {
+					return nil, BOSCLI_EMPTY_CODE, err
+				}
+			}
 		}
 	} else if args.srcType == IS_BOS {
-		if srcBosClient, err = initBosClientForBucket("", "", args.srcBucketName); err != nil {
+		srcBosClient, err = b.initSrcBosClient(args.srcBucketName, args.dstType == IS_BOS)
+		if err != nil {
 			return nil, BOSCLI_EMPTY_CODE, err
 		} else {
 			srcFiles = NewObjectListIterator(srcBosClient, filter, args.srcBucketName,
@@ -1196,55 +3090,40 @@ func (b *BosCli) syncExecute(filter, deleteFilter *bosFilter, args *syncArgs, st
 	}
 	comparator := NewComparator(atBothSide, notAtDst, notAtSrc, args, srcFiles, dstFiles)
 
-	// init channel
-	syncOpPool := make(chan int, args.syncProcessingNum)
-	executeResultChan := make(chan int, args.syncProcessingNum)
-	isEnd := make(chan bool, 1)
-	syncResultChan := make(chan executeResult, 1)
+	executor := newConcurrentExecutor(args.syncProcessingNum)
 	overWriteDst := true
 
-	// define a function to count the failed and successed number of sync operation
-	syncResultCount := func(syncResultChan chan executeResult) {
-		failed := 0
-		successed := 0
-		for {
-			select {
-			case result := <-executeResultChan:
-				if result == -1 { // fail
-					failed += 1
-				} else if result == 1 { // success
-					successed += 1
-				}
-			case <-isEnd:
-				syncResultChan <- executeResult{failed: failed, successed: successed}
-				return
-			}
-		}
-	}
-
-	// this function is used to execute sync operation
-	syncOpFunc := func(syncInfo *syncOpDetail, flag, prompt string, wg *sync.WaitGroup) {
+	// this function is used to execute sync operation, it fails when error is returned
+	syncOpFunc := func(syncInfo *syncOpDetail, flag, prompt, src, dst string) error {
 		var (
 			err error
 		)
 
-		defer func() {
-			wg.Done()
-			<-syncOpPool
-		}()
+		// archived objects can not be read until they are restored
+		if flag == SYNC_OP_COPY || flag == SYNC_OP_DOWNLOAD {
+			if b.skipArchivedObject(srcBosClient, flag, args.srcBucketName, syncInfo.srcPath,
+				syncInfo.srcFileInfo.storageClass, dst, args.restoreArchive) {
+				return fmt.Errorf("archived object is skipped: %s", src)
+			}
+		}
 
 		switch flag {
 		case SYNC_OP_COPY:
 			err = b.handler.utilCopyObject(srcBosClient, b.bosClient, args.srcBucketName,
 				syncInfo.srcPath, args.dstBucketName, syncInfo.dstPath, storageClass,
 				syncInfo.srcFileInfo.size, syncInfo.srcFileInfo.mtime, syncInfo.srcFileInfo.gtime,
-				restart)
+				restart, args.meta)
 
 		case SYNC_OP_UPLOAD:
+			if targetKey, ok := symlinks.targetKey(syncInfo.srcFileInfo,
+				syncInfo.dstPath); ok {
+				err = b.putSymlink(args.dstBucketName, targetKey, syncInfo.dstPath)
+				break
+			}
 			err = b.handler.utilUploadFile(b.bosClient, syncInfo.srcPath,
 				syncInfo.srcFileInfo.realPath, args.dstBucketName, syncInfo.dstPath, storageClass,
 				syncInfo.srcFileInfo.size, syncInfo.srcFileInfo.mtime, syncInfo.srcFileInfo.gtime,
-				restart)
+				restart, args.meta)
 
 		case SYNC_OP_DOWNLOAD:
 			err = b.handler.utilDownloadObject(b.bosClient, args.srcBucketName, syncInfo.srcPath,
@@ -1261,18 +3140,13 @@ func (b *BosCli) syncExecute(filter, deleteFilter *bosFilter, args *syncArgs, st
 			err = fmt.Errorf("Sync destination is a folder instead of a file: %s", args.dstPath)
 		}
 
-		// send result to syncResultCount
 		if err != nil {
-			executeResultChan <- -1 // -1 represent failed
 			printIfNotQuiet("Failed %s. Error: %s\n", prompt, err)
-		} else {
-			executeResultChan <- 1 // 1 represent successed
+			printOperationRecord(flag, OPERATION_STATUS_FAILED, src, dst, err)
 		}
+		return err
 	}
 
-	// start syncResultCount
-	go syncResultCount(syncResultChan)
-
 	for {
 		syncInfo, err := comparator.next()
 		if err != nil {
@@ -1285,9 +3159,10 @@ func (b *BosCli) syncExecute(filter, deleteFilter *bosFilter, args *syncArgs, st
 		if syncInfo.err != nil {
 			if ErrIsNotExist(syncInfo.err) {
 				if !dryrun {
-					executeResultChan <- -1
+					executor.fail()
 				}
 				printIfNotQuiet("Failed: %s. It may have been deleted!\n", syncInfo.err)
+				printOperationRecord(SYNC_OP_ERROR, OPERATION_STATUS_FAILED, "", "", syncInfo.err)
 				continue
 			} else {
 				retErr = syncInfo.err
@@ -1302,25 +3177,28 @@ func (b *BosCli) syncExecute(filter, deleteFilter *bosFilter, args *syncArgs, st
 
 		prompt := ""
 		flag := ""
+		src := ""
+		dst := ""
 		switch syncInfo.syncFunc {
 		// copy, upload or download
 		case OPERATE_CMD_COPY:
 			if args.syncType == LOCAL_TO_BOS {
 				flag = SYNC_OP_UPLOAD
-				prompt = fmt.Sprintf("%s: %s to bos:/%s/%s", flag, syncInfo.srcPath,
-					args.dstBucketName, syncInfo.dstPath)
+				src = syncInfo.srcPath
+				dst = BOS_PATH_PREFIX + args.dstBucketName + "/" + syncInfo.dstPath
 			} else if args.syncType == BOS_TO_LOCAL {
 				flag = SYNC_OP_DOWNLOAD
-				prompt = fmt.Sprintf("%s: bos:/%s/%s to %s", flag, args.srcBucketName,
-					syncInfo.srcPath, syncInfo.dstPath)
+				src = BOS_PATH_PREFIX + args.srcBucketName + "/" + syncInfo.srcPath
+				dst = syncInfo.dstPath
 			} else if args.syncType == BOS_TO_BOS {
 				flag = SYNC_OP_COPY
-				prompt = fmt.Sprintf("%s: bos:/%s/%s to bos:/%s/%s", flag, args.srcBucketName,
-					syncInfo.srcPath, args.dstBucketName, syncInfo.dstPath)
+				src = BOS_PATH_PREFIX + args.srcBucketName + "/" + syncInfo.srcPath
+				dst = BOS_PATH_PREFIX + args.dstBucketName + "/" + syncInfo.dstPath
 			} else {
 				retErr = fmt.Errorf("Unknown sync operation!")
 				goto END
 			}
+			prompt = fmt.Sprintf("%s: %s to %s", flag, src, dst)
 			if args.dstType == IS_LOCAL && util.DoesPathExist(syncInfo.dstPath) &&
 				util.DoesDirExist(syncInfo.dstPath) {
 				flag = SYNC_OP_ERROR
@@ -1329,10 +3207,13 @@ func (b *BosCli) syncExecute(filter, deleteFilter *bosFilter, args *syncArgs, st
 		case OPERATE_CMD_DELETE:
 			if args.dstType == IS_BOS {
 				flag = SYNC_OP_REMOVE
-				prompt = fmt.Sprintf("%s: bos:/%s/%s", flag, args.dstBucketName, syncInfo.dstPath)
+				dst = BOS_PATH_PREFIX + args.dstBucketName + "/" + syncInfo.dstPath
 			} else if args.dstType == IS_LOCAL {
 				flag = SYNC_OP_DELETE
-				prompt = fmt.Sprintf("%s: %s", flag, syncInfo.dstPath)
+				dst = syncInfo.dstPath
+			}
+			if dst != "" {
+				prompt = fmt.Sprintf("%s: %s", flag, dst)
 			}
 		default:
 			continue
@@ -1340,21 +3221,15 @@ func (b *BosCli) syncExecute(filter, deleteFilter *bosFilter, args *syncArgs, st
 
 		if dryrun && prompt != "" {
 			printIfNotQuiet("%s\n", prompt)
+			printOperationRecord(flag, OPERATION_STATUS_DRYRUN, src, dst, nil)
 		} else {
-			syncOpPool <- 1
-			opSync.Add(1)
-			go syncOpFunc(syncInfo, flag, prompt, &opSync)
+			executor.execute(func() error {
+				return syncOpFunc(syncInfo, flag, prompt, src, dst)
+			})
 		}
 	}
 
 END:
 	// waiting for all sync operation finish
-	opSync.Wait()
-
-	// end count result
-	// Sleep 200ms, waiting for syncResultCount add all op results
-	time.Sleep(200 * time.Millisecond)
-	isEnd <- true
-	ret := <-syncResultChan
-	return &ret, BOSCLI_EMPTY_CODE, retErr
+	return executor.wait(), BOSCLI_EMPTY_CODE, retErr
 }
@@ -23,6 +23,7 @@ import (
 
 import (
 	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
 	"github.com/baidubce/bce-sdk-go/services/bos"
 	"github.com/baidubce/bce-sdk-go/services/bos/api"
 	"github.com/baidubce/bce-sdk-go/util/log"
@@ -245,6 +246,10 @@ type getObjectMetaResp struct {
 	ret *api.GetObjectMetaResult
 }
 
+type headObjectResp struct {
+	ret map[string]string
+}
+
 // Wrapper GetObjectMeta
 func (b *bosClientWrapper) GetObjectMeta(bucket, object string) (*api.GetObjectMetaResult, error) {
 	req := &getObjectMetaReq{bucket: bucket, object: object}
@@ -273,6 +278,66 @@ func (b *bosClientWrapper) GetObjectMeta(bucket, object string) (*api.GetObjectM
 	return nil, err
 }
 
+// head object is not provided by go sdk, the response headers are needed by stat
+type objectHeaderInterface interface {
+	HeadObject(string, string) (map[string]string, error)
+}
+
+// head object and return all response headers, such as x-bce-server-side-encryption which
+// is not parsed by GetObjectMeta of go sdk.
+func headObject(bosClient bosClientInterface, bucket, object string) (map[string]string, error) {
+	switch client := bosClient.(type) {
+	case *bos.Client:
+		return sendHeadObject(client, bucket, object)
+	case objectHeaderInterface:
+		return client.HeadObject(bucket, object)
+	}
+	return nil, fmt.Errorf("head object is not supported by this client")
+}
+
+func sendHeadObject(bosClient *bos.Client, bucket, object string) (map[string]string, error) {
+	req := &bce.BceRequest{}
+	req.SetUri(bce.URI_PREFIX + bucket + "/" + object)
+	req.SetMethod(http.HEAD)
+	resp := &bce.BceResponse{}
+	if err := api.SendRequest(bosClient, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.IsFail() {
+		return nil, resp.ServiceError()
+	}
+	defer resp.Body().Close()
+	return resp.Headers(), nil
+}
+
+// Wrapper of head object
+func (b *bosClientWrapper) HeadObject(bucket, object string) (map[string]string, error) {
+	req := &getObjectMetaReq{bucket: bucket, object: object}
+	resp := &headObjectResp{}
+
+	hoFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		hoReq, ok := req.(*getObjectMetaReq)
+		if !ok {
+			return fmt.Errorf("Error HeadObject request type!")
+		}
+		hoResp, ok := resp.(*headObjectResp)
+		if !ok {
+			return fmt.Errorf("Error HeadObject response type!")
+		}
+		ret, err := sendHeadObject(bosClient, hoReq.bucket, hoReq.object)
+		if err == nil {
+			hoResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, hoFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return nil, err
+}
+
 type copyObjectReq struct {
 	bucket    string
 	object    string
@@ -402,6 +467,163 @@ func (b *bosClientWrapper) PutObjectFromFile(bucket, object, fileName string,
 	return "", err
 }
 
+type putObjectFromBytesReq struct {
+	bucket   string
+	object   string
+	bytesArr []byte
+	args     *api.PutObjectArgs
+}
+
+func (p *putObjectFromBytesReq) getBucketName() string {
+	return p.bucket
+}
+
+type putObjectFromBytesResp struct {
+	ret string
+}
+
+// Wrapper of PutObjectFromBytes
+func (b *bosClientWrapper) PutObjectFromBytes(bucket, object string, bytesArr []byte,
+	args *api.PutObjectArgs) (string, error) {
+	req := &putObjectFromBytesReq{
+		bucket:   bucket,
+		object:   object,
+		bytesArr: bytesArr,
+		args:     args,
+	}
+	resp := &putObjectFromBytesResp{}
+
+	poFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		poReq, ok := req.(*putObjectFromBytesReq)
+		if !ok {
+			return fmt.Errorf("Error PutObjectFromBytes request type!")
+		}
+		poResp, ok := resp.(*putObjectFromBytesResp)
+		if !ok {
+			return fmt.Errorf("Error PutObjectFromBytes response type!")
+		}
+		ret, err := bosClient.PutObjectFromBytes(poReq.bucket, poReq.object, poReq.bytesArr,
+			poReq.args)
+		if err == nil {
+			poResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, poFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return "", err
+}
+
+type appendObjectReq struct {
+	bucket   string
+	object   string
+	bytesArr []byte
+	args     *api.AppendObjectArgs
+}
+
+func (a *appendObjectReq) getBucketName() string {
+	return a.bucket
+}
+
+type appendObjectResp struct {
+	ret *api.AppendObjectResult
+}
+
+// Wrapper of AppendObject, the content is read into bytes, so that it can be sent again when
+// retrying with the endpoint got from BOS
+func (b *bosClientWrapper) AppendObject(bucket, object string, content *bce.Body,
+	args *api.AppendObjectArgs) (*api.AppendObjectResult, error) {
+	bytesArr, err := ioutil.ReadAll(content.Stream())
+	if err != nil {
+		return nil, err
+	}
+	req := &appendObjectReq{
+		bucket:   bucket,
+		object:   object,
+		bytesArr: bytesArr,
+		args:     args,
+	}
+	resp := &appendObjectResp{}
+
+	aoFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		aoReq, ok := req.(*appendObjectReq)
+		if !ok {
+			return fmt.Errorf("Error AppendObject request type!")
+		}
+		aoResp, ok := resp.(*appendObjectResp)
+		if !ok {
+			return fmt.Errorf("Error AppendObject response type!")
+		}
+		body, err := bce.NewBodyFromBytes(aoReq.bytesArr)
+		if err != nil {
+			return err
+		}
+		ret, err := bosClient.AppendObject(aoReq.bucket, aoReq.object, body, aoReq.args)
+		if err == nil {
+			aoResp.ret = ret
+		}
+		return err
+	}
+
+	err = retryHandler(b.bosClient, aoFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return nil, err
+}
+
+type fetchObjectReq struct {
+	bucket string
+	object string
+	source string
+	args   *api.FetchObjectArgs
+}
+
+func (f *fetchObjectReq) getBucketName() string {
+	return f.bucket
+}
+
+type fetchObjectResp struct {
+	ret *api.FetchObjectResult
+}
+
+// Wrapper of FetchObject, BOS gets the object from source url
+func (b *bosClientWrapper) FetchObject(bucket, object, source string,
+	args *api.FetchObjectArgs) (*api.FetchObjectResult, error) {
+	req := &fetchObjectReq{
+		bucket: bucket,
+		object: object,
+		source: source,
+		args:   args,
+	}
+	resp := &fetchObjectResp{}
+
+	foFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		foReq, ok := req.(*fetchObjectReq)
+		if !ok {
+			return fmt.Errorf("Error FetchObject request type!")
+		}
+		foResp, ok := resp.(*fetchObjectResp)
+		if !ok {
+			return fmt.Errorf("Error FetchObject response type!")
+		}
+		ret, err := bosClient.FetchObject(foReq.bucket, foReq.object, foReq.source, foReq.args)
+		if err == nil {
+			foResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, foFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return nil, err
+}
+
 type uploadSuperFileReq struct {
 	bucket       string
 	object       string
@@ -729,162 +951,670 @@ func (b *bosClientWrapper) DeleteBucketLogging(bucket string) error {
 	return retryHandler(b.bosClient, dlFunc, req, nil)
 }
 
-type putStorageClassReq struct {
-	bucket       string
-	storageClass string
+type putCorsReq struct {
+	bucket string
+	cors   string
 }
 
-func (p *putStorageClassReq) getBucketName() string {
+func (p *putCorsReq) getBucketName() string {
 	return p.bucket
 }
 
-// Wrapper of PutBucketStorageclass
-func (b *bosClientWrapper) PutBucketStorageclass(bucket, storageClass string) error {
-
-	req := &putStorageClassReq{
-		bucket:       bucket,
-		storageClass: storageClass,
+// Wrapper of PutBucketCorsFromString
+func (b *bosClientWrapper) PutBucketCorsFromString(bucket, cors string) error {
+	req := &putCorsReq{
+		bucket: bucket,
+		cors:   cors,
 	}
 
-	plFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
-		plReq, ok := req.(*putStorageClassReq)
+	pcFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		pcReq, ok := req.(*putCorsReq)
 		if !ok {
-			return fmt.Errorf("Error PutBucketStorageclass request type!")
+			return fmt.Errorf("Error PutBucketCors request type!")
 		}
-		err := bosClient.PutBucketStorageclass(plReq.bucket, plReq.storageClass)
-		return err
+		return bosClient.PutBucketCorsFromString(pcReq.bucket, pcReq.cors)
 	}
 
-	return retryHandler(b.bosClient, plFunc, req, nil)
+	return retryHandler(b.bosClient, pcFunc, req, nil)
 }
 
-type getStorageClassReq struct {
+type getCorsReq struct {
 	bucket string
 }
 
-func (g *getStorageClassReq) getBucketName() string {
+func (g *getCorsReq) getBucketName() string {
 	return g.bucket
 }
 
-type getStorageClassResp struct {
-	ret string
+type getCorsResp struct {
+	ret *api.GetBucketCorsResult
 }
 
-// Wrapper of GetBucketStorageclass
-func (b *bosClientWrapper) GetBucketStorageclass(bucket string) (string, error) {
+// Wrapper of GetBucketCors
+func (b *bosClientWrapper) GetBucketCors(bucket string) (*api.GetBucketCorsResult, error) {
 
-	req := &getStorageClassReq{
+	req := &getCorsReq{
 		bucket: bucket,
 	}
-	resp := &getStorageClassResp{}
+	resp := &getCorsResp{}
 
-	gsFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
-		gsReq, ok := req.(*getStorageClassReq)
+	gcFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		gcReq, ok := req.(*getCorsReq)
 		if !ok {
-			return fmt.Errorf("Error GetBucketStorageclass request type!")
+			return fmt.Errorf("Error GetBucketCors request type!")
 		}
-		gsResp, ok := resp.(*getStorageClassResp)
+		gcResp, ok := resp.(*getCorsResp)
 		if !ok {
-			return fmt.Errorf("Error GetBucketStorageclass response type!")
+			return fmt.Errorf("Error GetBucketCors response type!")
 		}
-		ret, err := bosClient.GetBucketStorageclass(gsReq.bucket)
+		ret, err := bosClient.GetBucketCors(gcReq.bucket)
 		if err == nil {
-			gsResp.ret = ret
+			gcResp.ret = ret
 		}
 		return err
 	}
 
-	err := retryHandler(b.bosClient, gsFunc, req, resp)
+	err := retryHandler(b.bosClient, gcFunc, req, resp)
 	if err == nil {
 		return resp.ret, nil
 	}
-	return "", err
+	return nil, err
 }
 
-type uploadPartCopyReq struct {
-	srcBucket  string
-	srcObject  string
-	dstBucket  string
-	dstObject  string
-	uploadId   string
-	partNumber int
-	args       *api.UploadPartCopyArgs
+type deleteCorsReq struct {
+	bucket string
 }
 
-func (c *uploadPartCopyReq) getBucketName() string {
-	return c.dstBucket
+func (d *deleteCorsReq) getBucketName() string {
+	return d.bucket
 }
 
-type uploadPartCopyResp struct {
-	ret *api.CopyObjectResult
+// Wrapper of DeleteBucketCors
+func (b *bosClientWrapper) DeleteBucketCors(bucket string) error {
+
+	req := &deleteCorsReq{
+		bucket: bucket,
+	}
+
+	dcFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		dcReq, ok := req.(*deleteCorsReq)
+		if !ok {
+			return fmt.Errorf("Error DeleteBucketCors request type!")
+		}
+		return bosClient.DeleteBucketCors(dcReq.bucket)
+	}
+
+	return retryHandler(b.bosClient, dcFunc, req, nil)
 }
 
-// Wrapper of GetBucketStorageclass
-func (b *bosClientWrapper) UploadPartCopy(bucket, object, srcBucket, srcObject, uploadId string,
-	partNumber int, args *api.UploadPartCopyArgs) (*api.CopyObjectResult, error) {
+type putReplicationReq struct {
+	bucket string
+	conf   string
+	ruleId string
+}
 
-	req := &uploadPartCopyReq{
-		srcBucket:  srcBucket,
-		srcObject:  srcObject,
-		dstBucket:  bucket,
-		dstObject:  object,
-		uploadId:   uploadId,
-		partNumber: partNumber,
-		args:       args,
+func (p *putReplicationReq) getBucketName() string {
+	return p.bucket
+}
+
+// Wrapper of PutBucketReplicationFromString
+func (b *bosClientWrapper) PutBucketReplicationFromString(bucket, conf, ruleId string) error {
+	req := &putReplicationReq{
+		bucket: bucket,
+		conf:   conf,
+		ruleId: ruleId,
 	}
-	resp := &uploadPartCopyResp{}
 
-	cpFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
-		cpReq, ok := req.(*uploadPartCopyReq)
+	prFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		prReq, ok := req.(*putReplicationReq)
 		if !ok {
-			return fmt.Errorf("Error uploadPartCopyReq request type!")
+			return fmt.Errorf("Error PutBucketReplication request type!")
 		}
-		cpResp, ok := resp.(*uploadPartCopyResp)
+		return bosClient.PutBucketReplicationFromString(prReq.bucket, prReq.conf, prReq.ruleId)
+	}
+
+	return retryHandler(b.bosClient, prFunc, req, nil)
+}
+
+type replicationReq struct {
+	bucket string
+	ruleId string
+}
+
+func (r *replicationReq) getBucketName() string {
+	return r.bucket
+}
+
+type getReplicationResp struct {
+	ret *api.GetBucketReplicationResult
+}
+
+// Wrapper of GetBucketReplication
+func (b *bosClientWrapper) GetBucketReplication(bucket, ruleId string) (
+	*api.GetBucketReplicationResult, error) {
+
+	req := &replicationReq{
+		bucket: bucket,
+		ruleId: ruleId,
+	}
+	resp := &getReplicationResp{}
+
+	grFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		grReq, ok := req.(*replicationReq)
 		if !ok {
-			return fmt.Errorf("Error uploadPartCopyResp response type!")
+			return fmt.Errorf("Error GetBucketReplication request type!")
 		}
-		ret, err := bosClient.UploadPartCopy(cpReq.dstBucket, cpReq.dstObject, cpReq.srcBucket,
-			cpReq.srcObject, cpReq.uploadId, cpReq.partNumber, cpReq.args)
+		grResp, ok := resp.(*getReplicationResp)
+		if !ok {
+			return fmt.Errorf("Error GetBucketReplication response type!")
+		}
+		ret, err := bosClient.GetBucketReplication(grReq.bucket, grReq.ruleId)
 		if err == nil {
-			cpResp.ret = ret
+			grResp.ret = ret
 		}
 		return err
 	}
 
-	err := retryHandler(b.bosClient, cpFunc, req, resp)
+	err := retryHandler(b.bosClient, grFunc, req, resp)
 	if err == nil {
 		return resp.ret, nil
 	}
 	return nil, err
 }
 
-// Wrapper of BasicUploadPart
-func (b *bosClientWrapper) BasicUploadPart(bucket, object, uploadId string, partNumber int,
-	content *bce.Body) (string, error) {
+type listReplicationResp struct {
+	ret *api.ListBucketReplicationResult
+}
 
-	var (
-		ret         string
-		err         error
-		retryBuffer bytes.Buffer
-	)
+// Wrapper of ListBucketReplication
+func (b *bosClientWrapper) ListBucketReplication(bucket string) (
+	*api.ListBucketReplicationResult, error) {
 
-	teeReader := io.TeeReader(content.Stream(), &retryBuffer)
-	content.SetStream(ioutil.NopCloser(teeReader))
+	req := &replicationReq{
+		bucket: bucket,
+	}
+	resp := &listReplicationResp{}
 
-	// try get endpoint from cache and execute function 'call'
-	if ok := modifiyBosClientEndpointByBucketNameCache(b.bosClient, bucket); ok {
-		ret, err = b.bosClient.BasicUploadPart(bucket, object, uploadId, partNumber, content)
-		if err == nil || !shouldRetry(err) {
-			return ret, err
-		} else {
-			ioutil.ReadAll(teeReader)
-			content.SetStream(ioutil.NopCloser(&retryBuffer))
+	lrFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		lrReq, ok := req.(*replicationReq)
+		if !ok {
+			return fmt.Errorf("Error ListBucketReplication request type!")
 		}
+		lrResp, ok := resp.(*listReplicationResp)
+		if !ok {
+			return fmt.Errorf("Error ListBucketReplication response type!")
+		}
+		ret, err := bosClient.ListBucketReplication(lrReq.bucket)
+		if err == nil {
+			lrResp.ret = ret
+		}
+		return err
 	}
 
-	// retry execute function 'call', but get endpiont from BOS
-	log.Debugf("First process failed or can't get endpoint of bucket from cache, error: %s", err)
-	epErr := modifiyBosClientEndpointByBucketNameBos(b.bosClient, bucket)
+	err := retryHandler(b.bosClient, lrFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return nil, err
+}
+
+// Wrapper of DeleteBucketReplication
+func (b *bosClientWrapper) DeleteBucketReplication(bucket, ruleId string) error {
+
+	req := &replicationReq{
+		bucket: bucket,
+		ruleId: ruleId,
+	}
+
+	drFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		drReq, ok := req.(*replicationReq)
+		if !ok {
+			return fmt.Errorf("Error DeleteBucketReplication request type!")
+		}
+		return bosClient.DeleteBucketReplication(drReq.bucket, drReq.ruleId)
+	}
+
+	return retryHandler(b.bosClient, drFunc, req, nil)
+}
+
+type getReplicationProgressResp struct {
+	ret *api.GetBucketReplicationProgressResult
+}
+
+// Wrapper of GetBucketReplicationProgress
+func (b *bosClientWrapper) GetBucketReplicationProgress(bucket, ruleId string) (
+	*api.GetBucketReplicationProgressResult, error) {
+
+	req := &replicationReq{
+		bucket: bucket,
+		ruleId: ruleId,
+	}
+	resp := &getReplicationProgressResp{}
+
+	gpFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		gpReq, ok := req.(*replicationReq)
+		if !ok {
+			return fmt.Errorf("Error GetBucketReplicationProgress request type!")
+		}
+		gpResp, ok := resp.(*getReplicationProgressResp)
+		if !ok {
+			return fmt.Errorf("Error GetBucketReplicationProgress response type!")
+		}
+		ret, err := bosClient.GetBucketReplicationProgress(gpReq.bucket, gpReq.ruleId)
+		if err == nil {
+			gpResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, gpFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return nil, err
+}
+
+type putEncryptionReq struct {
+	bucket    string
+	algorithm string
+}
+
+func (p *putEncryptionReq) getBucketName() string {
+	return p.bucket
+}
+
+// Wrapper of PutBucketEncryption
+func (b *bosClientWrapper) PutBucketEncryption(bucket, algorithm string) error {
+
+	req := &putEncryptionReq{
+		bucket:    bucket,
+		algorithm: algorithm,
+	}
+
+	peFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		peReq, ok := req.(*putEncryptionReq)
+		if !ok {
+			return fmt.Errorf("Error PutBucketEncryption request type!")
+		}
+		return bosClient.PutBucketEncryption(peReq.bucket, peReq.algorithm)
+	}
+
+	return retryHandler(b.bosClient, peFunc, req, nil)
+}
+
+type getEncryptionResp struct {
+	ret string
+}
+
+// Wrapper of GetBucketEncryption
+func (b *bosClientWrapper) GetBucketEncryption(bucket string) (string, error) {
+
+	req := &olnyOneStringReq{
+		bucket: bucket,
+	}
+	resp := &getEncryptionResp{}
+
+	geFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		geReq, ok := req.(*olnyOneStringReq)
+		if !ok {
+			return fmt.Errorf("Error GetBucketEncryption request type!")
+		}
+		geResp, ok := resp.(*getEncryptionResp)
+		if !ok {
+			return fmt.Errorf("Error GetBucketEncryption response type!")
+		}
+		ret, err := bosClient.GetBucketEncryption(geReq.bucket)
+		if err == nil {
+			geResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, geFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return "", err
+}
+
+// Wrapper of DeleteBucketEncryption
+func (b *bosClientWrapper) DeleteBucketEncryption(bucket string) error {
+
+	req := &olnyOneStringReq{
+		bucket: bucket,
+	}
+
+	deFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		deReq, ok := req.(*olnyOneStringReq)
+		if !ok {
+			return fmt.Errorf("Error DeleteBucketEncryption request type!")
+		}
+		return bosClient.DeleteBucketEncryption(deReq.bucket)
+	}
+
+	return retryHandler(b.bosClient, deFunc, req, nil)
+}
+
+type putStaticWebsiteReq struct {
+	bucket string
+	args   *api.PutBucketStaticWebsiteArgs
+}
+
+func (p *putStaticWebsiteReq) getBucketName() string {
+	return p.bucket
+}
+
+// Wrapper of PutBucketStaticWebsiteFromStruct
+func (b *bosClientWrapper) PutBucketStaticWebsiteFromStruct(bucket string,
+	obj *api.PutBucketStaticWebsiteArgs) error {
+
+	req := &putStaticWebsiteReq{
+		bucket: bucket,
+		args:   obj,
+	}
+
+	pwFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		pwReq, ok := req.(*putStaticWebsiteReq)
+		if !ok {
+			return fmt.Errorf("Error PutBucketStaticWebsiteFromStruct request type!")
+		}
+		return bosClient.PutBucketStaticWebsiteFromStruct(pwReq.bucket, pwReq.args)
+	}
+
+	return retryHandler(b.bosClient, pwFunc, req, nil)
+}
+
+type getStaticWebsiteResp struct {
+	ret *api.GetBucketStaticWebsiteResult
+}
+
+// Wrapper of GetBucketStaticWebsite
+func (b *bosClientWrapper) GetBucketStaticWebsite(bucket string) (
+	*api.GetBucketStaticWebsiteResult, error) {
+
+	req := &olnyOneStringReq{
+		bucket: bucket,
+	}
+	resp := &getStaticWebsiteResp{}
+
+	gwFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		gwReq, ok := req.(*olnyOneStringReq)
+		if !ok {
+			return fmt.Errorf("Error GetBucketStaticWebsite request type!")
+		}
+		gwResp, ok := resp.(*getStaticWebsiteResp)
+		if !ok {
+			return fmt.Errorf("Error GetBucketStaticWebsite response type!")
+		}
+		ret, err := bosClient.GetBucketStaticWebsite(gwReq.bucket)
+		if err == nil {
+			gwResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, gwFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return nil, err
+}
+
+// Wrapper of DeleteBucketStaticWebsite
+func (b *bosClientWrapper) DeleteBucketStaticWebsite(bucket string) error {
+
+	req := &olnyOneStringReq{
+		bucket: bucket,
+	}
+
+	dwFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		dwReq, ok := req.(*olnyOneStringReq)
+		if !ok {
+			return fmt.Errorf("Error DeleteBucketStaticWebsite request type!")
+		}
+		return bosClient.DeleteBucketStaticWebsite(dwReq.bucket)
+	}
+
+	return retryHandler(b.bosClient, dwFunc, req, nil)
+}
+
+type putTrashReq struct {
+	bucket   string
+	trashReq api.PutBucketTrashReq
+}
+
+func (p *putTrashReq) getBucketName() string {
+	return p.bucket
+}
+
+// Wrapper of PutBucketTrash
+func (b *bosClientWrapper) PutBucketTrash(bucket string, trashReq api.PutBucketTrashReq) error {
+
+	req := &putTrashReq{
+		bucket:   bucket,
+		trashReq: trashReq,
+	}
+
+	ptFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		ptReq, ok := req.(*putTrashReq)
+		if !ok {
+			return fmt.Errorf("Error PutBucketTrash request type!")
+		}
+		return bosClient.PutBucketTrash(ptReq.bucket, ptReq.trashReq)
+	}
+
+	return retryHandler(b.bosClient, ptFunc, req, nil)
+}
+
+type getTrashResp struct {
+	ret *api.GetBucketTrashResult
+}
+
+// Wrapper of GetBucketTrash
+func (b *bosClientWrapper) GetBucketTrash(bucket string) (*api.GetBucketTrashResult, error) {
+
+	req := &olnyOneStringReq{
+		bucket: bucket,
+	}
+	resp := &getTrashResp{}
+
+	gtFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		gtReq, ok := req.(*olnyOneStringReq)
+		if !ok {
+			return fmt.Errorf("Error GetBucketTrash request type!")
+		}
+		gtResp, ok := resp.(*getTrashResp)
+		if !ok {
+			return fmt.Errorf("Error GetBucketTrash response type!")
+		}
+		ret, err := bosClient.GetBucketTrash(gtReq.bucket)
+		if err == nil {
+			gtResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, gtFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return nil, err
+}
+
+// Wrapper of DeleteBucketTrash
+func (b *bosClientWrapper) DeleteBucketTrash(bucket string) error {
+
+	req := &olnyOneStringReq{
+		bucket: bucket,
+	}
+
+	dtFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		dtReq, ok := req.(*olnyOneStringReq)
+		if !ok {
+			return fmt.Errorf("Error DeleteBucketTrash request type!")
+		}
+		return bosClient.DeleteBucketTrash(dtReq.bucket)
+	}
+
+	return retryHandler(b.bosClient, dtFunc, req, nil)
+}
+
+type putStorageClassReq struct {
+	bucket       string
+	storageClass string
+}
+
+func (p *putStorageClassReq) getBucketName() string {
+	return p.bucket
+}
+
+// Wrapper of PutBucketStorageclass
+func (b *bosClientWrapper) PutBucketStorageclass(bucket, storageClass string) error {
+
+	req := &putStorageClassReq{
+		bucket:       bucket,
+		storageClass: storageClass,
+	}
+
+	plFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		plReq, ok := req.(*putStorageClassReq)
+		if !ok {
+			return fmt.Errorf("Error PutBucketStorageclass request type!")
+		}
+		err := bosClient.PutBucketStorageclass(plReq.bucket, plReq.storageClass)
+		return err
+	}
+
+	return retryHandler(b.bosClient, plFunc, req, nil)
+}
+
+type getStorageClassReq struct {
+	bucket string
+}
+
+func (g *getStorageClassReq) getBucketName() string {
+	return g.bucket
+}
+
+type getStorageClassResp struct {
+	ret string
+}
+
+// Wrapper of GetBucketStorageclass
+func (b *bosClientWrapper) GetBucketStorageclass(bucket string) (string, error) {
+
+	req := &getStorageClassReq{
+		bucket: bucket,
+	}
+	resp := &getStorageClassResp{}
+
+	gsFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		gsReq, ok := req.(*getStorageClassReq)
+		if !ok {
+			return fmt.Errorf("Error GetBucketStorageclass request type!")
+		}
+		gsResp, ok := resp.(*getStorageClassResp)
+		if !ok {
+			return fmt.Errorf("Error GetBucketStorageclass response type!")
+		}
+		ret, err := bosClient.GetBucketStorageclass(gsReq.bucket)
+		if err == nil {
+			gsResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, gsFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return "", err
+}
+
+type uploadPartCopyReq struct {
+	srcBucket  string
+	srcObject  string
+	dstBucket  string
+	dstObject  string
+	uploadId   string
+	partNumber int
+	args       *api.UploadPartCopyArgs
+}
+
+func (c *uploadPartCopyReq) getBucketName() string {
+	return c.dstBucket
+}
+
+type uploadPartCopyResp struct {
+	ret *api.CopyObjectResult
+}
+
+// Wrapper of GetBucketStorageclass
+func (b *bosClientWrapper) UploadPartCopy(bucket, object, srcBucket, srcObject, uploadId string,
+	partNumber int, args *api.UploadPartCopyArgs) (*api.CopyObjectResult, error) {
+
+	req := &uploadPartCopyReq{
+		srcBucket:  srcBucket,
+		srcObject:  srcObject,
+		dstBucket:  bucket,
+		dstObject:  object,
+		uploadId:   uploadId,
+		partNumber: partNumber,
+		args:       args,
+	}
+	resp := &uploadPartCopyResp{}
+
+	cpFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		cpReq, ok := req.(*uploadPartCopyReq)
+		if !ok {
+			return fmt.Errorf("Error uploadPartCopyReq request type!")
+		}
+		cpResp, ok := resp.(*uploadPartCopyResp)
+		if !ok {
+			return fmt.Errorf("Error uploadPartCopyResp response type!")
+		}
+		ret, err := bosClient.UploadPartCopy(cpReq.dstBucket, cpReq.dstObject, cpReq.srcBucket,
+			cpReq.srcObject, cpReq.uploadId, cpReq.partNumber, cpReq.args)
+		if err == nil {
+			cpResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, cpFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return nil, err
+}
+
+// Wrapper of BasicUploadPart
+func (b *bosClientWrapper) BasicUploadPart(bucket, object, uploadId string, partNumber int,
+	content *bce.Body) (string, error) {
+
+	var (
+		ret         string
+		err         error
+		retryBuffer bytes.Buffer
+	)
+
+	teeReader := io.TeeReader(content.Stream(), &retryBuffer)
+	content.SetStream(ioutil.NopCloser(teeReader))
+
+	// try get endpoint from cache and execute function 'call'
+	if ok := modifiyBosClientEndpointByBucketNameCache(b.bosClient, bucket); ok {
+		ret, err = b.bosClient.BasicUploadPart(bucket, object, uploadId, partNumber, content)
+		if err == nil || !shouldRetry(err) {
+			return ret, err
+		} else {
+			ioutil.ReadAll(teeReader)
+			content.SetStream(ioutil.NopCloser(&retryBuffer))
+		}
+	}
+
+	// retry execute function 'call', but get endpiont from BOS
+	log.Debugf("First process failed or can't get endpoint of bucket from cache, error: %s", err)
+	epErr := modifiyBosClientEndpointByBucketNameBos(b.bosClient, bucket)
 	if epErr == nil {
 		return b.bosClient.BasicUploadPart(bucket, object, uploadId, partNumber, content)
 	}
@@ -1126,3 +1856,221 @@ func (b *bosClientWrapper) GetObject(bucket, object string, responseHeaders map[
 	}
 	return nil, err
 }
+
+type restoreObjectReq struct {
+	bucket      string
+	object      string
+	restoreDays int
+	restoreTier string
+}
+
+func (r *restoreObjectReq) getBucketName() string {
+	return r.bucket
+}
+
+// Wrapper RestoreObject - restore an archived object
+func (b *bosClientWrapper) RestoreObject(bucket, object string, restoreDays int,
+	restoreTier string) error {
+
+	req := &restoreObjectReq{
+		bucket:      bucket,
+		object:      object,
+		restoreDays: restoreDays,
+		restoreTier: restoreTier,
+	}
+
+	restoreFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		roReq, ok := req.(*restoreObjectReq)
+		if !ok {
+			return fmt.Errorf("Error restoreObjectReq request type!")
+		}
+		return bosClient.RestoreObject(roReq.bucket, roReq.object, roReq.restoreDays,
+			roReq.restoreTier)
+	}
+	return retryHandler(b.bosClient, restoreFunc, req, nil)
+}
+
+type putSymlinkReq struct {
+	bucket      string
+	object      string
+	symlinkKey  string
+	symlinkArgs *api.PutSymlinkArgs
+}
+
+func (p *putSymlinkReq) getBucketName() string {
+	return p.bucket
+}
+
+// Wrapper PutSymlink - create a symlink pointing to the object in the same bucket
+func (b *bosClientWrapper) PutSymlink(bucket, object, symlinkKey string,
+	symlinkArgs *api.PutSymlinkArgs) error {
+
+	req := &putSymlinkReq{
+		bucket:      bucket,
+		object:      object,
+		symlinkKey:  symlinkKey,
+		symlinkArgs: symlinkArgs,
+	}
+
+	psFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		psReq, ok := req.(*putSymlinkReq)
+		if !ok {
+			return fmt.Errorf("Error PutSymlink request type!")
+		}
+		return bosClient.PutSymlink(psReq.bucket, psReq.object, psReq.symlinkKey,
+			psReq.symlinkArgs)
+	}
+	return retryHandler(b.bosClient, psFunc, req, nil)
+}
+
+type getSymlinkResp struct {
+	target string
+}
+
+// Wrapper GetSymlink - get the target of a symlink
+func (b *bosClientWrapper) GetSymlink(bucket, object string) (string, error) {
+	req := &getObjectMetaReq{bucket: bucket, object: object}
+	resp := &getSymlinkResp{}
+
+	gsFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		gsReq, ok := req.(*getObjectMetaReq)
+		if !ok {
+			return fmt.Errorf("Error GetSymlink request type!")
+		}
+		gsResp, ok := resp.(*getSymlinkResp)
+		if !ok {
+			return fmt.Errorf("Error GetSymlink response type!")
+		}
+		target, err := bosClient.GetSymlink(gsReq.bucket, gsReq.object)
+		if err == nil {
+			gsResp.target = target
+		}
+		return err
+	}
+
+	if err := retryHandler(b.bosClient, gsFunc, req, resp); err != nil {
+		return "", err
+	}
+	return resp.target, nil
+}
+
+type putObjectCannedAclReq struct {
+	bucket    string
+	object    string
+	cannedAcl string
+}
+
+func (p *putObjectCannedAclReq) getBucketName() string {
+	return p.bucket
+}
+
+// Wrapper of PutObjectAclFromCanned
+func (b *bosClientWrapper) PutObjectAclFromCanned(bucket, object, cannedAcl string) error {
+	req := &putObjectCannedAclReq{
+		bucket:    bucket,
+		object:    object,
+		cannedAcl: cannedAcl,
+	}
+
+	pcFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		pcReq, ok := req.(*putObjectCannedAclReq)
+		if !ok {
+			return fmt.Errorf("Error PutObjectAclFromCanned request type!")
+		}
+		return bosClient.PutObjectAclFromCanned(pcReq.bucket, pcReq.object, pcReq.cannedAcl)
+	}
+
+	return retryHandler(b.bosClient, pcFunc, req, nil)
+}
+
+type putObjectAclReq struct {
+	bucket string
+	object string
+	acl    string
+}
+
+func (p *putObjectAclReq) getBucketName() string {
+	return p.bucket
+}
+
+// Wrapper of PutObjectAclFromString
+func (b *bosClientWrapper) PutObjectAclFromString(bucket, object, acl string) error {
+	req := &putObjectAclReq{
+		bucket: bucket,
+		object: object,
+		acl:    acl,
+	}
+
+	paFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		paReq, ok := req.(*putObjectAclReq)
+		if !ok {
+			return fmt.Errorf("Error PutObjectAclFromString request type!")
+		}
+		return bosClient.PutObjectAclFromString(paReq.bucket, paReq.object, paReq.acl)
+	}
+
+	return retryHandler(b.bosClient, paFunc, req, nil)
+}
+
+type objectAclReq struct {
+	bucket string
+	object string
+}
+
+func (o *objectAclReq) getBucketName() string {
+	return o.bucket
+}
+
+type getObjectAclResp struct {
+	ret *api.GetObjectAclResult
+}
+
+// Wrapper of GetObjectAcl
+func (b *bosClientWrapper) GetObjectAcl(bucket, object string) (*api.GetObjectAclResult, error) {
+
+	req := &objectAclReq{
+		bucket: bucket,
+		object: object,
+	}
+	resp := &getObjectAclResp{}
+
+	gaFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		gaReq, ok := req.(*objectAclReq)
+		if !ok {
+			return fmt.Errorf("Error GetObjectAcl request type!")
+		}
+		gaResp, ok := resp.(*getObjectAclResp)
+		if !ok {
+			return fmt.Errorf("Error GetObjectAcl response type!")
+		}
+		ret, err := bosClient.GetObjectAcl(gaReq.bucket, gaReq.object)
+		if err == nil {
+			gaResp.ret = ret
+		}
+		return err
+	}
+
+	err := retryHandler(b.bosClient, gaFunc, req, resp)
+	if err == nil {
+		return resp.ret, nil
+	}
+	return nil, err
+}
+
+// Wrapper of DeleteObjectAcl
+func (b *bosClientWrapper) DeleteObjectAcl(bucket, object string) error {
+	req := &objectAclReq{
+		bucket: bucket,
+		object: object,
+	}
+
+	daFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+		daReq, ok := req.(*objectAclReq)
+		if !ok {
+			return fmt.Errorf("Error DeleteObjectAcl request type!")
+		}
+		return bosClient.DeleteObjectAcl(daReq.bucket, daReq.object)
+	}
+
+	return retryHandler(b.bosClient, daFunc, req, nil)
+}
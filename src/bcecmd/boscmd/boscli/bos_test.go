@@ -13,7 +13,9 @@
 package boscli
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	// 	"runtime"
@@ -39,10 +41,16 @@ var (
 )
 
 type fakeCliHandler struct {
-	multiDeleteDirArgVal string
-	sigleDeleteArgVal    string
-	utilDownlaodArgVal   string
-	utilUploadFileArgVal string
+	multiDeleteDirArgVal   string
+	sigleDeleteArgVal      string
+	utilDownlaodArgVal     string
+	utilUploadFileArgVal   string
+	utilUploadStreamArgVal string
+	deleteLocalFileArgVal  string
+	multiDeleteArgVal      []string
+	setStorageClassArgVal  []string
+	undeleteArgVal         []string
+	objectMetaArgVal       *objectMetaArgs
 }
 
 func (h *fakeCliHandler) multiDeleteDir(bosClient bosClientInterface, bucketName,
@@ -74,16 +82,45 @@ func (h *fakeCliHandler) utilDeleteObject(bosClient bosClientInterface, bucketNa
 	return nil
 }
 
+// delete a batch of objects
+func (h *fakeCliHandler) utilDeleteObjects(bosClient bosClientInterface, bucketName string,
+	keyList []string) (int, int, error) {
+	h.multiDeleteArgVal = append(h.multiDeleteArgVal, keyList...)
+	return len(keyList), 0, nil
+}
+
 // copy single object
 func (h *fakeCliHandler) utilCopyObject(srcBosClient, bosClient bosClientInterface, srcBucketName,
 	srcObjectKey, dstBucketName, dstObjectKey, storageClass string, fileSize, fileMtime,
-	timeOfgetObjectInfo int64, restart bool) error {
+	timeOfgetObjectInfo int64, restart bool, meta *objectMetaArgs) error {
+	h.objectMetaArgVal = meta
 	if dstObjectKey == "copyDstObjectError" {
 		return fmt.Errorf("error")
 	}
 	return nil
 }
 
+// change storage class of single object, record "key:storageClass"
+func (h *fakeCliHandler) utilSetStorageClass(bosClient bosClientInterface, bucketName,
+	objectKey, storageClass string, fileSize, fileMtime, timeOfgetObjectInfo int64,
+	restart bool) error {
+	if objectKey == "a/g" {
+		return fmt.Errorf("error")
+	}
+	h.setStorageClassArgVal = append(h.setStorageClassArgVal, objectKey+":"+storageClass)
+	return nil
+}
+
+// recover single object, record "trashKey:objectKey"
+func (h *fakeCliHandler) utilUndeleteObject(bosClient bosClientInterface, bucketName, trashKey,
+	objectKey, storageClass string, fileSize, fileMtime, timeOfgetObjectInfo int64) error {
+	if trashKey == "a/g" {
+		return fmt.Errorf("error")
+	}
+	h.undeleteArgVal = append(h.undeleteArgVal, trashKey+":"+objectKey)
+	return nil
+}
+
 // download an object to local
 func (h *fakeCliHandler) utilDownloadObject(bosClient bosClientInterface, srcBucketName, srcObjectKey,
 	dstFilePath, downLoadTmp string, yes bool, fileSize, mtime, timeOfgetObjectInfo int64, restart bool) error {
@@ -107,7 +144,8 @@ func (h *fakeCliHandler) utilDownloadObject(bosClient bosClientInterface, srcBuc
 
 func (h *fakeCliHandler) utilUploadFile(bosClient bosClientInterface, srcPath, relSrcPath,
 	dstBucketName, dstObjectKey, storageClass string, fileSize, fileMtime,
-	timeOfgetObjectInfo int64, restart bool) error {
+	timeOfgetObjectInfo int64, restart bool, meta *objectMetaArgs) error {
+	h.objectMetaArgVal = meta
 	h.utilUploadFileArgVal = srcPath + relSrcPath + dstBucketName + dstObjectKey + storageClass +
 		strconv.FormatInt(fileSize, 10)
 	if restart {
@@ -119,8 +157,55 @@ func (h *fakeCliHandler) utilUploadFile(bosClient bosClientInterface, srcPath, r
 	return nil
 }
 
+// download to stream
+func (h *fakeCliHandler) utilDownloadToStream(bosClient bosClientInterface, srcBucketName,
+	srcObjectKey string, stream io.Writer) error {
+	if srcBucketName == "error" {
+		return fmt.Errorf("error")
+	}
+	_, err := io.WriteString(stream, srcBucketName+srcObjectKey)
+	return err
+}
+
+// download a range of object to local
+func (h *fakeCliHandler) utilDownloadObjectRange(bosClient bosClientInterface, srcBucketName,
+	srcObjectKey, dstFilePath string, yes bool, start, end int64) error {
+	h.utilDownlaodArgVal = fmt.Sprintf("%s%s%s%d:%d", srcBucketName, srcObjectKey, dstFilePath,
+		start, end)
+	if srcBucketName == "error" {
+		return fmt.Errorf("error")
+	}
+	return nil
+}
+
+// write a range of object to stream
+func (h *fakeCliHandler) utilGetObjectRange(bosClient bosClientInterface, srcBucketName,
+	srcObjectKey string, start, end int64, decompress bool, stream io.Writer) error {
+	if srcBucketName == "error" {
+		return fmt.Errorf("error")
+	}
+	out := fmt.Sprintf("%s%s%d:%d", srcBucketName, srcObjectKey, start, end)
+	if decompress {
+		out += "gzip"
+	}
+	_, err := io.WriteString(stream, out)
+	return err
+}
+
+// upload from stream
+func (h *fakeCliHandler) utilUploadStream(bosClient bosClientInterface, stream io.Reader,
+	dstBucketName, dstObjectKey, storageClass string, meta *objectMetaArgs) error {
+	h.objectMetaArgVal = meta
+	h.utilUploadStreamArgVal = dstBucketName + dstObjectKey + storageClass
+	if dstBucketName == "error" {
+		return fmt.Errorf("error")
+	}
+	return nil
+}
+
 // delete local file
 func (h *fakeCliHandler) utilDeleteLocalFile(localPath string) error {
+	h.deleteLocalFileArgVal = localPath
 	if strings.HasSuffix(localPath, "error") {
 		return fmt.Errorf("utilDeleteLocalFile error")
 	}
@@ -129,7 +214,7 @@ func (h *fakeCliHandler) utilDeleteLocalFile(localPath string) error {
 
 func (h *fakeCliHandler) CopySuperFile(srcBosClient, bosClient bosClientInterface, srcBucketName,
 	srcObjectKey, dstBucketName, dstObjectKey, storageClass string, fileSize, mtime,
-	timeOfgetObjectInfo int64, restart bool, testPrefix string) error {
+	timeOfgetObjectInfo int64, restart bool, testPrefix string, meta *objectMetaArgs) error {
 	return nil
 }
 
@@ -151,6 +236,11 @@ type fakeBosClientForBos struct {
 	DeleteBucketName    string
 	makeBucketName      string
 	GetObjectMetaArgVal string
+	objectHeaders       map[string]map[string]string
+	restoredObjects     []string
+	symlinks            []string
+	appends             []string
+	fetches             []string
 }
 
 func (b *fakeBosClientForBos) HeadBucket(bucket string) error {
@@ -206,6 +296,15 @@ func (b *fakeBosClientForBos) PutBucket(bucket string) (string, error) {
 
 // Fake GetBucketLocation - get the location fo the given bucket
 func (b *fakeBosClientForBos) GetBucketLocation(bucket string) (string, error) {
+	switch bucket {
+	case "success", "dst-bj":
+		return "bj", nil
+	case "dst-gz":
+		return "gz", nil
+	case "no-such-bucket":
+		return "", &bce.BceServiceError{StatusCode: http.StatusNotFound,
+			Code: boscmd.CODE_NO_SUCH_BUCKET}
+	}
 	return "", fmt.Errorf("test")
 }
 
@@ -313,6 +412,50 @@ func (b *fakeBosClientForBos) GetObjectMeta(bucket, object string) (*api.GetObje
 	return b.objectMeta, nil
 }
 
+// Fake RestoreObject, record the restored objects as "bucket/object"
+func (b *fakeBosClientForBos) RestoreObject(bucket, object string, restoreDays int,
+	restoreTier string) error {
+	if strings.HasSuffix(object, "error") {
+		return fmt.Errorf("restore %s/%s", bucket, object)
+	}
+	b.restoredObjects = append(b.restoredObjects, bucket+"/"+object)
+	return nil
+}
+
+// Fake PutSymlink, record the symlinks as "bucket/symlinkKey->object"
+func (b *fakeBosClientForBos) PutSymlink(bucket, object, symlinkKey string,
+	symlinkArgs *api.PutSymlinkArgs) error {
+	if strings.HasSuffix(symlinkKey, "error") {
+		return fmt.Errorf("symlink %s/%s", bucket, symlinkKey)
+	}
+	b.symlinks = append(b.symlinks, bucket+"/"+symlinkKey+"->"+object)
+	return nil
+}
+
+// Fake GetSymlink, only "link" is a symlink
+func (b *fakeBosClientForBos) GetSymlink(bucket, object string) (string, error) {
+	if object == "link" {
+		return "target", nil
+	}
+	return "", &bce.BceServiceError{StatusCode: 404}
+}
+
+// Fake HeadObject, objects which are not in objectHeaders only have content length
+func (b *fakeBosClientForBos) HeadObject(bucket, object string) (map[string]string, error) {
+	if object == "404" {
+		return nil, &bce.BceServiceError{
+			StatusCode: 404,
+		}
+	}
+	if object == "head-error" {
+		return nil, fmt.Errorf("head error")
+	}
+	if headers, ok := b.objectHeaders[object]; ok {
+		return headers, nil
+	}
+	return map[string]string{"Content-Length": "100"}, nil
+}
+
 // Fake Copy Object
 func (b *fakeBosClientForBos) CopyObject(bucket, object, srcBucket, srcObject string,
 	args *api.CopyObjectArgs) (*api.CopyObjectResult, error) {
@@ -342,6 +485,50 @@ func (b *fakeBosClientForBos) PutObjectFromFile(bucket, object, fileName string,
 	return "", fmt.Errorf("smail" + fileName + bucket + object + args.StorageClass)
 }
 
+// Fake of PutObjectFromBytes
+func (b *fakeBosClientForBos) PutObjectFromBytes(bucket, object string, bytesArr []byte,
+	args *api.PutObjectArgs) (string, error) {
+	return "", fmt.Errorf("Not support")
+}
+
+// Fake of AppendObject, record the appends as "key@offset:size", another writer appends to
+// "race" at the same time
+func (b *fakeBosClientForBos) AppendObject(bucket, object string, content *bce.Body,
+	args *api.AppendObjectArgs) (*api.AppendObjectResult, error) {
+	if object == "offset-error" {
+		return nil, &bce.BceServiceError{
+			StatusCode: http.StatusConflict,
+			Code:       boscmd.CODE_OFFSET_INCORRECT,
+		}
+	}
+	if strings.HasSuffix(object, "error") {
+		return nil, fmt.Errorf("append %s/%s", bucket, object)
+	}
+	b.appends = append(b.appends, fmt.Sprintf("%s@%d:%d", object, args.Offset, content.Size()))
+	nextOffset := args.Offset + content.Size()
+	if object == "race" {
+		nextOffset++
+	}
+	return &api.AppendObjectResult{
+		NextAppendOffset: nextOffset,
+		ETag:             "\"etag\"",
+	}, nil
+}
+
+// Fake of FetchObject, record the fetches as "bucket/object<-source:mode"
+func (b *fakeBosClientForBos) FetchObject(bucket, object, source string,
+	args *api.FetchObjectArgs) (*api.FetchObjectResult, error) {
+	if bucket == "error" {
+		return nil, fmt.Errorf("fetch %s", source)
+	}
+	b.fetches = append(b.fetches, bucket+"/"+object+"<-"+source+":"+args.FetchMode)
+	ret := &api.FetchObjectResult{Code: "success"}
+	if args.FetchMode == api.FETCH_MODE_ASYNC {
+		ret.JobId = "job"
+	}
+	return ret, nil
+}
+
 // Fake of UploadSuperFile
 func (b *fakeBosClientForBos) UploadSuperFile(bucket, object, fileName, storageClass string) error {
 	if fileName == "success" {
@@ -378,8 +565,7 @@ func (b *fakeBosClientForBos) AbortMultipartUpload(bucket, object, uploadId stri
 }
 
 func (b *fakeBosClientForBos) CompleteMultipartUploadFromStruct(bucket, object, uploadId string,
-	parts *api.CompleteMultipartUploadArgs,
-	meta map[string]string) (*api.CompleteMultipartUploadResult, error) {
+	parts *api.CompleteMultipartUploadArgs) (*api.CompleteMultipartUploadResult, error) {
 
 	return nil, fmt.Errorf("Not support")
 }
@@ -392,6 +578,30 @@ func (b *fakeBosClientForBos) GetObject(bucket, object string, responseHeaders m
 
 func init() {
 	bosClientForBos := &fakeBosClientForBos{
+		objectHeaders: map[string]map[string]string{
+			"link": map[string]string{
+				"Content-Length":    "0",
+				"X-Bce-Object-Type": OBJECT_TYPE_SYMLINK,
+			},
+			"appendable": map[string]string{
+				"Content-Length":           "10",
+				"Etag":                     "\"old\"",
+				"X-Bce-Object-Type":        OBJECT_TYPE_APPENDABLE,
+				"X-Bce-Next-Append-Offset": "10",
+			},
+			"race": map[string]string{
+				"Content-Length":    "10",
+				"X-Bce-Object-Type": OBJECT_TYPE_APPENDABLE,
+			},
+			"offset-error": map[string]string{
+				"Content-Length":    "10",
+				"X-Bce-Object-Type": OBJECT_TYPE_APPENDABLE,
+			},
+			"append-error": map[string]string{
+				"Content-Length":    "10",
+				"X-Bce-Object-Type": OBJECT_TYPE_APPENDABLE,
+			},
+		},
 		results: []*api.ListObjectsResult{
 			&api.ListObjectsResult{
 				CommonPrefixes: []api.PrefixType{
@@ -615,9 +825,13 @@ func TestGenSignedUrl(t *testing.T) {
 type listType struct {
 	bosPath   string
 	opType    string
+	marker    string
+	pageSize  int
+	maxItems  int
 	all       bool
 	recursive bool
 	summary   bool
+	symlink   bool
 }
 
 func TestList(t *testing.T) {
@@ -654,6 +868,18 @@ func TestList(t *testing.T) {
 			all:       false,
 			recursive: false,
 		},
+		listType{
+			bosPath:   "bos:/0",
+			marker:    "1",
+			pageSize:  10,
+			maxItems:  2,
+			recursive: true,
+		},
+		listType{
+			bosPath:   "bos:/0",
+			recursive: true,
+			symlink:   true,
+		},
 	}
 	for _, tCase := range testCases {
 		if fakeClient, ok := testBosCli.bosClient.(*fakeBosClientForBos); ok {
@@ -662,7 +888,96 @@ func TestList(t *testing.T) {
 			t.Errorf("List: bosClient is not fakeBosClientForBos")
 			continue
 		}
-		testBosCli.List(tCase.bosPath, tCase.all, tCase.recursive, tCase.summary)
+		testBosCli.List(tCase.bosPath, tCase.marker, tCase.pageSize, tCase.maxItems, tCase.all,
+			tCase.recursive, tCase.summary, tCase.symlink)
+	}
+}
+
+type listPreProcessType struct {
+	bosPath    string
+	marker     string
+	pageSize   int
+	maxItems   int
+	bucketName string
+	objectKey  string
+	wantSize   int
+	code       BosCliErrorCode
+	isSuc      bool
+}
+
+func TestListPreProcess(t *testing.T) {
+	testCases := []listPreProcessType{
+		//1
+		listPreProcessType{
+			bosPath:  "bos:/",
+			wantSize: MAX_LIST_PAGE_SIZE,
+			code:     BOSCLI_OK,
+			isSuc:    true,
+		},
+		//2
+		listPreProcessType{
+			bosPath:    "bos:/bucket/a/",
+			marker:     "a/c",
+			pageSize:   100,
+			maxItems:   10,
+			bucketName: "bucket",
+			objectKey:  "a/",
+			wantSize:   100,
+			code:       BOSCLI_OK,
+			isSuc:      true,
+		},
+		//3
+		listPreProcessType{
+			bosPath:  "bos:/bucket",
+			pageSize: -1,
+			code:     BOSCLI_LIST_PAGE_SIZE_INVALID,
+		},
+		//4
+		listPreProcessType{
+			bosPath:  "bos:/bucket",
+			pageSize: MAX_LIST_PAGE_SIZE + 1,
+			code:     BOSCLI_LIST_PAGE_SIZE_INVALID,
+		},
+		//5
+		listPreProcessType{
+			bosPath:  "bos:/bucket",
+			maxItems: -1,
+			code:     BOSCLI_LIST_MAX_ITEMS_LESS_ZERO,
+		},
+		//6
+		listPreProcessType{
+			bosPath: "bos:/",
+			marker:  "a",
+			code:    BOSCLI_LIST_BUCKETS_NOT_SUPPORT_PAGING,
+		},
+		//7
+		listPreProcessType{
+			bosPath:  "bos:/",
+			maxItems: 1,
+			code:     BOSCLI_LIST_BUCKETS_NOT_SUPPORT_PAGING,
+		},
+		//8
+		listPreProcessType{
+			bosPath: "/bucket",
+			code:    BOSCLI_BOSPATH_IS_INVALID,
+		},
+	}
+	for i, tCase := range testCases {
+		args, code, err := testBosCli.listPreProcess(tCase.bosPath, tCase.marker, tCase.pageSize,
+			tCase.maxItems)
+		util.ExpectEqual("bos.go listPreProcess I", i+1, t.Errorf, tCase.code, code)
+		util.ExpectEqual("bos.go listPreProcess II", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if tCase.isSuc && err == nil {
+			util.ExpectEqual("bos.go listPreProcess III", i+1, t.Errorf, tCase.bucketName,
+				args.bucketName)
+			util.ExpectEqual("bos.go listPreProcess IV", i+1, t.Errorf, tCase.objectKey,
+				args.objectKey)
+			util.ExpectEqual("bos.go listPreProcess V", i+1, t.Errorf, tCase.marker, args.marker)
+			util.ExpectEqual("bos.go listPreProcess VI", i+1, t.Errorf, tCase.wantSize,
+				args.pageSize)
+			util.ExpectEqual("bos.go listPreProcess VII", i+1, t.Errorf, tCase.maxItems,
+				args.maxItems)
+		}
 	}
 }
 
@@ -709,6 +1024,8 @@ func TestBosListBuckets(t *testing.T) {
 type bosListObjectsType struct {
 	bucketName string
 	objectKey  string
+	marker     string
+	maxItems   int
 	all        bool
 	recursive  bool
 	summary    bool
@@ -749,6 +1066,22 @@ func TestBosListObjects(t *testing.T) {
 			recursive:  false,
 			isSuc:      true,
 		},
+		bosListObjectsType{
+			bucketName: "0",
+			objectKey:  "",
+			maxItems:   4,
+			recursive:  true,
+			summary:    true,
+			isSuc:      true,
+		},
+		bosListObjectsType{
+			bucketName: "0",
+			objectKey:  "",
+			marker:     "1",
+			maxItems:   100,
+			recursive:  false,
+			isSuc:      true,
+		},
 		bosListObjectsType{
 			bucketName: "error",
 			objectKey:  "testKey",
@@ -759,8 +1092,14 @@ func TestBosListObjects(t *testing.T) {
 		},
 	}
 	for i, tCase := range testCases {
-		err := testBosCli.listObjects(tCase.bucketName, tCase.objectKey, tCase.all, tCase.recursive,
-			tCase.summary)
+		args := &listArgs{
+			bucketName: tCase.bucketName,
+			objectKey:  tCase.objectKey,
+			marker:     tCase.marker,
+			pageSize:   MAX_LIST_PAGE_SIZE,
+			maxItems:   tCase.maxItems,
+		}
+		err := testBosCli.listObjects(args, tCase.all, tCase.recursive, tCase.summary)
 		util.ExpectEqual("tools.go listObjects I", i+1, t.Errorf, tCase.isSuc, err == nil)
 		if !tCase.isSuc {
 			util.ExpectEqual("tools.go listObjects I", i+1, t.Errorf, tCase.out, err.Error())
@@ -768,110 +1107,529 @@ func TestBosListObjects(t *testing.T) {
 	}
 }
 
-type makeBucketPreProcessType struct {
-	bucketName   string
-	region       string
-	yes          string
-	useAuto      bool
-	changeClient bool
-	out          string
-	code         BosCliErrorCode
+type diskUsagePreProcessType struct {
+	bosPath string
+	depth   int
+	sortBy  string
+	out     *diskUsageArgs
+	code    BosCliErrorCode
 }
 
-func TestMakeBucketPreProcess(t *testing.T) {
-	testCases := []makeBucketPreProcessType{
+func TestDiskUsagePreProcess(t *testing.T) {
+	testCases := []diskUsagePreProcessType{
 		//1
-		makeBucketPreProcessType{
-			bucketName: "/bucket",
-			code:       BOSCLI_BOSPATH_IS_INVALID,
+		diskUsagePreProcessType{
+			bosPath: "bos:/bucket/a/",
+			depth:   2,
+			out: &diskUsageArgs{
+				bucketName: "bucket",
+				objectKey:  "a/",
+				depth:      2,
+				sortBy:     DU_SORT_BY_NAME,
+			},
+			code: BOSCLI_OK,
 		},
-		makeBucketPreProcessType{
-			bucketName: "",
-			code:       BOSCLI_BUCKETNAME_IS_EMPTY,
+		//2
+		diskUsagePreProcessType{
+			bosPath: "bos:/bucket",
+			sortBy:  DU_SORT_BY_SIZE,
+			out: &diskUsageArgs{
+				bucketName: "bucket",
+				sortBy:     DU_SORT_BY_SIZE,
+			},
+			code: BOSCLI_OK,
 		},
 		//3
-		makeBucketPreProcessType{
-			bucketName: "bos:/bucket/key",
-			code:       BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME,
+		diskUsagePreProcessType{
+			bosPath: "bos:/",
+			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
 		},
-		makeBucketPreProcessType{
-			bucketName: "bos:/bucket",
-			out:        "bucket",
-			region:     "bj",
-			code:       BOSCLI_OK,
+		//4
+		diskUsagePreProcessType{
+			bosPath: "/bucket",
+			code:    BOSCLI_BOSPATH_IS_INVALID,
 		},
 		//5
-		makeBucketPreProcessType{
-			bucketName: "bucket",
-			out:        "bucket",
-			code:       BOSCLI_OK,
+		diskUsagePreProcessType{
+			bosPath: "bos:/bucket",
+			depth:   -1,
+			code:    BOSCLI_DU_DEPTH_LESS_ZERO,
 		},
-		makeBucketPreProcessType{
-			bucketName: "liupen-gz",
-			out:        "liupen-gz",
-			region:     "gz",
-			useAuto:    false,
-			yes:        "yes",
-			code:       BOSCLI_OK,
+		//6
+		diskUsagePreProcessType{
+			bosPath: "bos:/bucket",
+			sortBy:  "mtime",
+			code:    BOSCLI_DU_SORT_TYPE_INVALID,
 		},
-		//7
-		makeBucketPreProcessType{
-			bucketName: "liupen-gz",
-			region:     "gz",
-			useAuto:    false,
-			yes:        "no",
-			code:       BOSCLI_OPRATION_CANCEL,
+	}
+	for i, tCase := range testCases {
+		args, code, _ := testBosCli.diskUsagePreProcess(tCase.bosPath, tCase.depth, tCase.sortBy)
+		util.ExpectEqual("bos.go diskUsagePreProcess I", i+1, t.Errorf, tCase.code, code)
+		if tCase.code == BOSCLI_OK {
+			util.ExpectEqual("bos.go diskUsagePreProcess II", i+1, t.Errorf, tCase.out, args)
+		}
+	}
+}
+
+type diskUsageExecuteType struct {
+	args     *diskUsageArgs
+	prefixes []string
+	total    *prefixUsage
+	isSuc    bool
+}
+
+func TestDiskUsageExecute(t *testing.T) {
+	testCases := []diskUsageExecuteType{
+		//1
+		diskUsageExecuteType{
+			args: &diskUsageArgs{
+				bucketName: "0",
+				depth:      1,
+				sortBy:     DU_SORT_BY_NAME,
+			},
+			prefixes: []string{"a/"},
+			total: &prefixUsage{
+				objects:      6,
+				size:         1200,
+				classObjects: map[string]int64{"STANDARD": 6},
+				classSize:    map[string]int64{"STANDARD": 1200},
+			},
+			isSuc: true,
 		},
-		makeBucketPreProcessType{
-			bucketName: "liupen-gz",
-			region:     "gz",
-			useAuto:    true,
-			code:       BOSCLI_INTERNAL_ERROR,
+		//2
+		diskUsageExecuteType{
+			args: &diskUsageArgs{
+				bucketName: "0",
+				objectKey:  "a/",
+				depth:      3,
+				sortBy:     DU_SORT_BY_SIZE,
+			},
+			prefixes: []string{},
+			total: &prefixUsage{
+				prefix:       "a/",
+				objects:      6,
+				size:         1200,
+				classObjects: map[string]int64{"STANDARD": 6},
+				classSize:    map[string]int64{"STANDARD": 1200},
+			},
+			isSuc: true,
 		},
-		//9
-		makeBucketPreProcessType{
-			bucketName:   "liupen-gz/",
-			out:          "liupen-gz",
-			region:       "gz",
-			useAuto:      true,
-			changeClient: true,
-			code:         BOSCLI_OK,
+		//3
+		diskUsageExecuteType{
+			args: &diskUsageArgs{
+				bucketName: "0",
+				sortBy:     DU_SORT_BY_NAME,
+			},
+			prefixes: []string{},
+			total: &prefixUsage{
+				objects:      6,
+				size:         1200,
+				classObjects: map[string]int64{"STANDARD": 6},
+				classSize:    map[string]int64{"STANDARD": 1200},
+			},
+			isSuc: true,
 		},
-		makeBucketPreProcessType{
-			bucketName: "bos://bucket",
-			out:        "bucket",
-			region:     "bj",
-			code:       BOSCLI_OK,
+		//4
+		diskUsageExecuteType{
+			args: &diskUsageArgs{
+				bucketName: "error",
+				objectKey:  "testKey",
+				depth:      1,
+			},
+			isSuc: false,
 		},
 	}
-	temp := bceconf.ServerConfigProvider
-	// generate server config provider
-	serverConfigFileProvider, err := bceconf.NewFileServerConfigProvider("./config/config")
-	if err != nil {
-		t.Errorf("makeBucket init NewFileServerConfigProvider failed")
-		return
-	}
-	defaServerConfigProvider, err := bceconf.NewDefaultServerConfigProvider()
-	if err != nil {
-		t.Errorf("makeBucket init NewDefaultServerConfigProvider failed")
-		return
+	for i, tCase := range testCases {
+		usages, total, err := testBosCli.diskUsageExecute(tCase.args)
+		util.ExpectEqual("bos.go diskUsageExecute I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if !tCase.isSuc || err != nil {
+			continue
+		}
+		prefixes := []string{}
+		for _, usage := range usages {
+			prefixes = append(prefixes, usage.prefix)
+		}
+		util.ExpectEqual("bos.go diskUsageExecute II", i+1, t.Errorf, tCase.prefixes, prefixes)
+		util.ExpectEqual("bos.go diskUsageExecute III", i+1, t.Errorf, tCase.total, total)
 	}
-	bceconf.ServerConfigProvider = bceconf.NewChainServerConfigProvider(
-		[]bceconf.ServerConfigProviderInterface{serverConfigFileProvider,
-			defaServerConfigProvider})
+}
 
-	stdinTemp := os.Stdin
+type sortPrefixUsagesType struct {
+	sortBy   string
+	prefixes []string
+}
+
+func TestSortPrefixUsages(t *testing.T) {
+	newUsage := func(prefix string, objects, size int64) *prefixUsage {
+		return &prefixUsage{prefix: prefix, objects: objects, size: size}
+	}
+	testCases := []sortPrefixUsagesType{
+		sortPrefixUsagesType{DU_SORT_BY_NAME, []string{"a/", "b/", "c/", "d/"}},
+		sortPrefixUsagesType{DU_SORT_BY_SIZE, []string{"b/", "c/", "d/", "a/"}},
+		sortPrefixUsagesType{DU_SORT_BY_COUNT, []string{"d/", "a/", "c/", "b/"}},
+	}
 	for i, tCase := range testCases {
-		var (
-			tempFileName string
-			fd           *os.File
-			err          error
-		)
-		serverConfigFileProvider.SetRegion("bj")
-		if tCase.useAuto {
-			ok := serverConfigFileProvider.SetUseAutoSwitchDomain("yes")
-			if !ok {
-				t.Errorf("makeBucket set SetUseAutoSwitchDomain failed %t", tCase.useAuto)
+		usages := []*prefixUsage{
+			newUsage("c/", 2, 300),
+			newUsage("a/", 5, 10),
+			newUsage("d/", 9, 300),
+			newUsage("b/", 1, 900),
+		}
+		sortPrefixUsages(usages, tCase.sortBy)
+		prefixes := []string{}
+		for _, usage := range usages {
+			prefixes = append(prefixes, usage.prefix)
+		}
+		util.ExpectEqual("bos.go sortPrefixUsages", i+1, t.Errorf, tCase.prefixes, prefixes)
+	}
+}
+
+type parseObjectStatType struct {
+	headers map[string]string
+	out     *objectStat
+	isSuc   bool
+}
+
+func TestParseObjectStat(t *testing.T) {
+	testCases := []parseObjectStatType{
+		//1
+		parseObjectStatType{
+			headers: map[string]string{
+				"Content-Type":                 "text/plain",
+				"Content-Length":               "10",
+				"Etag":                         "\"abc\"",
+				"Last-Modified":                "Mon, 02 Jan 2006 15:04:05 GMT",
+				"X-Bce-Content-Crc32":          "123",
+				"X-Bce-Storage-Class":          "COLD",
+				"X-Bce-Server-Side-Encryption": "AES256",
+				"X-Bce-Meta-Owner":             "liupeng",
+			},
+			out: &objectStat{
+				bucketName:           "bucket",
+				key:                  "a/b",
+				contentType:          "text/plain",
+				contentLength:        10,
+				etag:                 "abc",
+				crc32:                "123",
+				storageClass:         "COLD",
+				restoreStatus:        RESTORE_STATUS_NONE,
+				mtime:                1136214245,
+				serverSideEncryption: "AES256",
+				objectType:           OBJECT_TYPE_NORMAL,
+				userMeta:             map[string]string{"owner": "liupeng"},
+			},
+			isSuc: true,
+		},
+		//2
+		parseObjectStatType{
+			headers: map[string]string{
+				"Content-Length":       "0",
+				"Etag":                 "-123",
+				"X-Bce-Object-Type":    "Symlink",
+				"X-Bce-Symlink-Target": "a/c",
+			},
+			out: &objectStat{
+				bucketName:    "bucket",
+				key:           "a/b",
+				etag:          "-123",
+				storageClass:  DEFAULT_STORAGE_CLASS,
+				restoreStatus: RESTORE_STATUS_NONE,
+				objectType:    OBJECT_TYPE_SYMLINK,
+				symlinkTarget: "a/c",
+				isSymlink:     true,
+				isMultipart:   true,
+				userMeta:      map[string]string{},
+			},
+			isSuc: true,
+		},
+		//3
+		parseObjectStatType{
+			headers: map[string]string{
+				"Content-Length": "abc",
+			},
+			isSuc: false,
+		},
+		//4
+		parseObjectStatType{
+			headers: map[string]string{
+				"X-Bce-Storage-Class": "ARCHIVE",
+				"X-Bce-Restore": "ongoing-request=\"false\", " +
+					"expiry-date=\"Wed, 07 Nov 2019 00:00:00 GMT\"",
+			},
+			out: &objectStat{
+				bucketName:    "bucket",
+				key:           "a/b",
+				storageClass:  "ARCHIVE",
+				restoreStatus: RESTORE_STATUS_RESTORED,
+				restoreExpiry: "Wed, 07 Nov 2019 00:00:00 GMT",
+				objectType:    OBJECT_TYPE_NORMAL,
+				userMeta:      map[string]string{},
+			},
+			isSuc: true,
+		},
+		//5
+		parseObjectStatType{
+			headers: map[string]string{
+				"Content-Length":           "10",
+				"X-Bce-Object-Type":        "Appendable",
+				"X-Bce-Next-Append-Offset": "12",
+			},
+			out: &objectStat{
+				bucketName:       "bucket",
+				key:              "a/b",
+				contentLength:    10,
+				storageClass:     DEFAULT_STORAGE_CLASS,
+				restoreStatus:    RESTORE_STATUS_NONE,
+				objectType:       OBJECT_TYPE_APPENDABLE,
+				isAppendable:     true,
+				nextAppendOffset: 12,
+				userMeta:         map[string]string{},
+			},
+			isSuc: true,
+		},
+		//6 data is appended at the end of object
+		parseObjectStatType{
+			headers: map[string]string{
+				"Content-Length":    "10",
+				"X-Bce-Object-Type": "Appendable",
+			},
+			out: &objectStat{
+				bucketName:       "bucket",
+				key:              "a/b",
+				contentLength:    10,
+				storageClass:     DEFAULT_STORAGE_CLASS,
+				restoreStatus:    RESTORE_STATUS_NONE,
+				objectType:       OBJECT_TYPE_APPENDABLE,
+				isAppendable:     true,
+				nextAppendOffset: 10,
+				userMeta:         map[string]string{},
+			},
+			isSuc: true,
+		},
+		//7
+		parseObjectStatType{
+			headers: map[string]string{
+				"X-Bce-Object-Type":        "Appendable",
+				"X-Bce-Next-Append-Offset": "abc",
+			},
+			isSuc: false,
+		},
+	}
+	for i, tCase := range testCases {
+		ret, err := parseObjectStat("bucket", "a/b", tCase.headers)
+		util.ExpectEqual("bos.go parseObjectStat I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if tCase.isSuc && err == nil {
+			util.ExpectEqual("bos.go parseObjectStat II", i+1, t.Errorf, tCase.out, ret)
+		}
+	}
+}
+
+type statPreProcessType struct {
+	bosPaths []string
+	out      []*statArgs
+	code     BosCliErrorCode
+}
+
+func TestStatPreProcess(t *testing.T) {
+	testCases := []statPreProcessType{
+		//1
+		statPreProcessType{
+			bosPaths: []string{"bos:/bucket/a/b", "bos:/bucket/a/", "bos:/bucket"},
+			out: []*statArgs{
+				&statArgs{bucketName: "bucket", objectKey: "a/b"},
+				&statArgs{bucketName: "bucket", objectKey: "a/", isPrefix: true},
+				&statArgs{bucketName: "bucket", isPrefix: true},
+			},
+			code: BOSCLI_OK,
+		},
+		//2
+		statPreProcessType{
+			bosPaths: []string{"bos:/bucket/a/b", "bos:/"},
+			code:     BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		//3
+		statPreProcessType{
+			bosPaths: []string{"/bucket/a"},
+			code:     BOSCLI_BOSPATH_IS_INVALID,
+		},
+	}
+	for i, tCase := range testCases {
+		ret, code, _ := testBosCli.statPreProcess(tCase.bosPaths)
+		util.ExpectEqual("bos.go statPreProcess I", i+1, t.Errorf, tCase.code, code)
+		if tCase.code == BOSCLI_OK {
+			util.ExpectEqual("bos.go statPreProcess II", i+1, t.Errorf, tCase.out, ret)
+		}
+	}
+}
+
+type statExecuteType struct {
+	args  *statArgs
+	out   string
+	isSuc bool
+}
+
+func TestStatExecute(t *testing.T) {
+	testCases := []statExecuteType{
+		//1
+		statExecuteType{
+			args: &statArgs{bucketName: "bucket", objectKey: "a/b"},
+			out: "type,bucket,key,content_type,content_length,etag,crc32,storage_class," +
+				"restore_status,restore_expiry,mtime,server_side_encryption,object_type,symlink," +
+				"symlink_target,multipart,appendable,next_append_offset,user_meta\n" +
+				"object_meta,bucket,a/b,,100,,,STANDARD,none,,1970-01-01T00:00:00Z,,Normal,false,," +
+				"false,false,0,\n",
+			isSuc: true,
+		},
+		//2
+		statExecuteType{
+			args:  &statArgs{bucketName: "bucket", objectKey: "404"},
+			isSuc: false,
+		},
+		//3
+		statExecuteType{
+			args:  &statArgs{bucketName: "0", isPrefix: true},
+			isSuc: true,
+		},
+		//4
+		statExecuteType{
+			args:  &statArgs{bucketName: "error", objectKey: "testKey/", isPrefix: true},
+			isSuc: false,
+		},
+		//5 the target of symlink is resolved
+		statExecuteType{
+			args: &statArgs{bucketName: "bucket", objectKey: "link"},
+			out: "type,bucket,key,content_type,content_length,etag,crc32,storage_class," +
+				"restore_status,restore_expiry,mtime,server_side_encryption,object_type,symlink," +
+				"symlink_target,multipart,appendable,next_append_offset,user_meta\n" +
+				"object_meta,bucket,link,,0,,,STANDARD,none,,1970-01-01T00:00:00Z,,Symlink,true," +
+				"target,false,false,0,\n",
+			isSuc: true,
+		},
+	}
+	oldOutput := recordOutput
+	defer func() {
+		recordOutput = oldOutput
+	}()
+	for i, tCase := range testCases {
+		buf := &bytes.Buffer{}
+		recordOutput = newRecordPrinter(OUTPUT_FORMAT_CSV, buf)
+		err := testBosCli.statExecute(tCase.args)
+		util.ExpectEqual("bos.go statExecute I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if tCase.out != "" {
+			util.ExpectEqual("bos.go statExecute II", i+1, t.Errorf, tCase.out, buf.String())
+		}
+		// all objects under prefix are shown
+		if tCase.args.isPrefix && tCase.isSuc {
+			util.ExpectEqual("bos.go statExecute III", i+1, t.Errorf, 7,
+				strings.Count(buf.String(), "\n"))
+		}
+	}
+}
+
+type makeBucketPreProcessType struct {
+	bucketName   string
+	region       string
+	yes          string
+	useAuto      bool
+	changeClient bool
+	out          string
+	code         BosCliErrorCode
+}
+
+func TestMakeBucketPreProcess(t *testing.T) {
+	testCases := []makeBucketPreProcessType{
+		//1
+		makeBucketPreProcessType{
+			bucketName: "/bucket",
+			code:       BOSCLI_BOSPATH_IS_INVALID,
+		},
+		makeBucketPreProcessType{
+			bucketName: "",
+			code:       BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		//3
+		makeBucketPreProcessType{
+			bucketName: "bos:/bucket/key",
+			code:       BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME,
+		},
+		makeBucketPreProcessType{
+			bucketName: "bos:/bucket",
+			out:        "bucket",
+			region:     "bj",
+			code:       BOSCLI_OK,
+		},
+		//5
+		makeBucketPreProcessType{
+			bucketName: "bucket",
+			out:        "bucket",
+			code:       BOSCLI_OK,
+		},
+		makeBucketPreProcessType{
+			bucketName: "liupen-gz",
+			out:        "liupen-gz",
+			region:     "gz",
+			useAuto:    false,
+			yes:        "yes",
+			code:       BOSCLI_OK,
+		},
+		//7
+		makeBucketPreProcessType{
+			bucketName: "liupen-gz",
+			region:     "gz",
+			useAuto:    false,
+			yes:        "no",
+			code:       BOSCLI_OPRATION_CANCEL,
+		},
+		makeBucketPreProcessType{
+			bucketName: "liupen-gz",
+			region:     "gz",
+			useAuto:    true,
+			code:       BOSCLI_INTERNAL_ERROR,
+		},
+		//9
+		makeBucketPreProcessType{
+			bucketName:   "liupen-gz/",
+			out:          "liupen-gz",
+			region:       "gz",
+			useAuto:      true,
+			changeClient: true,
+			code:         BOSCLI_OK,
+		},
+		makeBucketPreProcessType{
+			bucketName: "bos://bucket",
+			out:        "bucket",
+			region:     "bj",
+			code:       BOSCLI_OK,
+		},
+	}
+	temp := bceconf.ServerConfigProvider
+	// generate server config provider
+	serverConfigFileProvider, err := bceconf.NewFileServerConfigProvider("./config/config")
+	if err != nil {
+		t.Errorf("makeBucket init NewFileServerConfigProvider failed")
+		return
+	}
+	defaServerConfigProvider, err := bceconf.NewDefaultServerConfigProvider()
+	if err != nil {
+		t.Errorf("makeBucket init NewDefaultServerConfigProvider failed")
+		return
+	}
+	bceconf.ServerConfigProvider = bceconf.NewChainServerConfigProvider(
+		[]bceconf.ServerConfigProviderInterface{serverConfigFileProvider,
+			defaServerConfigProvider})
+
+	stdinTemp := os.Stdin
+	for i, tCase := range testCases {
+		var (
+			tempFileName string
+			fd           *os.File
+			err          error
+		)
+		serverConfigFileProvider.SetRegion("bj")
+		if tCase.useAuto {
+			ok := serverConfigFileProvider.SetUseAutoSwitchDomain("yes")
+			if !ok {
+				t.Errorf("makeBucket set SetUseAutoSwitchDomain failed %t", tCase.useAuto)
 				continue
 			}
 		} else {
@@ -1568,6 +2326,7 @@ type copyRemoteRequestPreProcessType struct {
 	srcPath       string
 	dstPath       string
 	storageClass  string
+	concurrency   int
 	recursive     bool
 	srcBucketName string
 	srcObjectKey  string
@@ -1721,10 +2480,30 @@ func TestCopyRemoteRequestPreProcess(t *testing.T) {
 			isDir:         false,
 			isSuc:         true,
 		},
+		// 19
+		copyRemoteRequestPreProcessType{
+			srcPath:       "bos:/bucket",
+			dstPath:       "bos:/dstBucket",
+			concurrency:   8,
+			recursive:     true,
+			srcBucketName: "bucket",
+			dstBucketName: "dstBucket",
+			isDir:         true,
+			code:          BOSCLI_OK,
+			isSuc:         true,
+		},
+		// 20
+		copyRemoteRequestPreProcessType{
+			srcPath:     "bos:/bucket",
+			dstPath:     "bos:/dstBucket",
+			concurrency: -1,
+			recursive:   true,
+			code:        BOSCLI_CONCURRENCY_LESS_ZERO,
+		},
 	}
 	for i, tCase := range testCases {
 		args, code, err := testBosCli.copyRemoteRequestPreProcess(tCase.srcPath, tCase.dstPath,
-			tCase.storageClass, tCase.recursive)
+			tCase.storageClass, tCase.concurrency, tCase.recursive)
 		util.ExpectEqual("bos.go remote pre I", i+1, t.Errorf, tCase.code, code)
 		util.ExpectEqual("bos.go remote pre II", i+1, t.Errorf, tCase.isSuc, err == nil)
 		if tCase.isSuc {
@@ -1741,6 +2520,10 @@ func TestCopyRemoteRequestPreProcess(t *testing.T) {
 				args.dstObjectKey)
 			util.ExpectEqual("bos.go remote pre VII", i+1, t.Errorf, tCase.isDir,
 				args.srcIsDir)
+			if tCase.concurrency > 0 {
+				util.ExpectEqual("bos.go remote pre VIII", i+1, t.Errorf, tCase.concurrency,
+					args.concurrency)
+			}
 		}
 	}
 }
@@ -1794,6 +2577,7 @@ type copyObjectExecuteType struct {
 	storageClass string
 	isDir        bool
 	restart      bool
+	move         bool
 	copied       int
 	setCopied    bool
 	isSuc        bool
@@ -1887,6 +2671,19 @@ func TestCopyObjectExecute(t *testing.T) {
 			dstObject: "copyDstObjectError",
 			isSuc:     false,
 		},
+		//10 objects are not moved to themselves with another storage class
+		copyObjectExecuteType{
+			srcBucket:    "cli-test",
+			srcObject:    "progress/",
+			dstBucket:    "cli-test",
+			dstObject:    "progress/",
+			storageClass: "STANDARD_IA",
+			isDir:        true,
+			move:         true,
+			copied:       0,
+			setCopied:    true,
+			isSuc:        true,
+		},
 	}
 	for i, tCase := range testCases {
 		args := &copyBetweenRemoteArgs{
@@ -1895,6 +2692,7 @@ func TestCopyObjectExecute(t *testing.T) {
 			dstBucketName: tCase.dstBucket,
 			dstObjectKey:  tCase.dstObject,
 			srcIsDir:      tCase.isDir,
+			move:          tCase.move,
 		}
 		ret, _, err := testBosCli.copyObjectExecute(args, tCase.storageClass, true)
 		util.ExpectEqual("bos.go remote exe I", i+1, t.Errorf, tCase.isSuc, err == nil)
@@ -1957,18 +2755,72 @@ func TestCopyBetweenRemote(t *testing.T) {
 	}
 	for i, tCase := range testCases {
 		retCode, _ := testBosCli.copyBetweenRemote(tCase.srcPath, tCase.dstPath, tCase.storageClass,
-			tCase.recursive, true)
+			1, tCase.recursive, true, false, false, false, nil)
 		util.ExpectEqual("bos.go copyBetweenRemote", i+1, t.Errorf, tCase.isSuc, retCode == BOSCLI_OK)
 	}
 }
 
+type moveBetweenRemoteType struct {
+	srcPath      string
+	dstPath      string
+	storageClass string
+	recursive    bool
+	code         BosCliErrorCode
+}
+
+func TestMoveBetweenRemote(t *testing.T) {
+	testCases := []moveBetweenRemoteType{
+		//1 move to a sub directory of itself
+		moveBetweenRemoteType{
+			srcPath:   "bos:/bucket/a/",
+			dstPath:   "bos:/bucket/a/b/",
+			recursive: true,
+			code:      BOSCLI_MOVE_DST_IN_SRC,
+		},
+		//2 move the whole bucket to itself
+		moveBetweenRemoteType{
+			srcPath:   "bos:/bucket",
+			dstPath:   "bos:/bucket/b/",
+			recursive: true,
+			code:      BOSCLI_MOVE_DST_IN_SRC,
+		},
+		//3 move an object to itself to change its storage class
+		moveBetweenRemoteType{
+			srcPath:      "bos:/bucket/a/b",
+			dstPath:      "bos:/bucket/a/b",
+			storageClass: "STANDARD_IA",
+			code:         BOSCLI_MOVE_TO_ITSELF,
+		},
+		//4 the name of source is used in destination directory
+		moveBetweenRemoteType{
+			srcPath: "bos:/bucket/a/b",
+			dstPath: "bos:/bucket/a/",
+			code:    BOSCLI_MOVE_TO_ITSELF,
+		},
+		//5
+		moveBetweenRemoteType{
+			srcPath: "bos:/bucket/b",
+			dstPath: "bos:/bucket",
+			code:    BOSCLI_MOVE_TO_ITSELF,
+		},
+	}
+	for i, tCase := range testCases {
+		retCode, err := testBosCli.copyBetweenRemote(tCase.srcPath, tCase.dstPath,
+			tCase.storageClass, 1, tCase.recursive, true, true, false, false, nil)
+		util.ExpectEqual("bos.go move remote I", i+1, t.Errorf, tCase.code, retCode)
+		util.ExpectEqual("bos.go move remote II", i+1, t.Errorf, true, err != nil)
+	}
+}
+
 type copyDownloadPreProcessType struct {
 	srcPath       string
 	dstPath       string
+	concurrency   int
 	recursive     bool
 	srcBucketName string
 	srcObjectKey  string
 	isDir         bool
+	isStream      bool
 	code          BosCliErrorCode
 	isSuc         bool
 }
@@ -2079,10 +2931,48 @@ func TestCopyDownloadPreProcess(t *testing.T) {
 			code:          BOSCLI_OK,
 			isSuc:         true,
 		},
+		//13
+		copyDownloadPreProcessType{
+			srcPath:       "bos:/bucket/bce",
+			dstPath:       "-",
+			srcBucketName: "bucket",
+			srcObjectKey:  "bce",
+			isStream:      true,
+			code:          BOSCLI_OK,
+			isSuc:         true,
+		},
+		//14
+		copyDownloadPreProcessType{
+			srcPath:   "bos:/bucket/bce/",
+			dstPath:   "-",
+			recursive: true,
+			code:      BOSCLI_DOWNLOAD_DIR_TO_STREAM,
+			isSuc:     false,
+		},
+		//15
+		copyDownloadPreProcessType{
+			srcPath:       "bos:/bucket/",
+			dstPath:       local_dir,
+			concurrency:   6,
+			recursive:     true,
+			srcBucketName: "bucket",
+			isDir:         true,
+			code:          BOSCLI_OK,
+			isSuc:         true,
+		},
+		//16
+		copyDownloadPreProcessType{
+			srcPath:     "bos:/bucket/",
+			dstPath:     local_dir,
+			concurrency: -2,
+			recursive:   true,
+			code:        BOSCLI_CONCURRENCY_LESS_ZERO,
+			isSuc:       false,
+		},
 	}
 	for i, tCase := range testCases {
 		args, code, err := testBosCli.copyDownloadPreProcess(tCase.srcPath, tCase.dstPath,
-			tCase.recursive)
+			tCase.concurrency, tCase.recursive)
 
 		util.ExpectEqual("bos.go down pre I", i+1, t.Errorf, tCase.isSuc, err == nil)
 		util.ExpectEqual("bos.go down pre II", i+1, t.Errorf, tCase.code, code)
@@ -2096,6 +2986,12 @@ func TestCopyDownloadPreProcess(t *testing.T) {
 				args.srcObjectKey)
 			util.ExpectEqual("bos.go down pre IV", i+1, t.Errorf, tCase.isDir,
 				args.srcIsDir)
+			util.ExpectEqual("bos.go down pre V", i+1, t.Errorf, tCase.isStream,
+				args.isDownloadToStream)
+			if tCase.concurrency > 0 {
+				util.ExpectEqual("bos.go down pre VI", i+1, t.Errorf, tCase.concurrency,
+					args.concurrency)
+			}
 		}
 	}
 }
@@ -2108,7 +3004,11 @@ type copyDownloadExecuteType struct {
 	out           string
 	haveSep       bool
 	isDir         bool
+	concurrency   int
 	downed        int
+	move          bool
+	dryrun        bool
+	deleted       string
 	code          BosCliErrorCode
 	isSuc         bool
 }
@@ -2233,101 +3133,1239 @@ func TestCopyDownloadExecute(t *testing.T) {
 			downed:        0,
 			isSuc:         true,
 		},
+		copyDownloadExecuteType{
+			srcBucketName: "0",
+			srcObjectKey:  "key/",
+			dstPath:       "./",
+			haveSep:       true,
+			isDir:         true,
+			concurrency:   4,
+			downed:        8,
+			isSuc:         true,
+		},
+
+		// single object success
+		copyDownloadExecuteType{
+			srcBucketName: "0",
+			srcObjectKey:  "key",
+			dstPath:       "./",
+			out:           "0key./yes",
+			downed:        1,
+			isSuc:         true,
+		},
+		// single object success
+		copyDownloadExecuteType{
+			srcBucketName: "bucket",
+			srcObjectKey:  "test/key",
+			dstPath:       "./",
+			out:           "buckettest/key./yes",
+			downed:        1,
+			isSuc:         true,
+		},
+		// single object error
+		copyDownloadExecuteType{
+			srcBucketName: "error",
+			srcObjectKey:  "error",
+			dstPath:       "./",
+			isSuc:         false,
+		},
+		// move single object
+		copyDownloadExecuteType{
+			srcBucketName: "bucket",
+			srcObjectKey:  "test/key",
+			dstPath:       "./",
+			out:           "buckettest/key./yes",
+			downed:        1,
+			move:          true,
+			deleted:       "buckettest/key",
+			isSuc:         true,
+		},
+		// move objects
+		copyDownloadExecuteType{
+			srcBucketName: "0",
+			srcObjectKey:  "key/",
+			dstPath:       "./",
+			out:           "0key/a/i./a/iyes",
+			isDir:         true,
+			downed:        8,
+			move:          true,
+			deleted:       "0key/a/i",
+			isSuc:         true,
+		},
+		// failed to delete moved object
+		copyDownloadExecuteType{
+			srcBucketName: "error-delete-single",
+			srcObjectKey:  "key",
+			dstPath:       "./",
+			move:          true,
+			isSuc:         false,
+		},
+		// move dryrun
+		copyDownloadExecuteType{
+			srcBucketName: "0",
+			srcObjectKey:  "key/",
+			dstPath:       "./",
+			isDir:         true,
+			move:          true,
+			dryrun:        true,
+			isSuc:         true,
+		},
+	}
+	for i, tCase := range testCases {
+		args := &copyDownloadArgs{
+			srcBucketName: tCase.srcBucketName,
+			srcObjectKey:  tCase.srcObjectKey,
+			srcIsDir:      tCase.isDir,
+			concurrency:   tCase.concurrency,
+			move:          tCase.move,
+			dryrun:        tCase.dryrun,
+		}
+		testBosHandler.utilDownlaodArgVal = ""
+		testBosHandler.sigleDeleteArgVal = ""
+		ret, _, err := testBosCli.copyDownloadExecute(args, tCase.dstPath, tCase.downLoadTmp, true, false)
+		util.ExpectEqual("bos.go down exe I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if tCase.isSuc {
+			util.ExpectEqual("bos.go down exe II", i+1, t.Errorf, tCase.downed, ret.successed)
+			if tCase.concurrency <= 1 {
+				util.ExpectEqual("bos.go down exe III", i+1, t.Errorf, tCase.out,
+					testBosHandler.utilDownlaodArgVal)
+			}
+			util.ExpectEqual("bos.go down exe IV", i+1, t.Errorf, tCase.deleted,
+				testBosHandler.sigleDeleteArgVal)
+		}
+		t.Logf("want downed %d get %d", tCase.downed, ret.successed)
+	}
+	testBosCli.bosClient = tempClientBos
+}
+
+type copyDownloadType struct {
+	srcPath     string
+	dstPath     string
+	downLoadTmp string
+	byteRange   string
+	out         string
+	recursive   bool
+	isSuc       bool
+}
+
+func TestCopyDownload(t *testing.T) {
+	tempClientBos := testBosCli.bosClient
+	testBosCli.bosClient = tempFakeBosClient
+	testCases := []copyDownloadType{
+		copyDownloadType{
+			srcPath:   "bos:/0/key/",
+			dstPath:   "./",
+			out:       "0key/a/i./a/iyes",
+			recursive: true,
+			isSuc:     true,
+		},
+		copyDownloadType{
+			srcPath:   "bos:/bucket/test/key",
+			dstPath:   "./",
+			out:       "buckettest/key./yes",
+			recursive: true,
+			isSuc:     true,
+		},
+		copyDownloadType{
+			srcPath:   "bos://bucket/test/key",
+			dstPath:   "./",
+			out:       "buckettest/key./yes",
+			recursive: true,
+			isSuc:     true,
+		},
+		// download a range
+		copyDownloadType{
+			srcPath:   "bos:/bucket/test/key",
+			dstPath:   "./",
+			byteRange: "10-19",
+			out:       "buckettest/key./10:19",
+			isSuc:     true,
+		},
+		copyDownloadType{
+			srcPath:   "bos:/bucket/test/key",
+			dstPath:   "./part",
+			byteRange: "10-",
+			out:       "buckettest/key./part10:-1",
+			isSuc:     true,
+		},
+		// can not download a range of objects
+		copyDownloadType{
+			srcPath:   "bos:/bucket/test/",
+			dstPath:   "./",
+			byteRange: "10-19",
+			recursive: true,
+			isSuc:     false,
+		},
+		// invalid range
+		copyDownloadType{
+			srcPath:   "bos:/bucket/test/key",
+			dstPath:   "./",
+			byteRange: "19-10",
+			isSuc:     false,
+		},
+	}
+	for i, tCase := range testCases {
+		testBosHandler.utilDownlaodArgVal = ""
+		retCode, _ := testBosCli.copyDownload(tCase.srcPath, tCase.dstPath, tCase.downLoadTmp,
+			tCase.byteRange, 1, tCase.recursive, true, false, false, false, false)
+		util.ExpectEqual("bos.go down I", i+1, t.Errorf, tCase.isSuc,
+			retCode == BOSCLI_OK)
+		if tCase.isSuc {
+			util.ExpectEqual("bos.go down II", i+1, t.Errorf, tCase.out,
+				testBosHandler.utilDownlaodArgVal)
+		}
+	}
+	testBosCli.bosClient = tempClientBos
+}
+
+type findPreProcessType struct {
+	bosPath      string
+	name         string
+	size         string
+	mtime        string
+	storageClass string
+	execCmd      string
+	del          bool
+	execArgs     []string
+	code         BosCliErrorCode
+	isSuc        bool
+}
+
+func TestFindPreProcess(t *testing.T) {
+	testCases := []findPreProcessType{
+		//1
+		findPreProcessType{
+			bosPath:      "bos:/bucket/logs/",
+			name:         "*.log",
+			size:         "+100M",
+			mtime:        "-7d",
+			storageClass: "cold",
+			execCmd:      "bcecmd bos stat {}",
+			execArgs:     []string{"bcecmd", "bos", "stat", "{}"},
+			code:         BOSCLI_OK,
+			isSuc:        true,
+		},
+		//2
+		findPreProcessType{
+			bosPath:  "bos:/bucket",
+			del:      true,
+			execArgs: []string{},
+			code:     BOSCLI_OK,
+			isSuc:    true,
+		},
+		//3
+		findPreProcessType{
+			bosPath: "bos:/",
+			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		//4
+		findPreProcessType{
+			bosPath: "bos:/bucket",
+			execCmd: "echo {}",
+			del:     true,
+			code:    BOSCLI_FIND_EXEC_DELETE_TOG,
+		},
+		//5
+		findPreProcessType{
+			bosPath: "bos:/bucket",
+			size:    "+100X",
+			code:    BOSCLI_FIND_SIZE_INVALID,
+		},
+		//6
+		findPreProcessType{
+			bosPath: "bos:/bucket",
+			mtime:   "7y",
+			code:    BOSCLI_FIND_MTIME_INVALID,
+		},
+		//7
+		findPreProcessType{
+			bosPath:      "bos:/bucket",
+			storageClass: "HOT",
+			code:         BOSCLI_UNSUPPORT_STORAGE_CLASS,
+		},
+		//8 quoted arguments
+		findPreProcessType{
+			bosPath:  "bos:/bucket",
+			execCmd:  "sh -c 'echo {} | wc -c'",
+			execArgs: []string{"sh", "-c", "echo {} | wc -c"},
+			code:     BOSCLI_OK,
+			isSuc:    true,
+		},
+		//9
+		findPreProcessType{
+			bosPath: "bos:/bucket",
+			execCmd: "sh -c 'echo {} | wc -c",
+			code:    BOSCLI_FIND_EXEC_INVALID,
+		},
+	}
+	for i, tCase := range testCases {
+		args, code, err := testBosCli.findPreProcess(tCase.bosPath, tCase.name, tCase.size,
+			tCase.mtime, tCase.storageClass, tCase.execCmd, false, tCase.del)
+		util.ExpectEqual("bos.go find pre I", i+1, t.Errorf, tCase.code, code)
+		if tCase.isSuc {
+			util.ExpectEqual("bos.go find pre II", i+1, t.Errorf, true, err == nil)
+			util.ExpectEqual("bos.go find pre III", i+1, t.Errorf, tCase.name,
+				args.filter.namePattern)
+			util.ExpectEqual("bos.go find pre IV", i+1, t.Errorf, tCase.size != "",
+				args.filter.size != nil)
+			util.ExpectEqual("bos.go find pre V", i+1, t.Errorf, tCase.mtime != "",
+				args.filter.mtime != nil)
+			util.ExpectEqual("bos.go find pre VI", i+1, t.Errorf,
+				strings.ToUpper(tCase.storageClass), args.filter.storageClass)
+			util.ExpectEqual("bos.go find pre VII", i+1, t.Errorf, tCase.execArgs,
+				args.execArgs)
+			util.ExpectEqual("bos.go find pre VIII", i+1, t.Errorf, tCase.del, args.del)
+		}
+	}
+}
+
+type findExecuteType struct {
+	bucketName   string
+	name         string
+	size         string
+	mtime        string
+	storageClass string
+	execArgs     []string
+	del          bool
+	deleted      []string
+	successed    int
+	failed       int
+	isSuc        bool
+}
+
+func TestFindExecute(t *testing.T) {
+	testCases := []findExecuteType{
+		//1 all objects
+		findExecuteType{
+			bucketName: "0",
+			successed:  6,
+			isSuc:      true,
+		},
+		//2
+		findExecuteType{
+			bucketName: "0",
+			size:       "+150",
+			successed:  4,
+			isSuc:      true,
+		},
+		//3
+		findExecuteType{
+			bucketName: "0",
+			size:       "-150",
+			successed:  2,
+			isSuc:      true,
+		},
+		//4
+		findExecuteType{
+			bucketName: "0",
+			name:       "[bc]",
+			successed:  2,
+			isSuc:      true,
+		},
+		//5 objects are modified before 2018
+		findExecuteType{
+			bucketName: "0",
+			mtime:      "-7d",
+			successed:  0,
+			isSuc:      true,
+		},
+		//6
+		findExecuteType{
+			bucketName: "0",
+			mtime:      "+365d",
+			successed:  6,
+			isSuc:      true,
+		},
+		//7 storage class of a/b is empty
+		findExecuteType{
+			bucketName:   "0",
+			storageClass: "STANDARD",
+			successed:    6,
+			isSuc:        true,
+		},
+		//8
+		findExecuteType{
+			bucketName:   "0",
+			storageClass: "COLD",
+			successed:    0,
+			isSuc:        true,
+		},
+		//9
+		findExecuteType{
+			bucketName: "0",
+			size:       "+250",
+			del:        true,
+			deleted:    []string{"a/d", "a/h"},
+			successed:  2,
+			isSuc:      true,
+		},
+		//10
+		findExecuteType{
+			bucketName: "0",
+			name:       "b",
+			execArgs:   []string{"true", "{}"},
+			successed:  1,
+			isSuc:      true,
+		},
+		//11
+		findExecuteType{
+			bucketName: "0",
+			name:       "b",
+			execArgs:   []string{"false", "{}"},
+			failed:     1,
+			isSuc:      true,
+		},
+		//12
+		findExecuteType{
+			bucketName: "error",
+			isSuc:      false,
+		},
+		//13 bad pattern
+		findExecuteType{
+			bucketName: "0",
+			name:       "[",
+			isSuc:      false,
+		},
+		//14 script quoted in --exec is one argument of sh
+		findExecuteType{
+			bucketName: "0",
+			name:       "b",
+			execArgs:   []string{"sh", "-c", "test \"$0\" = bos:/0/a/b", "{}"},
+			successed:  1,
+			isSuc:      true,
+		},
+	}
+	for i, tCase := range testCases {
+		filter, _, err := newFindFilter(tCase.name, tCase.size, tCase.mtime, tCase.storageClass)
+		if err != nil {
+			t.Errorf("bos.go find exe case %d: %s", i+1, err)
+			continue
+		}
+		args := &findArgs{
+			bucketName: tCase.bucketName,
+			filter:     filter,
+			execArgs:   tCase.execArgs,
+			del:        tCase.del,
+		}
+		testBosHandler.multiDeleteArgVal = nil
+		ret, err := testBosCli.findExecute(args)
+		util.ExpectEqual("bos.go find exe I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if tCase.isSuc {
+			util.ExpectEqual("bos.go find exe II", i+1, t.Errorf, tCase.successed,
+				ret.successed)
+			util.ExpectEqual("bos.go find exe III", i+1, t.Errorf, tCase.failed, ret.failed)
+			util.ExpectEqual("bos.go find exe IV", i+1, t.Errorf, tCase.deleted,
+				testBosHandler.multiDeleteArgVal)
+		}
+	}
+}
+
+func TestBuildFindExecArgs(t *testing.T) {
+	ret := buildFindExecArgs([]string{"echo", "{}", "--key={}", "x"}, "bos:/b/k")
+	util.ExpectEqual("bos.go buildFindExecArgs", 1, t.Errorf,
+		[]string{"echo", "bos:/b/k", "--key=bos:/b/k", "x"}, ret)
+
+	// a path with spaces is kept in one argument
+	ret = buildFindExecArgs([]string{"echo", "{}"}, "bos:/b/a b")
+	util.ExpectEqual("bos.go buildFindExecArgs", 2, t.Errorf, []string{"echo", "bos:/b/a b"}, ret)
+}
+
+type catPreProcessType struct {
+	bosPath    string
+	byteRange  string
+	tail       int64
+	decompress bool
+	start      int64
+	end        int64
+	code       BosCliErrorCode
+	isSuc      bool
+}
+
+func TestCatPreProcess(t *testing.T) {
+	testCases := []catPreProcessType{
+		//1
+		catPreProcessType{
+			bosPath: "bos:/bucket/key",
+			end:     -1,
+			code:    BOSCLI_OK,
+			isSuc:   true,
+		},
+		//2
+		catPreProcessType{
+			bosPath:   "bos:/bucket/key",
+			byteRange: "0-99",
+			start:     0,
+			end:       99,
+			code:      BOSCLI_OK,
+			isSuc:     true,
+		},
+		//3
+		catPreProcessType{
+			bosPath:   "bos:/bucket/key",
+			byteRange: "100-",
+			start:     100,
+			end:       -1,
+			code:      BOSCLI_OK,
+			isSuc:     true,
+		},
+		//4
+		catPreProcessType{
+			bosPath:    "bos:/bucket/key.gz",
+			decompress: true,
+			end:        -1,
+			code:       BOSCLI_OK,
+			isSuc:      true,
+		},
+		//5
+		catPreProcessType{
+			bosPath: "bos:/",
+			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		//6
+		catPreProcessType{
+			bosPath: "bos:/bucket",
+			code:    BOSCLI_CAT_OBJECT_IS_DIR,
+		},
+		//7
+		catPreProcessType{
+			bosPath: "bos:/bucket/dir/",
+			code:    BOSCLI_CAT_OBJECT_IS_DIR,
+		},
+		//8
+		catPreProcessType{
+			bosPath:   "bos:/bucket/key",
+			byteRange: "0-99",
+			tail:      10,
+			code:      BOSCLI_CAT_RANGE_TAIL_TOG,
+		},
+		//9
+		catPreProcessType{
+			bosPath: "bos:/bucket/key",
+			tail:    -1,
+			code:    BOSCLI_CAT_TAIL_LESS_ZERO,
+		},
+		//10
+		catPreProcessType{
+			bosPath:    "bos:/bucket/key.gz",
+			tail:       10,
+			decompress: true,
+			code:       BOSCLI_CAT_DECOMPRESS_WITH_RANGE,
+		},
+		//11
+		catPreProcessType{
+			bosPath:   "bos:/bucket/key",
+			byteRange: "a-b",
+			code:      BOSCLI_RANGE_INVALID,
+		},
+	}
+	for i, tCase := range testCases {
+		args, code, err := testBosCli.catPreProcess(tCase.bosPath, tCase.byteRange, tCase.tail,
+			tCase.decompress)
+		util.ExpectEqual("bos.go cat pre I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		util.ExpectEqual("bos.go cat pre II", i+1, t.Errorf, tCase.code, code)
+		if tCase.isSuc {
+			util.ExpectEqual("bos.go cat pre III", i+1, t.Errorf, tCase.start, args.start)
+			util.ExpectEqual("bos.go cat pre IV", i+1, t.Errorf, tCase.end, args.end)
+			util.ExpectEqual("bos.go cat pre V", i+1, t.Errorf, tCase.decompress,
+				args.decompress)
+		}
+	}
+}
+
+type catExecuteType struct {
+	args  *catArgs
+	out   string
+	isSuc bool
+}
+
+func TestCatExecute(t *testing.T) {
+	tempClientBos := testBosCli.bosClient
+	testBosCli.bosClient = tempFakeBosClient
+	testCases := []catExecuteType{
+		//1 whole object
+		catExecuteType{
+			args:  &catArgs{bucketName: "bucket", objectKey: "key", end: -1},
+			out:   "bucketkey0:-1",
+			isSuc: true,
+		},
+		//2 range
+		catExecuteType{
+			args:  &catArgs{bucketName: "bucket", objectKey: "key", start: 10, end: 19},
+			out:   "bucketkey10:19",
+			isSuc: true,
+		},
+		//3 the size of object is 100
+		catExecuteType{
+			args:  &catArgs{bucketName: "bucket", objectKey: "key", end: -1, tail: 30},
+			out:   "bucketkey70:-1",
+			isSuc: true,
+		},
+		//4 tail is larger than object
+		catExecuteType{
+			args:  &catArgs{bucketName: "bucket", objectKey: "key", end: -1, tail: 300},
+			out:   "bucketkey0:-1",
+			isSuc: true,
+		},
+		//5
+		catExecuteType{
+			args: &catArgs{bucketName: "bucket", objectKey: "key.gz", end: -1,
+				decompress: true},
+			out:   "bucketkey.gz0:-1gzip",
+			isSuc: true,
+		},
+		//6 object don't exist
+		catExecuteType{
+			args:  &catArgs{bucketName: "bucket", objectKey: "404", end: -1, tail: 30},
+			isSuc: false,
+		},
+		//7
+		catExecuteType{
+			args:  &catArgs{bucketName: "error", objectKey: "key", end: -1},
+			isSuc: false,
+		},
+	}
+	for i, tCase := range testCases {
+		out := &bytes.Buffer{}
+		err := testBosCli.catExecute(tCase.args, out)
+		util.ExpectEqual("bos.go cat exe I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		util.ExpectEqual("bos.go cat exe II", i+1, t.Errorf, tCase.out, out.String())
+	}
+	testBosCli.bosClient = tempClientBos
+}
+
+type restorePreProcessType struct {
+	bosPath   string
+	tier      string
+	days      int
+	recursive bool
+	out       *restoreArgs
+	code      BosCliErrorCode
+}
+
+func TestRestorePreProcess(t *testing.T) {
+	testCases := []restorePreProcessType{
+		//1
+		restorePreProcessType{
+			bosPath: "bos:/bucket/a/b",
+			tier:    "standard",
+			days:    7,
+			out: &restoreArgs{bucketName: "bucket", objectKey: "a/b", tier: "Standard",
+				days: 7},
+			code: BOSCLI_OK,
+		},
+		//2
+		restorePreProcessType{
+			bosPath:   "bos:/bucket/a/",
+			tier:      "Expedited",
+			days:      30,
+			recursive: true,
+			out: &restoreArgs{bucketName: "bucket", objectKey: "a/", isDir: true,
+				tier: "Expedited", days: 30},
+			code: BOSCLI_OK,
+		},
+		//3
+		restorePreProcessType{
+			bosPath: "bos:/bucket/a/",
+			tier:    "Standard",
+			days:    7,
+			code:    BOSCLI_RESTORE_DIR_MUST_USE_RECURSIVE,
+		},
+		//4
+		restorePreProcessType{
+			bosPath:   "bos:/bucket",
+			tier:      "Standard",
+			days:      0,
+			recursive: true,
+			code:      BOSCLI_RESTORE_DAYS_INVALID,
+		},
+		//5
+		restorePreProcessType{
+			bosPath: "bos:/bucket/a/b",
+			tier:    "Standard",
+			days:    31,
+			code:    BOSCLI_RESTORE_DAYS_INVALID,
+		},
+		//6
+		restorePreProcessType{
+			bosPath: "bos:/bucket/a/b",
+			tier:    "Bulk",
+			days:    7,
+			code:    BOSCLI_RESTORE_TIER_INVALID,
+		},
+		//7
+		restorePreProcessType{
+			bosPath:   "bos:/",
+			tier:      "Standard",
+			days:      7,
+			recursive: true,
+			code:      BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		//8
+		restorePreProcessType{
+			bosPath: "/bucket/a/b",
+			tier:    "Standard",
+			days:    7,
+			code:    BOSCLI_BOSPATH_IS_INVALID,
+		},
+	}
+	for i, tCase := range testCases {
+		ret, code, _ := testBosCli.restorePreProcess(tCase.bosPath, tCase.tier, tCase.days,
+			tCase.recursive)
+		util.ExpectEqual("bos.go restore pre I", i+1, t.Errorf, tCase.code, code)
+		if tCase.code == BOSCLI_OK {
+			util.ExpectEqual("bos.go restore pre II", i+1, t.Errorf, tCase.out, ret)
+		}
+	}
+}
+
+// objects under bucket "0" used by restore and the check of archived objects
+func newArchiveFakeBosClient() *fakeBosClientForBos {
+	return &fakeBosClientForBos{
+		results: []*api.ListObjectsResult{
+			&api.ListObjectsResult{
+				Contents: []api.ObjectSummaryType{
+					api.ObjectSummaryType{
+						Key:          "a/b",
+						LastModified: "2006-01-02T15:04:05Z",
+						Size:         100,
+						StorageClass: "ARCHIVE",
+					},
+					api.ObjectSummaryType{
+						Key:          "a/c",
+						LastModified: "2016-11-02T15:04:05Z",
+						Size:         200,
+						StorageClass: "STANDARD",
+					},
+					api.ObjectSummaryType{
+						Key:          "a/error",
+						LastModified: "2017-11-02T15:04:05Z",
+						Size:         300,
+						StorageClass: "ARCHIVE",
+					},
+				},
+			},
+		},
+	}
+}
+
+type restoreExecuteType struct {
+	args      *restoreArgs
+	restored  []string
+	successed int
+	failed    int
+	isSuc     bool
+}
+
+func TestRestoreExecute(t *testing.T) {
+	tempClientBos := testBosCli.bosClient
+	testCases := []restoreExecuteType{
+		//1
+		restoreExecuteType{
+			args:      &restoreArgs{bucketName: "bucket", objectKey: "a/b"},
+			restored:  []string{"bucket/a/b"},
+			successed: 1,
+			isSuc:     true,
+		},
+		//2
+		restoreExecuteType{
+			args:   &restoreArgs{bucketName: "bucket", objectKey: "error"},
+			failed: 1,
+			isSuc:  false,
+		},
+		//3 only archived objects are restored
+		restoreExecuteType{
+			args:      &restoreArgs{bucketName: "0", isDir: true},
+			restored:  []string{"0/a/b"},
+			successed: 1,
+			failed:    1,
+			isSuc:     true,
+		},
+		//4
+		restoreExecuteType{
+			args:  &restoreArgs{bucketName: "error", isDir: true},
+			isSuc: false,
+		},
+	}
+	for i, tCase := range testCases {
+		fakeClient := newArchiveFakeBosClient()
+		testBosCli.bosClient = fakeClient
+		ret, err := testBosCli.restoreExecute(tCase.args)
+		util.ExpectEqual("bos.go restore exe I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		util.ExpectEqual("bos.go restore exe II", i+1, t.Errorf, tCase.restored,
+			fakeClient.restoredObjects)
+		util.ExpectEqual("bos.go restore exe III", i+1, t.Errorf, tCase.successed,
+			ret.successed)
+		util.ExpectEqual("bos.go restore exe IV", i+1, t.Errorf, tCase.failed, ret.failed)
+	}
+	testBosCli.bosClient = tempClientBos
+}
 
-		// single object success
-		copyDownloadExecuteType{
-			srcBucketName: "0",
-			srcObjectKey:  "key",
-			dstPath:       "./",
-			out:           "0key./yes",
-			downed:        1,
-			isSuc:         true,
+type skipArchivedObjectType struct {
+	storageClass   string
+	objectMeta     *api.GetObjectMetaResult
+	restoreArchive bool
+	restored       []string
+	skip           bool
+}
+
+func TestSkipArchivedObject(t *testing.T) {
+	lastModified := "Mon, 02 Jan 2006 15:04:05 GMT"
+	testCases := []skipArchivedObjectType{
+		//1 metadata is not needed
+		skipArchivedObjectType{
+			storageClass: "STANDARD",
+			skip:         false,
 		},
-		// single object success
-		copyDownloadExecuteType{
-			srcBucketName: "bucket",
-			srcObjectKey:  "test/key",
-			dstPath:       "./",
-			out:           "buckettest/key./yes",
-			downed:        1,
-			isSuc:         true,
+		//2
+		skipArchivedObjectType{
+			storageClass: "",
+			objectMeta: &api.GetObjectMetaResult{ObjectMeta: api.ObjectMeta{
+				LastModified: lastModified, StorageClass: "COLD"}},
+			skip: false,
 		},
-		// single object error
-		copyDownloadExecuteType{
-			srcBucketName: "error",
-			srcObjectKey:  "error",
-			dstPath:       "./",
-			isSuc:         false,
+		//3
+		skipArchivedObjectType{
+			storageClass: "ARCHIVE",
+			objectMeta: &api.GetObjectMetaResult{ObjectMeta: api.ObjectMeta{
+				LastModified: lastModified, StorageClass: "ARCHIVE"}},
+			skip: true,
+		},
+		//4
+		skipArchivedObjectType{
+			storageClass: "ARCHIVE",
+			objectMeta: &api.GetObjectMetaResult{ObjectMeta: api.ObjectMeta{
+				LastModified: lastModified, StorageClass: "ARCHIVE"}},
+			restoreArchive: true,
+			restored:       []string{"bucket/key"},
+			skip:           true,
+		},
+		//5 restore is ongoing
+		skipArchivedObjectType{
+			storageClass: "",
+			objectMeta: &api.GetObjectMetaResult{ObjectMeta: api.ObjectMeta{
+				LastModified: lastModified, StorageClass: "ARCHIVE",
+				BceRestore: "ongoing-request=\"true\""}},
+			restoreArchive: true,
+			skip:           true,
+		},
+		//6
+		skipArchivedObjectType{
+			storageClass: "ARCHIVE",
+			objectMeta: &api.GetObjectMetaResult{ObjectMeta: api.ObjectMeta{
+				LastModified: lastModified, StorageClass: "ARCHIVE",
+				BceRestore: "ongoing-request=\"false\", " +
+					"expiry-date=\"Wed, 07 Nov 2019 00:00:00 GMT\""}},
+			skip: false,
+		},
+		//7 failed to get metadata
+		skipArchivedObjectType{
+			storageClass: "ARCHIVE",
+			skip:         false,
 		},
 	}
 	for i, tCase := range testCases {
-		args := &copyDownloadArgs{
-			srcBucketName: tCase.srcBucketName,
-			srcObjectKey:  tCase.srcObjectKey,
-			srcIsDir:      tCase.isDir,
+		fakeClient := &fakeBosClientForBos{objectMeta: tCase.objectMeta}
+		ret := testBosCli.skipArchivedObject(fakeClient, SYNC_OP_COPY, "bucket", "key",
+			tCase.storageClass, "bos:/bucket2/key", tCase.restoreArchive)
+		util.ExpectEqual("bos.go skipArchivedObject I", i+1, t.Errorf, tCase.skip, ret)
+		util.ExpectEqual("bos.go skipArchivedObject II", i+1, t.Errorf, tCase.restored,
+			fakeClient.restoredObjects)
+	}
+}
+
+type setStorageClassPreProcessType struct {
+	bosPath      string
+	storageClass string
+	size         string
+	olderThan    string
+	include      []string
+	exclude      []string
+	recursive    bool
+	code         BosCliErrorCode
+}
+
+func TestSetStorageClassPreProcess(t *testing.T) {
+	testCases := []setStorageClassPreProcessType{
+		//1
+		setStorageClassPreProcessType{
+			bosPath:      "bos:/bucket/a/b",
+			storageClass: "cold",
+			code:         BOSCLI_OK,
+		},
+		//2
+		setStorageClassPreProcessType{
+			bosPath:      "bos:/bucket/a/",
+			storageClass: "COLD",
+			size:         "+100M",
+			olderThan:    "30d",
+			include:      []string{"*.log"},
+			recursive:    true,
+			code:         BOSCLI_OK,
+		},
+		//3
+		setStorageClassPreProcessType{
+			bosPath:      "bos:/bucket/a/",
+			storageClass: "COLD",
+			code:         BOSCLI_SET_STORAGE_CLASS_MUST_RECURSIVE,
+		},
+		//4
+		setStorageClassPreProcessType{
+			bosPath:   "bos:/bucket/a/b",
+			recursive: true,
+			code:      BOSCLI_STORAGE_CLASS_IS_EMPTY,
+		},
+		//5
+		setStorageClassPreProcessType{
+			bosPath:      "bos:/bucket/a/b",
+			storageClass: "HOT",
+			code:         BOSCLI_UNSUPPORT_STORAGE_CLASS,
+		},
+		//6
+		setStorageClassPreProcessType{
+			bosPath:      "bos:/bucket/a/b",
+			storageClass: "COLD",
+			olderThan:    "-30d",
+			code:         BOSCLI_OLDER_THAN_INVALID,
+		},
+		//7
+		setStorageClassPreProcessType{
+			bosPath:      "bos:/bucket/a/b",
+			storageClass: "COLD",
+			olderThan:    "30y",
+			code:         BOSCLI_OLDER_THAN_INVALID,
+		},
+		//8
+		setStorageClassPreProcessType{
+			bosPath:      "bos:/bucket/a/b",
+			storageClass: "COLD",
+			size:         "1X",
+			code:         BOSCLI_FIND_SIZE_INVALID,
+		},
+		//9
+		setStorageClassPreProcessType{
+			bosPath:      "bos:/bucket/",
+			storageClass: "COLD",
+			include:      []string{"*.log"},
+			exclude:      []string{"*.jpg"},
+			recursive:    true,
+			code:         BOSCLI_SYNC_EXCLUDE_INCLUDE_TOG,
+		},
+		//10
+		setStorageClassPreProcessType{
+			bosPath:      "bos:/",
+			storageClass: "COLD",
+			recursive:    true,
+			code:         BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+	}
+	for i, tCase := range testCases {
+		ret, code, _ := testBosCli.setStorageClassPreProcess(tCase.bosPath, tCase.storageClass,
+			tCase.size, tCase.olderThan, tCase.include, tCase.exclude, 1, tCase.recursive)
+		util.ExpectEqual("bos.go set storage class pre I", i+1, t.Errorf, tCase.code, code)
+		if code == BOSCLI_OK {
+			util.ExpectEqual("bos.go set storage class pre II", i+1, t.Errorf,
+				strings.ToUpper(tCase.storageClass), ret.storageClass)
+			util.ExpectEqual("bos.go set storage class pre III", i+1, t.Errorf,
+				tCase.recursive, ret.isDir)
+			util.ExpectEqual("bos.go set storage class pre IV", i+1, t.Errorf,
+				len(tCase.include) > 0, ret.patternFilter != nil)
 		}
-		ret, _, err := testBosCli.copyDownloadExecute(args, tCase.dstPath, tCase.downLoadTmp, true, false)
-		util.ExpectEqual("bos.go down exe I", i+1, t.Errorf, tCase.isSuc, err == nil)
-		if tCase.isSuc {
-			util.ExpectEqual("bos.go down exe II", i+1, t.Errorf, tCase.downed, ret.successed)
-			util.ExpectEqual("bos.go down exe III", i+1, t.Errorf, tCase.out,
-				testBosHandler.utilDownlaodArgVal)
+	}
+}
+
+type setStorageClassExecuteType struct {
+	bosPath      string
+	storageClass string
+	size         string
+	olderThan    string
+	include      []string
+	exclude      []string
+	dryrun       bool
+	changed      []string
+	successed    int
+	failed       int
+	isSuc        bool
+}
+
+func TestSetStorageClassExecute(t *testing.T) {
+	testCases := []setStorageClassExecuteType{
+		//1 changing a/g fails
+		setStorageClassExecuteType{
+			bosPath:      "bos:/0",
+			storageClass: "COLD",
+			changed:      []string{"a/b:COLD", "a/c:COLD", "a/d:COLD", "a/f:COLD", "a/h:COLD"},
+			successed:    5,
+			failed:       1,
+			isSuc:        true,
+		},
+		//2 all objects are standard
+		setStorageClassExecuteType{
+			bosPath:      "bos:/0",
+			storageClass: "STANDARD",
+			isSuc:        true,
+		},
+		//3
+		setStorageClassExecuteType{
+			bosPath:      "bos:/0",
+			storageClass: "STANDARD_IA",
+			size:         "+250",
+			changed:      []string{"a/d:STANDARD_IA", "a/h:STANDARD_IA"},
+			successed:    2,
+			isSuc:        true,
+		},
+		//4
+		setStorageClassExecuteType{
+			bosPath:      "bos:/0",
+			storageClass: "COLD",
+			include:      []string{"bos:/0/a/[bc]"},
+			changed:      []string{"a/b:COLD", "a/c:COLD"},
+			successed:    2,
+			isSuc:        true,
+		},
+		//5
+		setStorageClassExecuteType{
+			bosPath:      "bos:/0",
+			storageClass: "COLD",
+			exclude:      []string{"bos:/0/a/[bcdfg]"},
+			changed:      []string{"a/h:COLD"},
+			successed:    1,
+			isSuc:        true,
+		},
+		//6 objects are modified before 2018
+		setStorageClassExecuteType{
+			bosPath:      "bos:/0",
+			storageClass: "COLD",
+			olderThan:    "100000d",
+			isSuc:        true,
+		},
+		//7
+		setStorageClassExecuteType{
+			bosPath:      "bos:/0",
+			storageClass: "COLD",
+			dryrun:       true,
+			isSuc:        true,
+		},
+		//8
+		setStorageClassExecuteType{
+			bosPath:      "bos:/error",
+			storageClass: "COLD",
+			isSuc:        false,
+		},
+	}
+	for i, tCase := range testCases {
+		args, code, err := testBosCli.setStorageClassPreProcess(tCase.bosPath,
+			tCase.storageClass, tCase.size, tCase.olderThan, tCase.include, tCase.exclude, 1,
+			true)
+		if code != BOSCLI_OK {
+			t.Errorf("bos.go set storage class exe case %d: %s %v", i+1, code, err)
+			continue
 		}
-		t.Logf("want downed %d get %d", tCase.downed, ret.successed)
+		args.dryrun = tCase.dryrun
+		testBosHandler.setStorageClassArgVal = nil
+		ret, err := testBosCli.setStorageClassExecute(args)
+		util.ExpectEqual("bos.go set storage class exe I", i+1, t.Errorf, tCase.isSuc,
+			err == nil)
+		util.ExpectEqual("bos.go set storage class exe II", i+1, t.Errorf, tCase.changed,
+			testBosHandler.setStorageClassArgVal)
+		util.ExpectEqual("bos.go set storage class exe III", i+1, t.Errorf, tCase.successed,
+			ret.successed)
+		util.ExpectEqual("bos.go set storage class exe IV", i+1, t.Errorf, tCase.failed,
+			ret.failed)
 	}
-	testBosCli.bosClient = tempClientBos
 }
 
-type copyDownloadType struct {
-	srcPath     string
-	dstPath     string
-	downLoadTmp string
-	out         string
+type undeletePreProcessType struct {
+	bosPath     string
 	recursive   bool
-	isSuc       bool
+	bucketName  string
+	objectKey   string
+	trashDir    string
+	concurrency int
+	code        BosCliErrorCode
 }
 
-func TestCopyDownload(t *testing.T) {
-	tempClientBos := testBosCli.bosClient
-	testBosCli.bosClient = tempFakeBosClient
-	testCases := []copyDownloadType{
-		copyDownloadType{
-			srcPath:   "bos:/0/key/",
-			dstPath:   "./",
-			out:       "0key/a/i./a/iyes",
-			recursive: true,
-			isSuc:     true,
+func TestUndeletePreProcess(t *testing.T) {
+	testCases := []undeletePreProcessType{
+		//1
+		undeletePreProcessType{
+			bosPath: "/bucket",
+			code:    BOSCLI_BOSPATH_IS_INVALID,
 		},
-		copyDownloadType{
-			srcPath:   "bos:/bucket/test/key",
-			dstPath:   "./",
-			out:       "buckettest/key./yes",
-			recursive: true,
-			isSuc:     true,
+		//2
+		undeletePreProcessType{
+			bosPath: "bos://",
+			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
 		},
-		copyDownloadType{
-			srcPath:   "bos://bucket/test/key",
-			dstPath:   "./",
-			out:       "buckettest/key./yes",
+		//3
+		undeletePreProcessType{
+			bosPath: "bos:/success/.trash/dir/",
+			code:    BOSCLI_UNDELETE_DIR_MUST_USE_RECURSIVE,
+		},
+		//4
+		undeletePreProcessType{
+			bosPath: "bos:/success/dir/key",
+			code:    BOSCLI_UNDELETE_NOT_IN_TRASH,
+		},
+		//5
+		undeletePreProcessType{
+			bosPath:   "bos:/success/.trashdir/",
 			recursive: true,
+			code:      BOSCLI_UNDELETE_NOT_IN_TRASH,
+		},
+		//6 failed to get trash
+		undeletePreProcessType{
+			bosPath: "bos:/error/.trash/key",
+			code:    BOSCLI_EMPTY_CODE,
+		},
+		//7
+		undeletePreProcessType{
+			bosPath:    "bos:/success/.trash/dir/key",
+			bucketName: "success",
+			objectKey:  ".trash/dir/key",
+			trashDir:   ".trash",
+			code:       BOSCLI_OK,
+		},
+		//8 whole trash
+		undeletePreProcessType{
+			bosPath:     "bos:/success",
+			recursive:   true,
+			bucketName:  "success",
+			objectKey:   ".trash/",
+			trashDir:    ".trash",
+			concurrency: 10,
+			code:        BOSCLI_OK,
+		},
+		//9
+		undeletePreProcessType{
+			bosPath:     "bos:/0/a",
+			recursive:   true,
+			bucketName:  "0",
+			objectKey:   "a/",
+			trashDir:    "a",
+			concurrency: 10,
+			code:        BOSCLI_OK,
+		},
+		//10 objects removed before the trash is disabled are kept in the default trash
+		undeletePreProcessType{
+			bosPath:     "bos:/trash-disabled/.trash/dir/",
+			recursive:   true,
+			bucketName:  "trash-disabled",
+			objectKey:   ".trash/dir/",
+			trashDir:    DEFAULT_TRASH_DIR,
+			concurrency: 10,
+			code:        BOSCLI_OK,
+		},
+	}
+	for i, tCase := range testCases {
+		args, code, err := testBosCli.undeletePreProcess(tCase.bosPath, 10, tCase.recursive)
+		util.ExpectEqual("bos.go undelete pre I", i+1, t.Errorf, tCase.code, code)
+		if code != BOSCLI_OK {
+			util.ExpectEqual("bos.go undelete pre II", i+1, t.Errorf, true, err != nil)
+			continue
+		}
+		util.ExpectEqual("bos.go undelete pre III", i+1, t.Errorf, tCase.bucketName,
+			args.bucketName)
+		util.ExpectEqual("bos.go undelete pre IV", i+1, t.Errorf, tCase.objectKey,
+			args.objectKey)
+		util.ExpectEqual("bos.go undelete pre V", i+1, t.Errorf, tCase.trashDir, args.trashDir)
+		util.ExpectEqual("bos.go undelete pre VI", i+1, t.Errorf, tCase.recursive, args.isDir)
+		util.ExpectEqual("bos.go undelete pre VII", i+1, t.Errorf, tCase.concurrency,
+			args.concurrency)
+	}
+}
+
+type undeleteExecuteType struct {
+	bosPath   string
+	dryrun    bool
+	yes       bool
+	recovered []string
+	successed int
+	failed    int
+	skipped   int
+	isSuc     bool
+}
+
+func TestUndeleteExecute(t *testing.T) {
+	testCases := []undeleteExecuteType{
+		//1 recovering a/g fails
+		undeleteExecuteType{
+			bosPath:   "bos:/0/a/",
+			yes:       true,
+			recovered: []string{"a/b:b", "a/c:c", "a/d:d", "a/f:f", "a/h:h"},
+			successed: 5,
+			failed:    1,
 			isSuc:     true,
 		},
+		//2 existing objects are not overwritten, which is not a failure
+		undeleteExecuteType{
+			bosPath: "bos:/0/a/",
+			skipped: 6,
+			isSuc:   true,
+		},
+		//3
+		undeleteExecuteType{
+			bosPath: "bos:/0/a/",
+			dryrun:  true,
+			isSuc:   true,
+		},
 	}
 	for i, tCase := range testCases {
-		retCode, _ := testBosCli.copyDownload(tCase.srcPath, tCase.dstPath, tCase.downLoadTmp, tCase.recursive,
-			true, false)
-		util.ExpectEqual("bos.go down I", i+1, t.Errorf, tCase.isSuc,
-			retCode == BOSCLI_OK)
-		util.ExpectEqual("bos.go down II", i+1, t.Errorf, tCase.out,
-			testBosHandler.utilDownlaodArgVal)
+		args, code, err := testBosCli.undeletePreProcess(tCase.bosPath, 1, true)
+		if code != BOSCLI_OK {
+			t.Errorf("bos.go undelete exe case %d: %s %v", i+1, code, err)
+			continue
+		}
+		args.dryrun = tCase.dryrun
+		args.yes = tCase.yes
+		testBosHandler.undeleteArgVal = nil
+		ret, err := testBosCli.undeleteExecute(args)
+		util.ExpectEqual("bos.go undelete exe I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		util.ExpectEqual("bos.go undelete exe II", i+1, t.Errorf, tCase.recovered,
+			testBosHandler.undeleteArgVal)
+		util.ExpectEqual("bos.go undelete exe III", i+1, t.Errorf, tCase.successed,
+			ret.successed)
+		util.ExpectEqual("bos.go undelete exe IV", i+1, t.Errorf, tCase.failed, ret.failed)
+		util.ExpectEqual("bos.go undelete exe V", i+1, t.Errorf, tCase.skipped, ret.skipped)
+	}
+}
+
+type doesObjectExistType struct {
+	objectKey string
+	exist     bool
+	isSuc     bool
+}
+
+func TestDoesObjectExist(t *testing.T) {
+	testCases := []doesObjectExistType{
+		//1
+		doesObjectExistType{objectKey: "a/b", exist: true, isSuc: true},
+		//2
+		doesObjectExistType{objectKey: "404", exist: false, isSuc: true},
+		//3
+		doesObjectExistType{objectKey: "head-error", exist: false, isSuc: false},
+	}
+	for i, tCase := range testCases {
+		exist, err := testBosCli.doesObjectExist("bucket", tCase.objectKey)
+		util.ExpectEqual("bos.go doesObjectExist I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		util.ExpectEqual("bos.go doesObjectExist II", i+1, t.Errorf, tCase.exist, exist)
 	}
-	testBosCli.bosClient = tempClientBos
+}
+
+func TestTrashIsDisabled(t *testing.T) {
+	util.ExpectEqual("bos.go trashIsDisabled I", 1, t.Errorf, true,
+		testBosCli.trashIsDisabled("trash-disabled"))
+	util.ExpectEqual("bos.go trashIsDisabled II", 1, t.Errorf, false,
+		testBosCli.trashIsDisabled("success"))
+	// other errors are ignored
+	util.ExpectEqual("bos.go trashIsDisabled III", 1, t.Errorf, false,
+		testBosCli.trashIsDisabled("error"))
 }
 
 type copyUploadPreProcessType struct {
 	srcPath       string
 	dstPath       string
 	storageClass  string
+	concurrency   int
 	recursive     bool
 	dstBucketName string
 	dstObjectKey  string
@@ -2435,16 +4473,46 @@ func TestCopyUploadPreProcess(t *testing.T) {
 			isSuc:   false,
 		},
 		//12
+		copyUploadPreProcessType{
+			srcPath:       "-",
+			dstPath:       "bos:/bucekt/xx",
+			dstBucketName: "bucekt",
+			dstObjectKey:  "xx",
+			code:          BOSCLI_OK,
+			isSuc:         true,
+		},
+		//13
 		copyUploadPreProcessType{
 			srcPath: "-",
-			dstPath: "bos:/bucekt/xx",
-			code:    BOSCLI_UNSUPPORT_METHOD,
+			dstPath: "bos:/notExist/xx",
+			code:    BOSCLI_DST_BUCKET_DONT_EXIST,
 			isSuc:   false,
 		},
+		//14
+		copyUploadPreProcessType{
+			srcPath:       pathPrefix + "/",
+			dstPath:       "bos:/bucket/key/",
+			concurrency:   3,
+			recursive:     true,
+			dstBucketName: "bucket",
+			dstObjectKey:  "key/",
+			isDir:         true,
+			code:          BOSCLI_OK,
+			isSuc:         true,
+		},
+		//15
+		copyUploadPreProcessType{
+			srcPath:     pathPrefix + "/",
+			dstPath:     "bos:/bucket/key/",
+			concurrency: -1,
+			recursive:   true,
+			code:        BOSCLI_CONCURRENCY_LESS_ZERO,
+			isSuc:       false,
+		},
 	}
 	for i, tCase := range testCases {
 		args, code, err := testBosCli.copyUploadRequestPreProcess(tCase.srcPath, tCase.dstPath,
-			tCase.storageClass, tCase.recursive)
+			tCase.storageClass, tCase.concurrency, tCase.recursive)
 
 		util.ExpectEqual("bos.go up pre I", i+1, t.Errorf, tCase.isSuc, err == nil)
 		util.ExpectEqual("bos.go up pre II", i+1, t.Errorf, tCase.code, code)
@@ -2457,6 +4525,10 @@ func TestCopyUploadPreProcess(t *testing.T) {
 				args.srcIsDir)
 			util.ExpectEqual("bos.go up pre VI", i+1, t.Errorf, tCase.srcPath,
 				args.srcPath)
+			if tCase.concurrency > 0 {
+				util.ExpectEqual("bos.go up pre VII", i+1, t.Errorf, tCase.concurrency,
+					args.concurrency)
+			}
 		}
 	}
 }
@@ -2469,7 +4541,10 @@ type copyUploadExecuteType struct {
 	finlObjectKey    string
 	isDir            bool
 	uploadFromStream bool
+	concurrency      int
 	uploaded         int
+	move             bool
+	dryrun           bool
 	code             BosCliErrorCode
 	isSuc            bool
 }
@@ -2538,6 +4613,17 @@ func TestUploadFileExecute(t *testing.T) {
 			isSuc:         true,
 		},
 		//5
+		copyUploadExecuteType{
+			srcPath:       pathPrefix,
+			dstBucketName: "bucket",
+			dstObjectKey:  "",
+			concurrency:   5,
+			isDir:         true,
+			code:          BOSCLI_EMPTY_CODE,
+			uploaded:      16,
+			isSuc:         true,
+		},
+		//5
 		copyUploadExecuteType{
 			srcPath:       pathPrefix + "/aDir/234",
 			dstBucketName: "bucket",
@@ -2549,6 +4635,30 @@ func TestUploadFileExecute(t *testing.T) {
 			uploaded:      1,
 			isSuc:         true,
 		},
+		//8 move file
+		copyUploadExecuteType{
+			srcPath:       pathPrefix + "/aDir/234",
+			dstBucketName: "bucket",
+			dstObjectKey:  "test/",
+			finlObjectKey: "test/234",
+			isDir:         false,
+			code:          BOSCLI_EMPTY_CODE,
+			uploaded:      1,
+			move:          true,
+			isSuc:         true,
+		},
+		//9 move dryrun
+		copyUploadExecuteType{
+			srcPath:       pathPrefix,
+			dstBucketName: "bucket",
+			dstObjectKey:  "",
+			isDir:         true,
+			code:          BOSCLI_EMPTY_CODE,
+			uploaded:      0,
+			move:          true,
+			dryrun:        true,
+			isSuc:         true,
+		},
 	}
 
 	for i, tCase := range testCases {
@@ -2558,7 +4668,11 @@ func TestUploadFileExecute(t *testing.T) {
 			dstObjectKey:     tCase.dstObjectKey,
 			srcIsDir:         tCase.isDir,
 			uploadFromStream: tCase.uploadFromStream,
+			concurrency:      tCase.concurrency,
+			move:             tCase.move,
+			dryrun:           tCase.dryrun,
 		}
+		testBosHandler.deleteLocalFileArgVal = ""
 		ret, code, err := testBosCli.uploadFileExecute(args, tCase.srcPath, tCase.storageClass,
 			true)
 
@@ -2576,6 +4690,13 @@ func TestUploadFileExecute(t *testing.T) {
 				util.ExpectEqual("bos.go upexe III", i+1, t.Errorf, out,
 					testBosHandler.utilUploadFileArgVal)
 			}
+			// the source file is deleted only when it is moved
+			deleted := ""
+			if tCase.move && !tCase.dryrun {
+				deleted, _ = util.Abs(tCase.srcPath)
+			}
+			util.ExpectEqual("bos.go upexe IV", i+1, t.Errorf, deleted,
+				testBosHandler.deleteLocalFileArgVal)
 		}
 	}
 }
@@ -2588,6 +4709,7 @@ type copyUploadType struct {
 	storageClass   string
 	recursive      bool
 	isSuc          bool
+	meta           *objectMetaArgs
 }
 
 func TestCopyUpload(t *testing.T) {
@@ -2632,10 +4754,19 @@ func TestCopyUpload(t *testing.T) {
 			finalObjectKey: "key/234",
 			isSuc:          true,
 		},
+		copyUploadType{
+			srcPath:        pathPrefix + "/aDir/234",
+			dstPath:        "bos:/bucket/key",
+			dstBucketName:  "bucket",
+			finalObjectKey: "key",
+			isSuc:          true,
+			meta:           &objectMetaArgs{contentType: "text/plain"},
+		},
 	}
 	for i, tCase := range testCases {
-		retCode, _ := testBosCli.copyUpload(tCase.srcPath, tCase.dstPath, tCase.storageClass, tCase.recursive,
-			true)
+		testBosHandler.objectMetaArgVal = nil
+		retCode, _ := testBosCli.copyUpload(tCase.srcPath, tCase.dstPath, tCase.storageClass, 0,
+			tCase.recursive, true, false, false, false, tCase.meta)
 
 		util.ExpectEqual("bos.go copyUpload I", i+1, t.Errorf, tCase.isSuc,
 			retCode == BOSCLI_OK)
@@ -2649,6 +4780,8 @@ func TestCopyUpload(t *testing.T) {
 			out += "yes"
 			util.ExpectEqual("bos.go copyUpload II", i+1, t.Errorf, out,
 				testBosHandler.utilUploadFileArgVal)
+			util.ExpectEqual("bos.go copyUpload III", i+1, t.Errorf, tCase.meta,
+				testBosHandler.objectMetaArgVal)
 		}
 	}
 	testBosCli.bosClient = tempClientBos
@@ -2700,8 +4833,9 @@ func TestCopy(t *testing.T) {
 		},
 	}
 	for _, tCase := range testCases {
-		testBosCli.Copy(tCase.srcPath, tCase.dstPath, tCase.storageClass, tCase.downLoadTmp,
-			tCase.recursive, true, true, true, false)
+		testBosCli.Copy(tCase.srcPath, tCase.dstPath, tCase.storageClass, tCase.downLoadTmp, "",
+			ObjectMetaOptions{}, SseOptions{}, 0, tCase.recursive, true, true, true, false, false,
+			false, false)
 	}
 }
 
@@ -3406,8 +5540,9 @@ func TestSync(t *testing.T) {
 
 	for _, tCase := range testCases {
 		testBosCli.Sync(tCase.srcPath, tCase.dstPath, tCase.storageClass, tCase.downLoadTmp,
-			tCase.syncType, tCase.exclude, tCase.include, tCase.excludeTime, tCase.includeTime,
-			tCase.excludeDelete, tCase.concurrency, tCase.del, tCase.dryrun, tCase.yes, tCase.quiet,
-			tCase.disableBar, tCase.restart)
+			tCase.syncType, ObjectMetaOptions{}, SseOptions{}, tCase.exclude, tCase.include,
+			tCase.excludeTime, tCase.includeTime, tCase.excludeDelete, tCase.concurrency, tCase.del,
+			tCase.dryrun, tCase.yes, tCase.quiet, tCase.disableBar, tCase.restart, false, false,
+			false)
 	}
 }
@@ -19,19 +19,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	// 	"path/filepath"
-	// 	"strings"
+	"regexp"
+	"strings"
 )
 
 import (
 	// 	"bceconf"
 	"bcecmd/boscmd"
-	"utils/util"
-	// 	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/services/bos/api"
+	"utils/util"
 )
 
+// id of replication rule is composed of [0-9 A-Z a-z _ -]
+var replicationRuleIdRegexp = regexp.MustCompile(`^[0-9A-Za-z_-]+$`)
+
 // Create new BosApi
 func NewBosApi() *BosApi {
 	var (
@@ -53,6 +58,16 @@ type BosApi struct {
 	bosClient bosClientInterface
 }
 
+// the result of get-bucket-storage-class
+type bucketStorageClassOutput struct {
+	StorageClass string `json:"storageClass"`
+}
+
+// the result of get-bucket-encryption
+type bucketEncryptionOutput struct {
+	EncryptionAlgorithm string `json:"encryptionAlgorithm"`
+}
+
 type putBucketAclArgs struct {
 	bucketName string
 	acl        []byte
@@ -197,14 +212,138 @@ func (b *BosApi) getBucketAclExecute(bucketName string) error {
 	}
 
 	// print ACL
-	aclJosn, err := json.Marshal(ret)
+	return printApiResult(ret)
+}
+
+type putObjectAclArgs struct {
+	bucketName string
+	objectKey  string
+	acl        []byte
+	opType     int
+}
+
+// Put object ACL
+func (b *BosApi) PutObjectAcl(aclConfigPath, bosPath, objectKey, canned string) {
+
+	// preprocessing
+	// opType:
+	//    1 put acl from file
+	//    2 put acl from canned
+	args, err, retCode := b.putObjectAclPreProcess(aclConfigPath, bosPath, objectKey, canned)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+
+	//executing
+	err, retCode = b.putObjectAclExecute(args, canned)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+}
+
+// Put object ACL preprocessing
+func (b *BosApi) putObjectAclPreProcess(aclConfigPath, bosPath, objectKey,
+	canned string) (*putObjectAclArgs, error, BosCliErrorCode) {
+
+	bucketName, retCode := b.getObjectMetaPreProcess(bosPath, objectKey)
+	if retCode != BOSCLI_OK {
+		return nil, nil, retCode
+	}
+
+	if aclConfigPath != "" && canned != "" {
+		return nil, fmt.Errorf("Can't put acl from canned and file at the same time"),
+			BOSCLI_PUT_ACL_CANNED_FILE_SAME_TIME
+	}
+
+	// object only supports private and public-read
+	if canned != "" {
+		if canned != api.CANNED_ACL_PRIVATE && canned != api.CANNED_ACL_PUBLIC_READ {
+			return nil, fmt.Errorf("unsupported canned ACL of object"),
+				BOSCLI_PUT_OBJECT_ACL_CANNED_DONT_SUPPORT
+		}
+		return &putObjectAclArgs{bucketName: bucketName, objectKey: objectKey, opType: 2}, nil,
+			BOSCLI_OK
+	}
+
+	if aclConfigPath != "" {
+		if !util.DoesFileExist(aclConfigPath) {
+			return nil, nil, boscmd.LOCAL_FILE_NOT_EXIST
+		}
+		aclJson, err := ioutil.ReadFile(aclConfigPath)
+		if err != nil {
+			return nil, err, BOSCLI_EMPTY_CODE
+		}
+
+		// acl is valid?
+		rule := &api.PutObjectAclArgs{}
+		if err := json.NewDecoder(bytes.NewReader(aclJson)).Decode(rule); err != nil {
+			return nil, err, BOSCLI_EMPTY_CODE
+		}
+		return &putObjectAclArgs{bucketName: bucketName, objectKey: objectKey, acl: aclJson,
+			opType: 1}, nil, BOSCLI_OK
+	}
+
+	return nil, nil, BOSCLI_PUT_ACL_CANNED_FILE_BOTH_EMPTY
+}
+
+// Executing put object acl
+func (b *BosApi) putObjectAclExecute(args *putObjectAclArgs, canned string) (error,
+	BosCliErrorCode) {
+
+	// put canned ACL
+	if args.opType == 2 {
+		err := b.bosClient.PutObjectAclFromCanned(args.bucketName, args.objectKey, canned)
+		if err != nil {
+			return err, BOSCLI_EMPTY_CODE
+		}
+		return nil, BOSCLI_OK
+	}
+
+	// print acl from file.
+	var out bytes.Buffer
+	json.Indent(&out, args.acl, "", "  ")
+	out.WriteTo(os.Stdout)
+
+	err := b.bosClient.PutObjectAclFromString(args.bucketName, args.objectKey, string(args.acl))
+	if err != nil {
+		return err, BOSCLI_EMPTY_CODE
+	}
+	return nil, BOSCLI_OK
+}
+
+// Get object ACL
+// must have bucket_name and object_name
+func (b *BosApi) GetObjectAcl(bosPath, objectKey string) {
+	bucketName, retCode := b.getObjectMetaPreProcess(bosPath, objectKey)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	if err := b.getObjectAclExecute(bucketName, objectKey); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Get object ACL execute
+func (b *BosApi) getObjectAclExecute(bucketName, objectKey string) error {
+	ret, err := b.bosClient.GetObjectAcl(bucketName, objectKey)
 	if err != nil {
 		return err
 	}
-	var out bytes.Buffer
-	json.Indent(&out, aclJosn, "", "  ")
-	fmt.Println(out.String())
-	return nil
+	return printApiResult(ret)
+}
+
+// Delete object ACL, the object will use the ACL of its bucket
+// must have bucket_name and object_name
+func (b *BosApi) DeleteObjectAcl(bosPath, objectKey string) {
+	bucketName, retCode := b.getObjectMetaPreProcess(bosPath, objectKey)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	if err := b.bosClient.DeleteObjectAcl(bucketName, objectKey); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
 }
 
 type putLifecycleArgs struct {
@@ -369,15 +508,7 @@ func (b *BosApi) getLifecycleExecute(bucketName string) error {
 	}
 
 	// print lifecycle
-	lifecycleJosn, err := json.Marshal(ret)
-	if err != nil {
-		return err
-	}
-	var out bytes.Buffer
-	json.Indent(&out, lifecycleJosn, "", "  ")
-	fmt.Println(out.String())
-
-	return nil
+	return printApiResult(ret)
 }
 
 // Delete life cycle
@@ -519,15 +650,7 @@ func (b *BosApi) getLoggingExecute(bucketName string) error {
 	}
 
 	// print logging information
-	loggingJosn, err := json.Marshal(ret)
-	if err != nil {
-		return err
-	}
-	var out bytes.Buffer
-	json.Indent(&out, loggingJosn, "", "  ")
-	fmt.Println(out.String())
-
-	return nil
+	return printApiResult(ret)
 }
 
 // Delete logging
@@ -565,77 +688,178 @@ func (b *BosApi) deleteLoggingExecute(bucketName string) error {
 	return b.bosClient.DeleteBucketLogging(bucketName)
 }
 
-// Put storage class
-// must have bucket-name and storage-class
-func (b *BosApi) PutBucketStorageClass(bosPath, storageClass string) {
-	// check request
-	bucketName, retCode := b.putBucketStorageClassPreProcess(bosPath, storageClass)
+type putBucketCorsArgs struct {
+	bucketName string
+	cors       []byte
+}
+
+// Put bucket CORS
+// must have bucket_name
+func (b *BosApi) PutBucketCors(corsConfigPath, bosPath string, template bool) {
+
+	// preprocessing
+	args, err, retCode := b.putBucketCorsPreProcess(corsConfigPath, bosPath, template)
 	if retCode != BOSCLI_OK {
-		bcecliAbnormalExistCode(retCode)
+		bcecliAbnormalExistCodeErr(retCode, err)
 	}
 
-	// put storage class
-	if err := b.putBucketStorageClassExecute(bucketName, storageClass); err != nil {
-		bcecliAbnormalExistErr(err)
+	//executing
+	err, retCode = b.putBucketCorsExecute(args.cors, args.bucketName, template)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
 	}
 }
 
-// put storage class preprocessing
-func (b *BosApi) putBucketStorageClassPreProcess(bosPath, storageClass string) (string,
-	BosCliErrorCode) {
+// Put bucket CORS preprocessing
+func (b *BosApi) putBucketCorsPreProcess(corsConfigPath, bosPath string,
+	template bool) (*putBucketCorsArgs, error, BosCliErrorCode) {
+	// show template?
+	if template {
+		return &putBucketCorsArgs{}, nil, BOSCLI_OK
+	}
 
 	retCode, err := checkBosPath(bosPath)
 	if err != nil {
-		return "", retCode
+		return nil, nil, retCode
 	}
+
 	bucketName, objectKey := splitBosBucketKey(bosPath)
 	if bucketName == "" {
-		return "", BOSCLI_BUCKETNAME_IS_EMPTY
+		return nil, nil, BOSCLI_BUCKETNAME_IS_EMPTY
 	} else if objectKey != "" {
-		return "", BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME
+		return nil, nil, BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME
 	}
 
-	// check storage class is valid
-	if storageClass == "" {
-		return "", BOSCLI_STORAGE_CLASS_IS_EMPTY
+	if corsConfigPath == "" {
+		return nil, fmt.Errorf("you must specify cors_config_file and bucket_name"),
+			BOSCLI_PUT_CORS_NO_CONFIG_AND_BUCKET
 	}
-	if _, retCode := getStorageClassFromStr(storageClass); retCode != BOSCLI_OK {
-		return "", retCode
+
+	// config path exist?
+	if !util.DoesFileExist(corsConfigPath) {
+		return nil, nil, boscmd.LOCAL_FILE_NOT_EXIST
 	}
-	return bucketName, BOSCLI_OK
+
+	corsJson, err := ioutil.ReadFile(corsConfigPath)
+	if err != nil {
+		return nil, err, BOSCLI_EMPTY_CODE
+	}
+
+	// CORS config is valid?
+	if err := checkBucketCors(corsJson); err != nil {
+		return nil, err, BOSCLI_CORS_CONFIG_INVALID
+	}
+
+	return &putBucketCorsArgs{
+		bucketName: bucketName,
+		cors:       corsJson,
+	}, nil, BOSCLI_OK
 }
 
-// Put storage class execute
-func (b *BosApi) putBucketStorageClassExecute(bucketName, storageClass string) error {
-	return b.bosClient.PutBucketStorageclass(bucketName, storageClass)
+// check the CORS configuration before putting it to BOS.
+// Unknown fields are rejected, so that a misspelled key is not dropped silently.
+func checkBucketCors(corsJson []byte) error {
+	cors := &api.PutBucketCorsArgs{}
+	decoder := json.NewDecoder(bytes.NewReader(corsJson))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cors); err != nil {
+		return err
+	}
+
+	if len(cors.CorsConfiguration) == 0 {
+		return fmt.Errorf("corsConfiguration is empty")
+	} else if len(cors.CorsConfiguration) > BOS_CORS_MAX_RULE_NUM {
+		return fmt.Errorf("there are %d CORS rules, at most %d rules are allowed",
+			len(cors.CorsConfiguration), BOS_CORS_MAX_RULE_NUM)
+	}
+
+	for i, rule := range cors.CorsConfiguration {
+		if len(rule.AllowedOrigins) == 0 {
+			return fmt.Errorf("allowedOrigins of rule %d is empty", i+1)
+		}
+		for _, origin := range rule.AllowedOrigins {
+			if origin == "" || strings.Count(origin, "*") > 1 {
+				return fmt.Errorf("invalid origin '%s' in rule %d, an origin can contain at "+
+					"most one '*'", origin, i+1)
+			}
+		}
+		if len(rule.AllowedMethods) == 0 {
+			return fmt.Errorf("allowedMethods of rule %d is empty", i+1)
+		}
+		for _, method := range rule.AllowedMethods {
+			if !bosCorsMethods[method] {
+				return fmt.Errorf("unsupported method '%s' in rule %d", method, i+1)
+			}
+		}
+		if rule.MaxAgeSeconds < 0 {
+			return fmt.Errorf("maxAgeSeconds of rule %d is less than 0", i+1)
+		}
+	}
+	return nil
 }
 
-// Get storage class
-// must have bucket-name and storage-class
-func (b *BosApi) GetBucketStorageClass(bosPath string) {
+// Executing put bucket CORS
+func (b *BosApi) putBucketCorsExecute(corsJson []byte, bucketName string, template bool) (error,
+	BosCliErrorCode) {
+
+	if template {
+		// print template
+		corsTemplate := api.PutBucketCorsArgs{
+			CorsConfiguration: []api.BucketCORSType{
+				api.BucketCORSType{
+					AllowedOrigins:       []string{"http://www.example.com"},
+					AllowedMethods:       []string{"GET", "HEAD"},
+					AllowedHeaders:       []string{"*"},
+					AllowedExposeHeaders: []string{"ETag"},
+					MaxAgeSeconds:        3600,
+				},
+			},
+		}
+		corsJson, err := json.Marshal(corsTemplate)
+		if err != nil {
+			return err, BOSCLI_EMPTY_CODE
+		}
+		var out bytes.Buffer
+		json.Indent(&out, corsJson, "", "  ")
+		out.WriteTo(os.Stdout)
+	} else {
+		// print CORS config
+		var out bytes.Buffer
+		json.Indent(&out, corsJson, "", "  ")
+		out.WriteTo(os.Stdout)
+
+		// put CORS config
+		if err := b.bosClient.PutBucketCorsFromString(bucketName,
+			string(corsJson)); err != nil {
+			return err, BOSCLI_EMPTY_CODE
+		}
+	}
+	return nil, BOSCLI_OK
+}
+
+// Get bucket CORS
+// must have bucket_name
+func (b *BosApi) GetBucketCors(bosPath string) {
 	// check bucket name
-	bucketName, retCode := b.getBucketStorageClassPreProcess(bosPath)
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
 	if retCode != BOSCLI_OK {
 		bcecliAbnormalExistCode(retCode)
 	}
 
-	// get storage class
-	err := b.getBucketStorageClassExecute(bucketName)
-	if err != nil {
+	// get CORS config
+	if err := b.getBucketCorsExecute(bucketName); err != nil {
 		bcecliAbnormalExistErr(err)
 	}
 }
 
-func (b *BosApi) GetObjectMeta(bucketName string, objectName string) (*api.GetObjectMetaResult, error) {
-	return b.bosClient.GetObjectMeta(bucketName, objectName)
-}
-
-// get storage class preprocessing
-func (b *BosApi) getBucketStorageClassPreProcess(bosPath string) (string, BosCliErrorCode) {
+// get bucket name from bos path which must not contain object key, used by the bucket config
+// commands which only need bucket_name
+func (b *BosApi) getBucketNamePreProcess(bosPath string) (string, BosCliErrorCode) {
 	retCode, err := checkBosPath(bosPath)
 	if err != nil {
 		return "", retCode
 	}
+
 	bucketName, objectKey := splitBosBucketKey(bosPath)
 	if bucketName == "" {
 		return "", BOSCLI_BUCKETNAME_IS_EMPTY
@@ -645,15 +869,696 @@ func (b *BosApi) getBucketStorageClassPreProcess(bosPath string) (string, BosCli
 	return bucketName, BOSCLI_OK
 }
 
-func (b *BosApi) getBucketStorageClassExecute(bucketName string) error {
-	// get storage class
-	ret, err := b.bosClient.GetBucketStorageclass(bucketName)
+// Get bucket CORS execute
+func (b *BosApi) getBucketCorsExecute(bucketName string) error {
+	ret, err := b.bosClient.GetBucketCors(bucketName)
 	if err != nil {
 		return err
 	}
 
-	// print logging information
-	fmt.Printf("{\n    \"storageClass\": \"%s\"\n}\n", ret)
+	// print CORS config
+	return printApiResult(ret)
+}
 
-	return nil
+// Delete bucket CORS
+// must have bucket_name
+func (b *BosApi) DeleteBucketCors(bosPath string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// delete CORS config
+	if err := b.bosClient.DeleteBucketCors(bucketName); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+type putBucketReplicationArgs struct {
+	bucketName  string
+	replication []byte
+	conf        *api.PutBucketReplicationArgs
+}
+
+// Put bucket replication
+// must have bucket_name
+func (b *BosApi) PutBucketReplication(replicationConfigPath, bosPath string, template bool) {
+
+	// preprocessing
+	args, err, retCode := b.putBucketReplicationPreProcess(replicationConfigPath, bosPath,
+		template)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+
+	// destination must exist and be in another region
+	if !template {
+		err, retCode = b.checkReplicationDestination(args.bucketName, args.conf)
+		if retCode != BOSCLI_OK {
+			bcecliAbnormalExistCodeErr(retCode, err)
+		}
+	}
+
+	//executing
+	err, retCode = b.putBucketReplicationExecute(args, template)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+}
+
+// Put bucket replication preprocessing
+func (b *BosApi) putBucketReplicationPreProcess(replicationConfigPath, bosPath string,
+	template bool) (*putBucketReplicationArgs, error, BosCliErrorCode) {
+	// show template?
+	if template {
+		return &putBucketReplicationArgs{}, nil, BOSCLI_OK
+	}
+
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return nil, nil, retCode
+	}
+
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return nil, nil, BOSCLI_BUCKETNAME_IS_EMPTY
+	} else if objectKey != "" {
+		return nil, nil, BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME
+	}
+
+	if replicationConfigPath == "" {
+		return nil, fmt.Errorf("you must specify replication_config_file and bucket_name"),
+			BOSCLI_PUT_REPLICATION_NO_CONFIG
+	}
+
+	// config path exist?
+	if !util.DoesFileExist(replicationConfigPath) {
+		return nil, nil, boscmd.LOCAL_FILE_NOT_EXIST
+	}
+
+	replicationJson, err := ioutil.ReadFile(replicationConfigPath)
+	if err != nil {
+		return nil, err, BOSCLI_EMPTY_CODE
+	}
+
+	// replication config is valid?
+	conf, err := checkBucketReplication(bucketName, replicationJson)
+	if err != nil {
+		return nil, err, BOSCLI_REPLICATION_CONFIG_INVALID
+	}
+
+	return &putBucketReplicationArgs{
+		bucketName:  bucketName,
+		replication: replicationJson,
+		conf:        conf,
+	}, nil, BOSCLI_OK
+}
+
+// check the replication rule of bucket before putting it to BOS
+func checkBucketReplication(bucketName string, replicationJson []byte) (
+	*api.PutBucketReplicationArgs, error) {
+
+	conf := &api.PutBucketReplicationArgs{}
+	decoder := json.NewDecoder(bytes.NewReader(replicationJson))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(conf); err != nil {
+		return nil, err
+	}
+
+	if !replicationRuleIdRegexp.MatchString(conf.Id) {
+		return nil, fmt.Errorf("invalid id '%s', id must be composed of [0-9 A-Z a-z _ -]",
+			conf.Id)
+	}
+	if conf.Status != "enabled" && conf.Status != "disabled" {
+		return nil, fmt.Errorf("status must be enabled or disabled, not '%s'", conf.Status)
+	}
+	if conf.ReplicateDeletes != "enabled" && conf.ReplicateDeletes != "disabled" {
+		return nil, fmt.Errorf("replicateDeletes must be enabled or disabled, not '%s'",
+			conf.ReplicateDeletes)
+	}
+
+	if len(conf.Resource) == 0 {
+		return nil, fmt.Errorf("resource is empty")
+	}
+	for _, resource := range conf.Resource {
+		if !strings.HasPrefix(resource, bucketName+boscmd.BOS_PATH_SEPARATOR) {
+			return nil, fmt.Errorf("resource '%s' is not in bucket %s", resource, bucketName)
+		}
+	}
+
+	if conf.Destination == nil || conf.Destination.Bucket == "" {
+		return nil, fmt.Errorf("destination bucket is empty")
+	} else if conf.Destination.Bucket == bucketName {
+		return nil, fmt.Errorf("destination bucket can't be the source bucket")
+	}
+	if _, code := getStorageClassFromStr(conf.Destination.StorageClass); code != BOSCLI_OK {
+		return nil, fmt.Errorf("unsupported storage class '%s' of destination",
+			conf.Destination.StorageClass)
+	}
+	if conf.ReplicateHistory != nil {
+		if conf.ReplicateHistory.Bucket != conf.Destination.Bucket {
+			return nil, fmt.Errorf("bucket of replicateHistory must be the destination bucket")
+		}
+		if _, code := getStorageClassFromStr(conf.ReplicateHistory.StorageClass); code !=
+			BOSCLI_OK {
+			return nil, fmt.Errorf("unsupported storage class '%s' of replicateHistory",
+				conf.ReplicateHistory.StorageClass)
+		}
+	}
+	return conf, nil
+}
+
+// check whether the destination bucket exists, and it is in a region other than the source.
+// When destRegion is given in the rule, it must be the region of the destination bucket.
+func (b *BosApi) checkReplicationDestination(bucketName string,
+	conf *api.PutBucketReplicationArgs) (error, BosCliErrorCode) {
+
+	dstBucketName := conf.Destination.Bucket
+	dstRegion, err := b.bosClient.GetBucketLocation(dstBucketName)
+	if err != nil {
+		if serverErr, ok := err.(*bce.BceServiceError); ok &&
+			(serverErr.StatusCode == http.StatusNotFound ||
+				serverErr.Code == boscmd.CODE_NO_SUCH_BUCKET) {
+			return fmt.Errorf("destination bucket %s don't exist!", dstBucketName),
+				BOSCLI_DST_BUCKET_DONT_EXIST
+		}
+		return err, BOSCLI_EMPTY_CODE
+	}
+
+	srcRegion, err := b.bosClient.GetBucketLocation(bucketName)
+	if err != nil {
+		return err, BOSCLI_EMPTY_CODE
+	}
+	if srcRegion == dstRegion {
+		return fmt.Errorf("bucket %s and %s are both in region %s", bucketName, dstBucketName,
+			dstRegion), BOSCLI_REPLICATION_SAME_REGION
+	}
+	if conf.DestRegion != "" && conf.DestRegion != dstRegion {
+		return fmt.Errorf("destRegion is %s, but bucket %s is in region %s", conf.DestRegion,
+			dstBucketName, dstRegion), BOSCLI_REPLICATION_DEST_REGION_MISMATCH
+	}
+	return nil, BOSCLI_OK
+}
+
+// Executing put bucket replication
+func (b *BosApi) putBucketReplicationExecute(args *putBucketReplicationArgs, template bool) (
+	error, BosCliErrorCode) {
+
+	if template {
+		// print template
+		replicationTemplate := api.PutBucketReplicationArgs{
+			Id:       "sample-id",
+			Status:   "enabled",
+			Resource: []string{"${bucket_name}/${prefix}*"},
+			Destination: &api.BucketReplicationDescriptor{
+				Bucket:       "${dst_bucket_name}",
+				StorageClass: api.STORAGE_CLASS_STANDARD,
+			},
+			ReplicateHistory: &api.BucketReplicationDescriptor{
+				Bucket:       "${dst_bucket_name}",
+				StorageClass: api.STORAGE_CLASS_STANDARD,
+			},
+			ReplicateDeletes: "disabled",
+			DestRegion:       "${dst_region}",
+		}
+		replicationJson, err := json.Marshal(replicationTemplate)
+		if err != nil {
+			return err, BOSCLI_EMPTY_CODE
+		}
+		var out bytes.Buffer
+		json.Indent(&out, replicationJson, "", "  ")
+		out.WriteTo(os.Stdout)
+	} else {
+		// print replication config
+		var out bytes.Buffer
+		json.Indent(&out, args.replication, "", "  ")
+		out.WriteTo(os.Stdout)
+
+		// put replication config
+		if err := b.bosClient.PutBucketReplicationFromString(args.bucketName,
+			string(args.replication), args.conf.Id); err != nil {
+			return err, BOSCLI_EMPTY_CODE
+		}
+	}
+	return nil, BOSCLI_OK
+}
+
+// Get bucket replication
+// list all the rules of bucket when rule id is empty
+func (b *BosApi) GetBucketReplication(bosPath, ruleId string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// get replication config
+	if err := b.getBucketReplicationExecute(bucketName, ruleId); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Get bucket replication execute
+func (b *BosApi) getBucketReplicationExecute(bucketName, ruleId string) error {
+	var (
+		ret interface{}
+		err error
+	)
+	if ruleId == "" {
+		ret, err = b.bosClient.ListBucketReplication(bucketName)
+	} else {
+		ret, err = b.bosClient.GetBucketReplication(bucketName, ruleId)
+	}
+	if err != nil {
+		return err
+	}
+
+	// print replication config
+	return printApiResult(ret)
+}
+
+// Delete bucket replication
+// must have bucket_name
+func (b *BosApi) DeleteBucketReplication(bosPath, ruleId string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// delete replication config
+	if err := b.bosClient.DeleteBucketReplication(bucketName, ruleId); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Get progress of bucket replication
+// must have bucket_name
+func (b *BosApi) GetBucketReplicationProgress(bosPath, ruleId string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// get replication progress
+	if err := b.getBucketReplicationProgressExecute(bucketName, ruleId); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Get progress of bucket replication execute
+func (b *BosApi) getBucketReplicationProgressExecute(bucketName, ruleId string) error {
+	ret, err := b.bosClient.GetBucketReplicationProgress(bucketName, ruleId)
+	if err != nil {
+		return err
+	}
+
+	// print replication progress
+	return printApiResult(ret)
+}
+
+// Put bucket encryption
+// objects put to the bucket without encryption headers are encrypted by the algorithm
+func (b *BosApi) PutBucketEncryption(bosPath, algorithm string) {
+	// check request
+	bucketName, retCode := b.putBucketEncryptionPreProcess(bosPath, algorithm)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// put encryption
+	err := b.bosClient.PutBucketEncryption(bucketName, strings.ToUpper(algorithm))
+	if err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// put bucket encryption preprocessing
+func (b *BosApi) putBucketEncryptionPreProcess(bosPath, algorithm string) (string,
+	BosCliErrorCode) {
+
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		return "", retCode
+	}
+	if strings.ToUpper(algorithm) != api.ENCRYPTION_AES256 {
+		return "", BOSCLI_SSE_ALGORITHM_INVALID
+	}
+	return bucketName, BOSCLI_OK
+}
+
+// Get bucket encryption
+// must have bucket_name
+func (b *BosApi) GetBucketEncryption(bosPath string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// get encryption
+	if err := b.getBucketEncryptionExecute(bucketName); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Get bucket encryption execute
+func (b *BosApi) getBucketEncryptionExecute(bucketName string) error {
+	ret, err := b.bosClient.GetBucketEncryption(bucketName)
+	if err != nil {
+		return err
+	}
+
+	// print encryption algorithm
+	return printApiResult(&bucketEncryptionOutput{EncryptionAlgorithm: ret})
+}
+
+// Delete bucket encryption
+// must have bucket_name
+func (b *BosApi) DeleteBucketEncryption(bosPath string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// delete encryption
+	if err := b.bosClient.DeleteBucketEncryption(bucketName); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Put bucket static website
+// must have bucket_name, and at least one of the index and not found documents
+func (b *BosApi) PutBucketStaticWebsite(bosPath, index, notFound string) {
+	// check request
+	bucketName, retCode := b.putBucketStaticWebsitePreProcess(bosPath, index, notFound)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// put static website
+	args := &api.PutBucketStaticWebsiteArgs{
+		Index:    index,
+		NotFound: notFound,
+	}
+	if err := b.bosClient.PutBucketStaticWebsiteFromStruct(bucketName, args); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// put bucket static website preprocessing
+func (b *BosApi) putBucketStaticWebsitePreProcess(bosPath, index, notFound string) (string,
+	BosCliErrorCode) {
+
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		return "", retCode
+	}
+	if index == "" && notFound == "" {
+		return "", BOSCLI_PUT_WEBSITE_NO_DOCUMENT
+	}
+	return bucketName, BOSCLI_OK
+}
+
+// Get bucket static website
+// must have bucket_name
+func (b *BosApi) GetBucketStaticWebsite(bosPath string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// get static website
+	if err := b.getBucketStaticWebsiteExecute(bucketName); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Get bucket static website execute
+func (b *BosApi) getBucketStaticWebsiteExecute(bucketName string) error {
+	ret, err := b.bosClient.GetBucketStaticWebsite(bucketName)
+	if err != nil {
+		return err
+	}
+
+	// print static website config
+	return printApiResult(ret)
+}
+
+// Delete bucket static website
+// must have bucket_name
+func (b *BosApi) DeleteBucketStaticWebsite(bosPath string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// delete static website
+	if err := b.bosClient.DeleteBucketStaticWebsite(bucketName); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Put bucket trash
+// must have bucket_name and trash_dir
+func (b *BosApi) PutBucketTrash(bosPath, trashDir string) {
+	// check request
+	bucketName, trashDir, retCode := b.putBucketTrashPreProcess(bosPath, trashDir)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// put trash
+	trashReq := api.PutBucketTrashReq{
+		TrashDir: trashDir,
+	}
+	if err := b.bosClient.PutBucketTrash(bucketName, trashReq); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// put bucket trash preprocessing
+// return bucket name and trash directory without leading and trailing '/'
+func (b *BosApi) putBucketTrashPreProcess(bosPath, trashDir string) (string, string,
+	BosCliErrorCode) {
+
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		return "", "", retCode
+	}
+	trashDir = strings.Trim(trashDir, boscmd.BOS_PATH_SEPARATOR)
+	if trashDir == "" {
+		return "", "", BOSCLI_TRASH_DIR_IS_EMPTY
+	}
+	return bucketName, trashDir, BOSCLI_OK
+}
+
+// Get bucket trash
+// must have bucket_name
+func (b *BosApi) GetBucketTrash(bosPath string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// get trash
+	if err := b.getBucketTrashExecute(bucketName); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Get bucket trash execute
+func (b *BosApi) getBucketTrashExecute(bucketName string) error {
+	ret, err := b.bosClient.GetBucketTrash(bucketName)
+	if err != nil {
+		return err
+	}
+
+	// print trash config
+	return printApiResult(ret)
+}
+
+// Delete bucket trash
+// must have bucket_name
+func (b *BosApi) DeleteBucketTrash(bosPath string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketNamePreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// delete trash
+	if err := b.bosClient.DeleteBucketTrash(bucketName); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// Put storage class
+// must have bucket-name and storage-class
+func (b *BosApi) PutBucketStorageClass(bosPath, storageClass string) {
+	// check request
+	bucketName, retCode := b.putBucketStorageClassPreProcess(bosPath, storageClass)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// put storage class
+	if err := b.putBucketStorageClassExecute(bucketName, storageClass); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// put storage class preprocessing
+func (b *BosApi) putBucketStorageClassPreProcess(bosPath, storageClass string) (string,
+	BosCliErrorCode) {
+
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return "", retCode
+	}
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return "", BOSCLI_BUCKETNAME_IS_EMPTY
+	} else if objectKey != "" {
+		return "", BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME
+	}
+
+	// check storage class is valid
+	if storageClass == "" {
+		return "", BOSCLI_STORAGE_CLASS_IS_EMPTY
+	}
+	if _, retCode := getStorageClassFromStr(storageClass); retCode != BOSCLI_OK {
+		return "", retCode
+	}
+	return bucketName, BOSCLI_OK
+}
+
+// Put storage class execute
+func (b *BosApi) putBucketStorageClassExecute(bucketName, storageClass string) error {
+	return b.bosClient.PutBucketStorageclass(bucketName, storageClass)
+}
+
+// Get storage class
+// must have bucket-name and storage-class
+func (b *BosApi) GetBucketStorageClass(bosPath string) {
+	// check bucket name
+	bucketName, retCode := b.getBucketStorageClassPreProcess(bosPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// get storage class
+	err := b.getBucketStorageClassExecute(bucketName)
+	if err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// get storage class preprocessing
+func (b *BosApi) getBucketStorageClassPreProcess(bosPath string) (string, BosCliErrorCode) {
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return "", retCode
+	}
+	bucketName, objectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return "", BOSCLI_BUCKETNAME_IS_EMPTY
+	} else if objectKey != "" {
+		return "", BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME
+	}
+	return bucketName, BOSCLI_OK
+}
+
+func (b *BosApi) getBucketStorageClassExecute(bucketName string) error {
+	// get storage class
+	ret, err := b.bosClient.GetBucketStorageclass(bucketName)
+	if err != nil {
+		return err
+	}
+
+	// print storage class
+	return printApiResult(&bucketStorageClassOutput{StorageClass: ret})
+}
+
+// the result of get-object-meta, the keys are in lower camel case like other BOS API results
+type objectMetaOutput struct {
+	ContentLength      int64             `json:"contentLength"`
+	ContentType        string            `json:"contentType"`
+	ContentMD5         string            `json:"contentMD5"`
+	ContentCrc32       string            `json:"contentCrc32"`
+	ContentEncoding    string            `json:"contentEncoding"`
+	ContentDisposition string            `json:"contentDisposition"`
+	CacheControl       string            `json:"cacheControl"`
+	Expires            string            `json:"expires"`
+	LastModified       string            `json:"lastModified"`
+	ETag               string            `json:"eTag"`
+	StorageClass       string            `json:"storageClass"`
+	ObjectType         string            `json:"objectType"`
+	NextAppendOffset   string            `json:"nextAppendOffset"`
+	Restore            string            `json:"restore"`
+	UserMeta           map[string]string `json:"userMeta"`
+}
+
+// Get object meta
+// must have bucket_name and object_name
+func (b *BosApi) GetObjectMeta(bosPath, objectKey string) {
+	// check bucket name and object name
+	bucketName, retCode := b.getObjectMetaPreProcess(bosPath, objectKey)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCode(retCode)
+	}
+
+	// get object meta
+	if err := b.getObjectMetaExecute(bucketName, objectKey); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// get object meta preprocessing
+func (b *BosApi) getObjectMetaPreProcess(bosPath, objectKey string) (string, BosCliErrorCode) {
+	retCode, err := checkBosPath(bosPath)
+	if err != nil {
+		return "", retCode
+	}
+	bucketName, bucketObjectKey := splitBosBucketKey(bosPath)
+	if bucketName == "" {
+		return "", BOSCLI_BUCKETNAME_IS_EMPTY
+	} else if bucketObjectKey != "" {
+		return "", BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME
+	} else if objectKey == "" {
+		return "", BOSCLI_OBJECTKEY_IS_EMPTY
+	}
+	return bucketName, BOSCLI_OK
+}
+
+func (b *BosApi) getObjectMetaExecute(bucketName, objectKey string) error {
+	ret, err := b.bosClient.GetObjectMeta(bucketName, objectKey)
+	if err != nil {
+		return err
+	}
+
+	// print object meta
+	return printApiResult(&objectMetaOutput{
+		ContentLength:      ret.ContentLength,
+		ContentType:        ret.ContentType,
+		ContentMD5:         ret.ContentMD5,
+		ContentCrc32:       ret.ContentCrc32,
+		ContentEncoding:    ret.ContentEncoding,
+		ContentDisposition: ret.ContentDisposition,
+		CacheControl:       ret.CacheControl,
+		Expires:            ret.Expires,
+		LastModified:       ret.LastModified,
+		ETag:               ret.ETag,
+		StorageClass:       ret.StorageClass,
+		ObjectType:         ret.ObjectType,
+		NextAppendOffset:   ret.NextAppendOffset,
+		Restore:            ret.BceRestore,
+		UserMeta:           ret.UserMeta,
+	})
 }
@@ -28,7 +28,7 @@ import (
 	"bcecmd/boscmd"
 	// 	"bceconf"
 	// 	"github.com/baidubce/bce-sdk-go/services/bos"
-	// 	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/services/bos/api"
 	"utils/util"
 )
@@ -103,6 +103,166 @@ func (b *fakeBosClientForBos) DeleteBucketLogging(bucket string) error {
 	return fmt.Errorf(bucket)
 }
 
+// Fake of PutBucketCorsFromString
+func (b *fakeBosClientForBos) PutBucketCorsFromString(bucket, cors string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s%s", bucket, cors)
+}
+
+// Fake of GetBucketCors
+func (b *fakeBosClientForBos) GetBucketCors(bucket string) (*api.GetBucketCorsResult, error) {
+	if bucket == "success" {
+		return &api.GetBucketCorsResult{
+			CorsConfiguration: []api.BucketCORSType{
+				api.BucketCORSType{
+					AllowedOrigins: []string{"*"},
+					AllowedMethods: []string{"GET"},
+				},
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("%s", bucket)
+}
+
+// Fake of DeleteBucketCors
+func (b *fakeBosClientForBos) DeleteBucketCors(bucket string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s", bucket)
+}
+
+// Fake of PutBucketReplicationFromString
+func (b *fakeBosClientForBos) PutBucketReplicationFromString(bucket, conf, ruleId string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s%s", bucket, ruleId)
+}
+
+// Fake of GetBucketReplication
+func (b *fakeBosClientForBos) GetBucketReplication(bucket, ruleId string) (
+	*api.GetBucketReplicationResult, error) {
+	if bucket == "success" {
+		return &api.GetBucketReplicationResult{
+			Id:          ruleId,
+			Status:      "enabled",
+			Resource:    []string{"success/*"},
+			Destination: &api.BucketReplicationDescriptor{Bucket: "dst-gz"},
+		}, nil
+	}
+	return nil, fmt.Errorf("%s%s", bucket, ruleId)
+}
+
+// Fake of ListBucketReplication
+func (b *fakeBosClientForBos) ListBucketReplication(bucket string) (
+	*api.ListBucketReplicationResult, error) {
+	if bucket == "success" {
+		return &api.ListBucketReplicationResult{}, nil
+	}
+	return nil, fmt.Errorf("%s", bucket)
+}
+
+// Fake of DeleteBucketReplication
+func (b *fakeBosClientForBos) DeleteBucketReplication(bucket, ruleId string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s%s", bucket, ruleId)
+}
+
+// Fake of GetBucketReplicationProgress
+func (b *fakeBosClientForBos) GetBucketReplicationProgress(bucket, ruleId string) (
+	*api.GetBucketReplicationProgressResult, error) {
+	if bucket == "success" {
+		return &api.GetBucketReplicationProgressResult{
+			Status:                    "enabled",
+			HistoryReplicationPercent: 50,
+		}, nil
+	}
+	return nil, fmt.Errorf("%s%s", bucket, ruleId)
+}
+
+// Fake of PutBucketEncryption
+func (b *fakeBosClientForBos) PutBucketEncryption(bucket, algorithm string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s%s", bucket, algorithm)
+}
+
+// Fake of GetBucketEncryption
+func (b *fakeBosClientForBos) GetBucketEncryption(bucket string) (string, error) {
+	if bucket == "success" {
+		return api.ENCRYPTION_AES256, nil
+	}
+	return "", fmt.Errorf("%s", bucket)
+}
+
+// Fake of DeleteBucketEncryption
+func (b *fakeBosClientForBos) DeleteBucketEncryption(bucket string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s", bucket)
+}
+
+// Fake of PutBucketStaticWebsiteFromStruct
+func (b *fakeBosClientForBos) PutBucketStaticWebsiteFromStruct(bucket string,
+	args *api.PutBucketStaticWebsiteArgs) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s%s%s", bucket, args.Index, args.NotFound)
+}
+
+// Fake of GetBucketStaticWebsite
+func (b *fakeBosClientForBos) GetBucketStaticWebsite(bucket string) (
+	*api.GetBucketStaticWebsiteResult, error) {
+	if bucket == "success" {
+		return &api.GetBucketStaticWebsiteResult{Index: "index.html", NotFound: "404.html"}, nil
+	}
+	return nil, fmt.Errorf("%s", bucket)
+}
+
+// Fake of DeleteBucketStaticWebsite
+func (b *fakeBosClientForBos) DeleteBucketStaticWebsite(bucket string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s", bucket)
+}
+
+// Fake of PutBucketTrash
+func (b *fakeBosClientForBos) PutBucketTrash(bucket string, trashReq api.PutBucketTrashReq) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s%s", bucket, trashReq.TrashDir)
+}
+
+// Fake of GetBucketTrash
+func (b *fakeBosClientForBos) GetBucketTrash(bucket string) (*api.GetBucketTrashResult, error) {
+	if bucket == "success" {
+		return &api.GetBucketTrashResult{TrashDir: ".trash"}, nil
+	} else if bucket == "0" {
+		return &api.GetBucketTrashResult{TrashDir: "/a/"}, nil
+	} else if bucket == "trash-disabled" {
+		return nil, &bce.BceServiceError{StatusCode: 404}
+	}
+	return nil, fmt.Errorf("%s", bucket)
+}
+
+// Fake of DeleteBucketTrash
+func (b *fakeBosClientForBos) DeleteBucketTrash(bucket string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s", bucket)
+}
+
 // Fake of PutBucketStorageclass
 func (b *fakeBosClientForBos) PutBucketStorageclass(bucket, storageClass string) error {
 	if bucket == "success" {
@@ -148,6 +308,39 @@ func (b *fakeBosClientForBos) GetBucketAcl(bucket string) (*api.GetBucketAclResu
 	return nil, fmt.Errorf(bucket)
 }
 
+// Fake of PutObjectAclFromCanned
+func (b *fakeBosClientForBos) PutObjectAclFromCanned(bucket, object, cannedAcl string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s%s%s", bucket, object, cannedAcl)
+}
+
+// Fake of PutObjectAclFromString
+func (b *fakeBosClientForBos) PutObjectAclFromString(bucket, object, acl string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s%s%s", bucket, object, acl)
+}
+
+// Fake of GetObjectAcl
+func (b *fakeBosClientForBos) GetObjectAcl(bucket, object string) (*api.GetObjectAclResult,
+	error) {
+	if bucket == "success" {
+		return &api.GetObjectAclResult{AccessControlList: []api.GrantType{}}, nil
+	}
+	return nil, fmt.Errorf("%s%s", bucket, object)
+}
+
+// Fake of DeleteObjectAcl
+func (b *fakeBosClientForBos) DeleteObjectAcl(bucket, object string) error {
+	if bucket == "success" {
+		return nil
+	}
+	return fmt.Errorf("%s%s", bucket, object)
+}
+
 type putBucketAclPreProcessType struct {
 	configPath string
 	bosPath    string
@@ -435,6 +628,187 @@ func TestGetBucketAcl(t *testing.T) {
 	bosapi.GetBucketAcl("success")
 }
 
+type putObjectAclPreProcessType struct {
+	configPath string
+	bosPath    string
+	objectKey  string
+	canned     string
+	args       *putObjectAclArgs
+	code       BosCliErrorCode
+}
+
+func TestPutObjectAclPreProcess(t *testing.T) {
+	fd, fileName, err := util.CreateAnRandomFileWithContent("%s",
+		`{"accessControlList": [{"grantee": [{"id": "*"}],"permission": ["READ"]}]}`)
+	if err != nil {
+		t.Errorf("create acl test file failed! error: %v", err)
+		return
+	}
+	acl, err := ioutil.ReadAll(fd)
+	if err != nil {
+		t.Errorf("get acl from file failed! error: %v", err)
+		return
+	}
+	fd.Close()
+	defer os.Remove(fileName)
+
+	testCases := []putObjectAclPreProcessType{
+		// 1
+		putObjectAclPreProcessType{
+			bosPath:   "bos:/",
+			objectKey: "key",
+			code:      BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		// 2
+		putObjectAclPreProcessType{
+			bosPath: "bos:/bucket",
+			canned:  "private",
+			code:    BOSCLI_OBJECTKEY_IS_EMPTY,
+		},
+		// 3
+		putObjectAclPreProcessType{
+			bosPath:    "bos:/bucket",
+			objectKey:  "key",
+			configPath: fileName,
+			canned:     "private",
+			code:       BOSCLI_PUT_ACL_CANNED_FILE_SAME_TIME,
+		},
+		// 4 public-read-write is only for bucket
+		putObjectAclPreProcessType{
+			bosPath:   "bos:/bucket",
+			objectKey: "key",
+			canned:    "public-read-write",
+			code:      BOSCLI_PUT_OBJECT_ACL_CANNED_DONT_SUPPORT,
+		},
+		// 5
+		putObjectAclPreProcessType{
+			bosPath:   "bos:/bucket",
+			objectKey: "key",
+			canned:    "public-read",
+			args:      &putObjectAclArgs{bucketName: "bucket", objectKey: "key", opType: 2},
+			code:      BOSCLI_OK,
+		},
+		// 6
+		putObjectAclPreProcessType{
+			bosPath:    "bos:/bucket",
+			objectKey:  "key",
+			configPath: "./acltest",
+			code:       boscmd.LOCAL_FILE_NOT_EXIST,
+		},
+		// 7 not json
+		putObjectAclPreProcessType{
+			bosPath:    "bos:/bucket",
+			objectKey:  "key",
+			configPath: "./bosapi.go",
+			code:       BOSCLI_EMPTY_CODE,
+		},
+		// 8
+		putObjectAclPreProcessType{
+			bosPath:    "bos:/bucket",
+			objectKey:  "key",
+			configPath: fileName,
+			args: &putObjectAclArgs{bucketName: "bucket", objectKey: "key", acl: acl,
+				opType: 1},
+			code: BOSCLI_OK,
+		},
+		// 9
+		putObjectAclPreProcessType{
+			bosPath:   "bos:/bucket",
+			objectKey: "key",
+			code:      BOSCLI_PUT_ACL_CANNED_FILE_BOTH_EMPTY,
+		},
+	}
+	for i, tCase := range testCases {
+		args, _, code := bosapi.putObjectAclPreProcess(tCase.configPath, tCase.bosPath,
+			tCase.objectKey, tCase.canned)
+		util.ExpectEqual("bosapi.go putObjectAclPreProcess I", i+1, t.Errorf, tCase.code, code)
+		util.ExpectEqual("bosapi.go putObjectAclPreProcess II", i+1, t.Errorf, tCase.args, args)
+	}
+}
+
+type putObjectAclExecuteType struct {
+	args   *putObjectAclArgs
+	canned string
+	code   BosCliErrorCode
+	err    string
+}
+
+func TestPutObjectAclExecute(t *testing.T) {
+	acl := `{"accessControlList": [{"grantee": [{"id": "*"}],"permission": ["READ"]}]}`
+
+	testCases := []putObjectAclExecuteType{
+		// 1
+		putObjectAclExecuteType{
+			args: &putObjectAclArgs{bucketName: "success", objectKey: "key", acl: []byte(acl),
+				opType: 1},
+			code: BOSCLI_OK,
+		},
+		// 2
+		putObjectAclExecuteType{
+			args: &putObjectAclArgs{bucketName: "error", objectKey: "key", acl: []byte(acl),
+				opType: 1},
+			err:  "errorkey" + acl,
+			code: BOSCLI_EMPTY_CODE,
+		},
+		// 3
+		putObjectAclExecuteType{
+			args:   &putObjectAclArgs{bucketName: "success", objectKey: "key", opType: 2},
+			canned: "public-read",
+			code:   BOSCLI_OK,
+		},
+		// 4
+		putObjectAclExecuteType{
+			args:   &putObjectAclArgs{bucketName: "error", objectKey: "key", opType: 2},
+			canned: "public-read",
+			err:    "errorkeypublic-read",
+			code:   BOSCLI_EMPTY_CODE,
+		},
+	}
+	for i, tCase := range testCases {
+		err, code := bosapi.putObjectAclExecute(tCase.args, tCase.canned)
+		util.ExpectEqual("bosapi.go putObjectAclExecute I", i+1, t.Errorf, tCase.code, code)
+		if code != BOSCLI_OK {
+			util.ExpectEqual("bosapi.go putObjectAclExecute II", i+1, t.Errorf, tCase.err,
+				err.Error())
+		}
+	}
+}
+
+type getObjectAclExecuteType struct {
+	bucketName string
+	objectKey  string
+	err        string
+}
+
+func TestGetObjectAclExecute(t *testing.T) {
+	testCases := []getObjectAclExecuteType{
+		// 1
+		getObjectAclExecuteType{
+			bucketName: "success",
+			objectKey:  "key",
+		},
+		// 2
+		getObjectAclExecuteType{
+			bucketName: "error",
+			objectKey:  "key",
+			err:        "errorkey",
+		},
+	}
+	for i, tCase := range testCases {
+		err := bosapi.getObjectAclExecute(tCase.bucketName, tCase.objectKey)
+		util.ExpectEqual("bosapi.go getObjectAclExecute I", i+1, t.Errorf, tCase.err == "",
+			err == nil)
+		if err != nil {
+			util.ExpectEqual("bosapi.go getObjectAclExecute II", i+1, t.Errorf, tCase.err,
+				err.Error())
+		}
+	}
+}
+
+func TestDeleteObjectAcl(t *testing.T) {
+	bosapi.DeleteObjectAcl("bos:/success", "key")
+}
+
 var (
 	lifecycle = `
 		{
@@ -1093,52 +1467,767 @@ func TestDeleteLogging(t *testing.T) {
 	bosapi.DeleteLogging("success")
 }
 
-type putBucketStorageClassPreProcessType struct {
-	bosPath      string
-	storageClass string
-	bucketName   string
-	code         BosCliErrorCode
+const (
+	bucketCors = `{"corsConfiguration": [{"allowedOrigins": ["http://*.example.com"],` +
+		`"allowedMethods": ["GET", "PUT"], "maxAgeSeconds": 1800}]}`
+)
+
+type checkBucketCorsType struct {
+	cors string
+	err  string
+}
+
+func TestCheckBucketCors(t *testing.T) {
+	testCases := []checkBucketCorsType{
+		//1
+		checkBucketCorsType{cors: bucketCors},
+		//2 misspelled field
+		checkBucketCorsType{
+			cors: `{"corsConfiguration": [{"allowedOrigin": ["*"], "allowedMethods": ["GET"]}]}`,
+			err:  `json: unknown field "allowedOrigin"`,
+		},
+		//3
+		checkBucketCorsType{
+			cors: `{"corsConfiguration": []}`,
+			err:  "corsConfiguration is empty",
+		},
+		//4
+		checkBucketCorsType{
+			cors: `{"corsConfiguration": [{"allowedMethods": ["GET"]}]}`,
+			err:  "allowedOrigins of rule 1 is empty",
+		},
+		//5
+		checkBucketCorsType{
+			cors: `{"corsConfiguration": [{"allowedOrigins": ["http://*.*.com"],` +
+				`"allowedMethods": ["GET"]}]}`,
+			err: "invalid origin 'http://*.*.com' in rule 1, an origin can contain at most one '*'",
+		},
+		//6
+		checkBucketCorsType{
+			cors: `{"corsConfiguration": [{"allowedOrigins": ["*"]}]}`,
+			err:  "allowedMethods of rule 1 is empty",
+		},
+		//7
+		checkBucketCorsType{
+			cors: `{"corsConfiguration": [{"allowedOrigins": ["*"], "allowedMethods": ["GET"]},` +
+				`{"allowedOrigins": ["*"], "allowedMethods": ["get"]}]}`,
+			err: "unsupported method 'get' in rule 2",
+		},
+		//8
+		checkBucketCorsType{
+			cors: `{"corsConfiguration": [{"allowedOrigins": ["*"], "allowedMethods": ["GET"],` +
+				`"maxAgeSeconds": -1}]}`,
+			err: "maxAgeSeconds of rule 1 is less than 0",
+		},
+	}
+	for i, tCase := range testCases {
+		err := checkBucketCors([]byte(tCase.cors))
+		util.ExpectEqual("bosapi.go checkBucketCors I", i+1, t.Errorf, tCase.err == "", err == nil)
+		if err != nil {
+			util.ExpectEqual("bosapi.go checkBucketCors II", i+1, t.Errorf, tCase.err,
+				err.Error())
+		}
+	}
 }
 
-func TestPutBucketStorageClassPreProcess(t *testing.T) {
+type putBucketCorsPreProcessType struct {
+	configPath string
+	bosPath    string
+	template   bool
+	bucketName string
+	cors       []byte
+	code       BosCliErrorCode
+}
 
-	testCases := []putBucketStorageClassPreProcessType{
+func TestPutBucketCorsPreProcess(t *testing.T) {
+	fd, fileName, err := util.CreateAnRandomFileWithContent("%s", bucketCors)
+	if err != nil {
+		t.Errorf("create cors test file failed! error: %v", err)
+		return
+	}
+	fd.Close()
+	defer os.Remove(fileName)
+
+	testCases := []putBucketCorsPreProcessType{
 		// 1
-		putBucketStorageClassPreProcessType{
+		putBucketCorsPreProcessType{
 			bosPath: "/liup",
 			code:    BOSCLI_BOSPATH_IS_INVALID,
 		},
 		// 2
-		putBucketStorageClassPreProcessType{
+		putBucketCorsPreProcessType{
 			bosPath: "liup/object",
 			code:    BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME,
 		},
 		// 3
-		putBucketStorageClassPreProcessType{
-			bosPath: "bos://",
-			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
+		putBucketCorsPreProcessType{
+			bosPath: "bos:/bucket",
+			code:    BOSCLI_PUT_CORS_NO_CONFIG_AND_BUCKET,
 		},
 		// 4
-		putBucketStorageClassPreProcessType{
-			bosPath: "bos:/bucket",
-			code:    BOSCLI_STORAGE_CLASS_IS_EMPTY,
+		putBucketCorsPreProcessType{
+			bosPath:    "bos:/bucket",
+			configPath: "./corstest",
+			code:       boscmd.LOCAL_FILE_NOT_EXIST,
 		},
 		// 5
-		putBucketStorageClassPreProcessType{
-			bosPath:      "bos:/bucket",
-			storageClass: "xxx",
-			code:         BOSCLI_UNSUPPORT_STORAGE_CLASS,
+		putBucketCorsPreProcessType{
+			bosPath:    "bos:/bucket",
+			configPath: "./bosapi.go",
+			code:       BOSCLI_CORS_CONFIG_INVALID,
 		},
 		// 6
-		putBucketStorageClassPreProcessType{
-			bosPath:      "bos:/bucket",
-			storageClass: "COLD",
-			bucketName:   "bucket",
-			code:         BOSCLI_OK,
+		putBucketCorsPreProcessType{
+			bosPath:    "bos:/bucket",
+			configPath: fileName,
+			bucketName: "bucket",
+			cors:       []byte(bucketCors),
+			code:       BOSCLI_OK,
 		},
-	}
-
-	for i, tCase := range testCases {
+		// 7
+		putBucketCorsPreProcessType{
+			template: true,
+			code:     BOSCLI_OK,
+		},
+	}
+
+	for i, tCase := range testCases {
+		ret, _, code := bosapi.putBucketCorsPreProcess(tCase.configPath, tCase.bosPath,
+			tCase.template)
+		util.ExpectEqual("bosapi.go putBucketCorsPreProcess I", i+1, t.Errorf, tCase.code, code)
+		if code == BOSCLI_OK {
+			util.ExpectEqual("bosapi.go putBucketCorsPreProcess II", i+1, t.Errorf,
+				tCase.bucketName, ret.bucketName)
+			util.ExpectEqual("bosapi.go putBucketCorsPreProcess III", i+1, t.Errorf, tCase.cors,
+				ret.cors)
+		}
+	}
+}
+
+type putBucketCorsExecuteType struct {
+	corsJson   []byte
+	bucketName string
+	template   bool
+	code       BosCliErrorCode
+	err        string
+}
+
+func TestPutBucketCorsExecute(t *testing.T) {
+	testCases := []putBucketCorsExecuteType{
+		// 1
+		putBucketCorsExecuteType{
+			bucketName: "success",
+			corsJson:   []byte(bucketCors),
+			code:       BOSCLI_OK,
+		},
+		// 2
+		putBucketCorsExecuteType{
+			template: true,
+			code:     BOSCLI_OK,
+		},
+		// 3
+		putBucketCorsExecuteType{
+			bucketName: "error",
+			corsJson:   []byte(bucketCors),
+			err:        "error" + bucketCors,
+			code:       BOSCLI_EMPTY_CODE,
+		},
+	}
+
+	for i, tCase := range testCases {
+		err, code := bosapi.putBucketCorsExecute(tCase.corsJson, tCase.bucketName,
+			tCase.template)
+		util.ExpectEqual("bosapi.go putBucketCorsExecute I", i+1, t.Errorf, tCase.code, code)
+		if code != BOSCLI_OK {
+			util.ExpectEqual("bosapi.go putBucketCorsExecute II", i+1, t.Errorf, tCase.err,
+				err.Error())
+		}
+	}
+}
+
+type getBucketCorsExecuteType struct {
+	bucketName string
+	err        string
+}
+
+func TestGetBucketCorsExecute(t *testing.T) {
+	testCases := []getBucketCorsExecuteType{
+		// 1
+		getBucketCorsExecuteType{
+			bucketName: "success",
+		},
+		// 2
+		getBucketCorsExecuteType{
+			bucketName: "error",
+			err:        "error",
+		},
+	}
+	for i, tCase := range testCases {
+		err := bosapi.getBucketCorsExecute(tCase.bucketName)
+		util.ExpectEqual("bosapi.go getBucketCorsExecute I", i+1, t.Errorf, tCase.err == "",
+			err == nil)
+		if err != nil {
+			util.ExpectEqual("bosapi.go getBucketCorsExecute II", i+1, t.Errorf, tCase.err,
+				err.Error())
+		}
+	}
+}
+
+func TestDeleteBucketCors(t *testing.T) {
+	bosapi.DeleteBucketCors("bos:/success")
+}
+
+const (
+	bucketReplication = `{"id": "rule-1", "status": "enabled", "resource": ["bucket/*"],` +
+		`"destination": {"bucket": "dst-gz", "storageClass": "COLD"},` +
+		`"replicateDeletes": "disabled"}`
+)
+
+type checkBucketReplicationType struct {
+	replication string
+	err         string
+}
+
+func TestCheckBucketReplication(t *testing.T) {
+	testCases := []checkBucketReplicationType{
+		//1
+		checkBucketReplicationType{replication: bucketReplication},
+		//2
+		checkBucketReplicationType{
+			replication: `{"id": "rule-1", "status": "enabled", "resource": ["bucket/*"],` +
+				`"destination": {"bucket": "dst-gz"}, "replicateDeletes": "enabled",` +
+				`"replicateHistory": {"bucket": "dst-gz", "storageClass": "STANDARD_IA"}}`,
+		},
+		//3 misspelled field
+		checkBucketReplicationType{
+			replication: `{"id": "rule-1", "destnation": {"bucket": "dst-gz"}}`,
+			err:         `json: unknown field "destnation"`,
+		},
+		//4
+		checkBucketReplicationType{
+			replication: `{"id": "rule 1"}`,
+			err:         "invalid id 'rule 1', id must be composed of [0-9 A-Z a-z _ -]",
+		},
+		//5
+		checkBucketReplicationType{
+			replication: `{"id": "rule-1", "status": "on"}`,
+			err:         "status must be enabled or disabled, not 'on'",
+		},
+		//6
+		checkBucketReplicationType{
+			replication: `{"id": "rule-1", "status": "enabled", "replicateDeletes": "disabled",` +
+				`"resource": ["other/*"]}`,
+			err: "resource 'other/*' is not in bucket bucket",
+		},
+		//7
+		checkBucketReplicationType{
+			replication: `{"id": "rule-1", "status": "enabled", "replicateDeletes": "disabled",` +
+				`"resource": ["bucket/*"], "destination": {"bucket": "bucket"}}`,
+			err: "destination bucket can't be the source bucket",
+		},
+		//8
+		checkBucketReplicationType{
+			replication: `{"id": "rule-1", "status": "enabled", "replicateDeletes": "disabled",` +
+				`"resource": ["bucket/*"], "destination": {"bucket": "dst-gz",` +
+				`"storageClass": "HOT"}}`,
+			err: "unsupported storage class 'HOT' of destination",
+		},
+		//9
+		checkBucketReplicationType{
+			replication: `{"id": "rule-1", "status": "enabled", "replicateDeletes": "disabled",` +
+				`"resource": ["bucket/*"], "destination": {"bucket": "dst-gz"},` +
+				`"replicateHistory": {"bucket": "dst-bj"}}`,
+			err: "bucket of replicateHistory must be the destination bucket",
+		},
+	}
+	for i, tCase := range testCases {
+		_, err := checkBucketReplication("bucket", []byte(tCase.replication))
+		util.ExpectEqual("bosapi.go checkBucketReplication I", i+1, t.Errorf, tCase.err == "",
+			err == nil)
+		if err != nil {
+			util.ExpectEqual("bosapi.go checkBucketReplication II", i+1, t.Errorf, tCase.err,
+				err.Error())
+		}
+	}
+}
+
+type putBucketReplicationPreProcessType struct {
+	configPath string
+	bosPath    string
+	template   bool
+	bucketName string
+	ruleId     string
+	code       BosCliErrorCode
+}
+
+func TestPutBucketReplicationPreProcess(t *testing.T) {
+	fd, fileName, err := util.CreateAnRandomFileWithContent("%s", bucketReplication)
+	if err != nil {
+		t.Errorf("create replication test file failed! error: %v", err)
+		return
+	}
+	fd.Close()
+	defer os.Remove(fileName)
+
+	testCases := []putBucketReplicationPreProcessType{
+		// 1
+		putBucketReplicationPreProcessType{
+			bosPath: "bos://",
+			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		// 2
+		putBucketReplicationPreProcessType{
+			bosPath: "bos:/bucket",
+			code:    BOSCLI_PUT_REPLICATION_NO_CONFIG,
+		},
+		// 3
+		putBucketReplicationPreProcessType{
+			bosPath:    "bos:/bucket",
+			configPath: "./replicationtest",
+			code:       boscmd.LOCAL_FILE_NOT_EXIST,
+		},
+		// 4
+		putBucketReplicationPreProcessType{
+			bosPath:    "bos:/other",
+			configPath: fileName,
+			code:       BOSCLI_REPLICATION_CONFIG_INVALID,
+		},
+		// 5
+		putBucketReplicationPreProcessType{
+			bosPath:    "bos:/bucket",
+			configPath: fileName,
+			bucketName: "bucket",
+			ruleId:     "rule-1",
+			code:       BOSCLI_OK,
+		},
+		// 6
+		putBucketReplicationPreProcessType{
+			template: true,
+			code:     BOSCLI_OK,
+		},
+	}
+
+	for i, tCase := range testCases {
+		ret, _, code := bosapi.putBucketReplicationPreProcess(tCase.configPath, tCase.bosPath,
+			tCase.template)
+		util.ExpectEqual("bosapi.go putBucketReplicationPreProcess I", i+1, t.Errorf, tCase.code,
+			code)
+		if code == BOSCLI_OK && !tCase.template {
+			util.ExpectEqual("bosapi.go putBucketReplicationPreProcess II", i+1, t.Errorf,
+				tCase.bucketName, ret.bucketName)
+			util.ExpectEqual("bosapi.go putBucketReplicationPreProcess III", i+1, t.Errorf,
+				tCase.ruleId, ret.conf.Id)
+		}
+	}
+}
+
+type checkReplicationDestinationType struct {
+	bucketName string
+	conf       *api.PutBucketReplicationArgs
+	code       BosCliErrorCode
+}
+
+func TestCheckReplicationDestination(t *testing.T) {
+	testCases := []checkReplicationDestinationType{
+		// 1
+		checkReplicationDestinationType{
+			bucketName: "success",
+			conf: &api.PutBucketReplicationArgs{
+				Destination: &api.BucketReplicationDescriptor{Bucket: "dst-gz"},
+			},
+			code: BOSCLI_OK,
+		},
+		// 2
+		checkReplicationDestinationType{
+			bucketName: "success",
+			conf: &api.PutBucketReplicationArgs{
+				Destination: &api.BucketReplicationDescriptor{Bucket: "dst-gz"},
+				DestRegion:  "gz",
+			},
+			code: BOSCLI_OK,
+		},
+		// 3
+		checkReplicationDestinationType{
+			bucketName: "success",
+			conf: &api.PutBucketReplicationArgs{
+				Destination: &api.BucketReplicationDescriptor{Bucket: "no-such-bucket"},
+			},
+			code: BOSCLI_DST_BUCKET_DONT_EXIST,
+		},
+		// 4
+		checkReplicationDestinationType{
+			bucketName: "success",
+			conf: &api.PutBucketReplicationArgs{
+				Destination: &api.BucketReplicationDescriptor{Bucket: "dst-bj"},
+			},
+			code: BOSCLI_REPLICATION_SAME_REGION,
+		},
+		// 5
+		checkReplicationDestinationType{
+			bucketName: "success",
+			conf: &api.PutBucketReplicationArgs{
+				Destination: &api.BucketReplicationDescriptor{Bucket: "dst-gz"},
+				DestRegion:  "su",
+			},
+			code: BOSCLI_REPLICATION_DEST_REGION_MISMATCH,
+		},
+		// 6
+		checkReplicationDestinationType{
+			bucketName: "success",
+			conf: &api.PutBucketReplicationArgs{
+				Destination: &api.BucketReplicationDescriptor{Bucket: "error"},
+			},
+			code: BOSCLI_EMPTY_CODE,
+		},
+	}
+	for i, tCase := range testCases {
+		_, code := bosapi.checkReplicationDestination(tCase.bucketName, tCase.conf)
+		util.ExpectEqual("bosapi.go checkReplicationDestination I", i+1, t.Errorf, tCase.code,
+			code)
+	}
+}
+
+type putBucketReplicationExecuteType struct {
+	args     *putBucketReplicationArgs
+	template bool
+	code     BosCliErrorCode
+	err      string
+}
+
+func TestPutBucketReplicationExecute(t *testing.T) {
+	testCases := []putBucketReplicationExecuteType{
+		// 1
+		putBucketReplicationExecuteType{
+			args: &putBucketReplicationArgs{
+				bucketName:  "success",
+				replication: []byte(bucketReplication),
+				conf:        &api.PutBucketReplicationArgs{Id: "rule-1"},
+			},
+			code: BOSCLI_OK,
+		},
+		// 2
+		putBucketReplicationExecuteType{
+			args:     &putBucketReplicationArgs{},
+			template: true,
+			code:     BOSCLI_OK,
+		},
+		// 3
+		putBucketReplicationExecuteType{
+			args: &putBucketReplicationArgs{
+				bucketName:  "error",
+				replication: []byte(bucketReplication),
+				conf:        &api.PutBucketReplicationArgs{Id: "rule-1"},
+			},
+			err:  "errorrule-1",
+			code: BOSCLI_EMPTY_CODE,
+		},
+	}
+	for i, tCase := range testCases {
+		err, code := bosapi.putBucketReplicationExecute(tCase.args, tCase.template)
+		util.ExpectEqual("bosapi.go putBucketReplicationExecute I", i+1, t.Errorf, tCase.code,
+			code)
+		if code != BOSCLI_OK {
+			util.ExpectEqual("bosapi.go putBucketReplicationExecute II", i+1, t.Errorf,
+				tCase.err, err.Error())
+		}
+	}
+}
+
+type getBucketReplicationExecuteType struct {
+	bucketName string
+	ruleId     string
+	err        string
+}
+
+func TestGetBucketReplicationExecute(t *testing.T) {
+	testCases := []getBucketReplicationExecuteType{
+		// 1 list all rules
+		getBucketReplicationExecuteType{
+			bucketName: "success",
+		},
+		// 2
+		getBucketReplicationExecuteType{
+			bucketName: "success",
+			ruleId:     "rule-1",
+		},
+		// 3
+		getBucketReplicationExecuteType{
+			bucketName: "error",
+			err:        "error",
+		},
+		// 4
+		getBucketReplicationExecuteType{
+			bucketName: "error",
+			ruleId:     "rule-1",
+			err:        "errorrule-1",
+		},
+	}
+	for i, tCase := range testCases {
+		err := bosapi.getBucketReplicationExecute(tCase.bucketName, tCase.ruleId)
+		util.ExpectEqual("bosapi.go getBucketReplicationExecute I", i+1, t.Errorf,
+			tCase.err == "", err == nil)
+		if err != nil {
+			util.ExpectEqual("bosapi.go getBucketReplicationExecute II", i+1, t.Errorf,
+				tCase.err, err.Error())
+		}
+	}
+}
+
+func TestGetBucketReplicationProgressExecute(t *testing.T) {
+	err := bosapi.getBucketReplicationProgressExecute("success", "rule-1")
+	util.ExpectEqual("bosapi.go getBucketReplicationProgressExecute I", 1, t.Errorf, true,
+		err == nil)
+	err = bosapi.getBucketReplicationProgressExecute("error", "rule-1")
+	util.ExpectEqual("bosapi.go getBucketReplicationProgressExecute II", 1, t.Errorf,
+		"errorrule-1", err.Error())
+}
+
+func TestDeleteBucketReplication(t *testing.T) {
+	bosapi.DeleteBucketReplication("bos:/success", "rule-1")
+}
+
+type putBucketEncryptionPreProcessType struct {
+	bosPath    string
+	algorithm  string
+	bucketName string
+	code       BosCliErrorCode
+}
+
+func TestPutBucketEncryptionPreProcess(t *testing.T) {
+	testCases := []putBucketEncryptionPreProcessType{
+		// 1
+		putBucketEncryptionPreProcessType{
+			bosPath:   "bos:/bucket/object",
+			algorithm: "AES256",
+			code:      BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME,
+		},
+		// 2
+		putBucketEncryptionPreProcessType{
+			bosPath:   "bos:/bucket",
+			algorithm: "SM4",
+			code:      BOSCLI_SSE_ALGORITHM_INVALID,
+		},
+		// 3
+		putBucketEncryptionPreProcessType{
+			bosPath: "bos:/bucket",
+			code:    BOSCLI_SSE_ALGORITHM_INVALID,
+		},
+		// 4 algorithm is case insensitive
+		putBucketEncryptionPreProcessType{
+			bosPath:    "bos:/bucket",
+			algorithm:  "aes256",
+			bucketName: "bucket",
+			code:       BOSCLI_OK,
+		},
+	}
+
+	for i, tCase := range testCases {
+		bucketName, code := bosapi.putBucketEncryptionPreProcess(tCase.bosPath, tCase.algorithm)
+		util.ExpectEqual("bosapi.go putBucketEncryptionPreProcess I", i+1, t.Errorf, tCase.code,
+			code)
+		if code == BOSCLI_OK {
+			util.ExpectEqual("bosapi.go putBucketEncryptionPreProcess II", i+1, t.Errorf,
+				tCase.bucketName, bucketName)
+		}
+	}
+}
+
+func TestPutBucketEncryption(t *testing.T) {
+	bosapi.PutBucketEncryption("bos:/success", "AES256")
+}
+
+func TestGetBucketEncryptionExecute(t *testing.T) {
+	err := bosapi.getBucketEncryptionExecute("success")
+	util.ExpectEqual("bosapi.go getBucketEncryptionExecute I", 1, t.Errorf, true, err == nil)
+	err = bosapi.getBucketEncryptionExecute("error")
+	util.ExpectEqual("bosapi.go getBucketEncryptionExecute II", 1, t.Errorf, "error",
+		err.Error())
+}
+
+func TestDeleteBucketEncryption(t *testing.T) {
+	bosapi.DeleteBucketEncryption("bos:/success")
+}
+
+type putBucketStaticWebsitePreProcessType struct {
+	bosPath    string
+	index      string
+	notFound   string
+	bucketName string
+	code       BosCliErrorCode
+}
+
+func TestPutBucketStaticWebsitePreProcess(t *testing.T) {
+	testCases := []putBucketStaticWebsitePreProcessType{
+		// 1
+		putBucketStaticWebsitePreProcessType{
+			bosPath: "bos://",
+			index:   "index.html",
+			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		// 2
+		putBucketStaticWebsitePreProcessType{
+			bosPath: "bos:/bucket",
+			code:    BOSCLI_PUT_WEBSITE_NO_DOCUMENT,
+		},
+		// 3
+		putBucketStaticWebsitePreProcessType{
+			bosPath:    "bos:/bucket",
+			notFound:   "404.html",
+			bucketName: "bucket",
+			code:       BOSCLI_OK,
+		},
+		// 4
+		putBucketStaticWebsitePreProcessType{
+			bosPath:    "bos:/bucket",
+			index:      "index.html",
+			notFound:   "404.html",
+			bucketName: "bucket",
+			code:       BOSCLI_OK,
+		},
+	}
+
+	for i, tCase := range testCases {
+		bucketName, code := bosapi.putBucketStaticWebsitePreProcess(tCase.bosPath, tCase.index,
+			tCase.notFound)
+		util.ExpectEqual("bosapi.go putBucketStaticWebsitePreProcess I", i+1, t.Errorf,
+			tCase.code, code)
+		if code == BOSCLI_OK {
+			util.ExpectEqual("bosapi.go putBucketStaticWebsitePreProcess II", i+1, t.Errorf,
+				tCase.bucketName, bucketName)
+		}
+	}
+}
+
+func TestPutBucketStaticWebsite(t *testing.T) {
+	bosapi.PutBucketStaticWebsite("bos:/success", "index.html", "404.html")
+}
+
+func TestGetBucketStaticWebsiteExecute(t *testing.T) {
+	err := bosapi.getBucketStaticWebsiteExecute("success")
+	util.ExpectEqual("bosapi.go getBucketStaticWebsiteExecute I", 1, t.Errorf, true, err == nil)
+	err = bosapi.getBucketStaticWebsiteExecute("error")
+	util.ExpectEqual("bosapi.go getBucketStaticWebsiteExecute II", 1, t.Errorf, "error",
+		err.Error())
+}
+
+func TestDeleteBucketStaticWebsite(t *testing.T) {
+	bosapi.DeleteBucketStaticWebsite("bos:/success")
+}
+
+type putBucketTrashPreProcessType struct {
+	bosPath    string
+	trashDir   string
+	bucketName string
+	retDir     string
+	code       BosCliErrorCode
+}
+
+func TestPutBucketTrashPreProcess(t *testing.T) {
+	testCases := []putBucketTrashPreProcessType{
+		// 1
+		putBucketTrashPreProcessType{
+			bosPath:  "bos://",
+			trashDir: ".trash",
+			code:     BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		// 2
+		putBucketTrashPreProcessType{
+			bosPath:  "bos:/bucket",
+			trashDir: "/",
+			code:     BOSCLI_TRASH_DIR_IS_EMPTY,
+		},
+		// 3
+		putBucketTrashPreProcessType{
+			bosPath:    "bos:/bucket",
+			trashDir:   ".trash",
+			bucketName: "bucket",
+			retDir:     ".trash",
+			code:       BOSCLI_OK,
+		},
+		// 4
+		putBucketTrashPreProcessType{
+			bosPath:    "bucket",
+			trashDir:   "/recycle/bin/",
+			bucketName: "bucket",
+			retDir:     "recycle/bin",
+			code:       BOSCLI_OK,
+		},
+	}
+
+	for i, tCase := range testCases {
+		bucketName, trashDir, code := bosapi.putBucketTrashPreProcess(tCase.bosPath,
+			tCase.trashDir)
+		util.ExpectEqual("bosapi.go putBucketTrashPreProcess I", i+1, t.Errorf, tCase.code, code)
+		if code == BOSCLI_OK {
+			util.ExpectEqual("bosapi.go putBucketTrashPreProcess II", i+1, t.Errorf,
+				tCase.bucketName, bucketName)
+			util.ExpectEqual("bosapi.go putBucketTrashPreProcess III", i+1, t.Errorf,
+				tCase.retDir, trashDir)
+		}
+	}
+}
+
+func TestPutBucketTrash(t *testing.T) {
+	bosapi.PutBucketTrash("bos:/success", ".trash")
+}
+
+func TestGetBucketTrashExecute(t *testing.T) {
+	err := bosapi.getBucketTrashExecute("success")
+	util.ExpectEqual("bosapi.go getBucketTrashExecute I", 1, t.Errorf, true, err == nil)
+	err = bosapi.getBucketTrashExecute("error")
+	util.ExpectEqual("bosapi.go getBucketTrashExecute II", 1, t.Errorf, "error", err.Error())
+}
+
+func TestDeleteBucketTrash(t *testing.T) {
+	bosapi.DeleteBucketTrash("bos:/success")
+}
+
+type putBucketStorageClassPreProcessType struct {
+	bosPath      string
+	storageClass string
+	bucketName   string
+	code         BosCliErrorCode
+}
+
+func TestPutBucketStorageClassPreProcess(t *testing.T) {
+
+	testCases := []putBucketStorageClassPreProcessType{
+		// 1
+		putBucketStorageClassPreProcessType{
+			bosPath: "/liup",
+			code:    BOSCLI_BOSPATH_IS_INVALID,
+		},
+		// 2
+		putBucketStorageClassPreProcessType{
+			bosPath: "liup/object",
+			code:    BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME,
+		},
+		// 3
+		putBucketStorageClassPreProcessType{
+			bosPath: "bos://",
+			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		// 4
+		putBucketStorageClassPreProcessType{
+			bosPath: "bos:/bucket",
+			code:    BOSCLI_STORAGE_CLASS_IS_EMPTY,
+		},
+		// 5
+		putBucketStorageClassPreProcessType{
+			bosPath:      "bos:/bucket",
+			storageClass: "xxx",
+			code:         BOSCLI_UNSUPPORT_STORAGE_CLASS,
+		},
+		// 6
+		putBucketStorageClassPreProcessType{
+			bosPath:      "bos:/bucket",
+			storageClass: "COLD",
+			bucketName:   "bucket",
+			code:         BOSCLI_OK,
+		},
+	}
+
+	for i, tCase := range testCases {
 		bucketName, code := bosapi.putBucketStorageClassPreProcess(tCase.bosPath,
 			tCase.storageClass)
 		util.ExpectEqual("bosapi.go putBucketStorageClassPreProcess I", i+1, t.Errorf, tCase.code,
@@ -1284,3 +2373,101 @@ func TestGetBucketStorageClassExecute(t *testing.T) {
 func TestGetBucketStorageClass(t *testing.T) {
 	bosapi.GetBucketStorageClass("success")
 }
+
+type getObjectMetaPreProcessType struct {
+	bosPath    string
+	objectKey  string
+	bucketName string
+	code       BosCliErrorCode
+}
+
+func TestGetObjectMetaPreProcess(t *testing.T) {
+	testCases := []getObjectMetaPreProcessType{
+		// 1
+		getObjectMetaPreProcessType{
+			bosPath:   "/liup",
+			objectKey: "object",
+			code:      BOSCLI_BOSPATH_IS_INVALID,
+		},
+		// 2
+		getObjectMetaPreProcessType{
+			bosPath:   "liup/object",
+			objectKey: "object",
+			code:      BOSCLI_BUCKETNAME_CONTAIN_OBJECTNAME,
+		},
+		// 3
+		getObjectMetaPreProcessType{
+			bosPath:   "bos://",
+			objectKey: "object",
+			code:      BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		// 4
+		getObjectMetaPreProcessType{
+			bosPath: "bucket",
+			code:    BOSCLI_OBJECTKEY_IS_EMPTY,
+		},
+		// 5
+		getObjectMetaPreProcessType{
+			bosPath:    "bucket",
+			objectKey:  "object",
+			bucketName: "bucket",
+			code:       BOSCLI_OK,
+		},
+		// 6
+		getObjectMetaPreProcessType{
+			bosPath:    "bos:/bucket",
+			objectKey:  "dir/object",
+			bucketName: "bucket",
+			code:       BOSCLI_OK,
+		},
+	}
+	for i, tCase := range testCases {
+		ret, code := bosapi.getObjectMetaPreProcess(tCase.bosPath, tCase.objectKey)
+		util.ExpectEqual("bosapi.go getObjectMetaPreProcess I", i+1, t.Errorf, tCase.code, code)
+		if tCase.code == BOSCLI_OK {
+			util.ExpectEqual("bosapi.go getObjectMetaPreProcess II", i+1, t.Errorf,
+				tCase.bucketName, ret)
+		}
+	}
+}
+
+type getObjectMetaExecuteType struct {
+	objectKey  string
+	objectMeta *api.GetObjectMetaResult
+	isSuc      bool
+}
+
+func TestGetObjectMetaExecute(t *testing.T) {
+	testCases := []getObjectMetaExecuteType{
+		// 1
+		getObjectMetaExecuteType{
+			objectKey: "object",
+			objectMeta: &api.GetObjectMetaResult{
+				ObjectMeta: api.ObjectMeta{
+					ContentLength: 100,
+					StorageClass:  "STANDARD",
+					UserMeta:      map[string]string{"a": "b"},
+				},
+			},
+			isSuc: true,
+		},
+		// 2
+		getObjectMetaExecuteType{
+			objectKey: "404",
+			isSuc:     false,
+		},
+		// 3
+		getObjectMetaExecuteType{
+			objectKey: "object",
+			isSuc:     false,
+		},
+	}
+	for i, tCase := range testCases {
+		bosapiWithMeta := &BosApi{
+			bosClient: &fakeBosClientForBos{objectMeta: tCase.objectMeta},
+		}
+		err := bosapiWithMeta.getObjectMetaExecute("bucket", tCase.objectKey)
+		util.ExpectEqual("bosapi.go getObjectMetaExecute I", i+1, t.Errorf, tCase.isSuc,
+			err == nil)
+	}
+}
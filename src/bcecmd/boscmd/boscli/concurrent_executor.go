@@ -0,0 +1,92 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+// This module executes batch operations concurrently.
+
+package boscli
+
+import (
+	"fmt"
+	"sync"
+)
+
+// returned by an operation which is skipped, it is counted as neither a success nor a failure
+var errOperationSkipped = fmt.Errorf("operation is skipped")
+
+// concurrentExecutor run operations in a bounded pool of goroutines, and count the number of
+// successful, failed and skipped operations.
+type concurrentExecutor struct {
+	opPool     chan int
+	resultChan chan int
+	retChan    chan executeResult
+	opSync     sync.WaitGroup
+}
+
+func newConcurrentExecutor(concurrency int) *concurrentExecutor {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	c := &concurrentExecutor{
+		opPool:     make(chan int, concurrency),
+		resultChan: make(chan int, concurrency),
+		retChan:    make(chan executeResult, 1),
+	}
+	go c.count()
+	return c
+}
+
+// count the failed, successed and skipped number of operations, until resultChan is closed
+func (c *concurrentExecutor) count() {
+	ret := executeResult{}
+	for result := range c.resultChan {
+		if result == -1 { // fail
+			ret.failed += 1
+		} else if result == 1 { // success
+			ret.successed += 1
+		} else if result == 0 { // skip
+			ret.skipped += 1
+		}
+	}
+	c.retChan <- ret
+}
+
+// execute an operation in a new goroutine, block until there is a free worker.
+func (c *concurrentExecutor) execute(op func() error) {
+	c.opPool <- 1
+	c.opSync.Add(1)
+	go func() {
+		defer func() {
+			c.opSync.Done()
+			<-c.opPool
+		}()
+		if err := op(); err == errOperationSkipped {
+			c.resultChan <- 0 // 0 represent skipped
+		} else if err != nil {
+			c.resultChan <- -1 // -1 represent failed
+		} else {
+			c.resultChan <- 1 // 1 represent successed
+		}
+	}()
+}
+
+// record an operation failed before it is executed
+func (c *concurrentExecutor) fail() {
+	c.resultChan <- -1
+}
+
+// wait for all operations finish and return the result, executor can't be used any more.
+func (c *concurrentExecutor) wait() *executeResult {
+	c.opSync.Wait()
+	close(c.resultChan)
+	ret := <-c.retChan
+	return &ret
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+package boscli
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+import (
+	"utils/util"
+)
+
+type concurrentExecutorType struct {
+	concurrency int
+	successed   int
+	failed      int
+	preFailed   int
+	skipped     int
+}
+
+func TestConcurrentExecutor(t *testing.T) {
+	testCases := []concurrentExecutorType{
+		concurrentExecutorType{
+			concurrency: 0,
+		},
+		concurrentExecutorType{
+			concurrency: 1,
+			successed:   10,
+			failed:      3,
+		},
+		concurrentExecutorType{
+			concurrency: 4,
+			successed:   100,
+			failed:      20,
+			preFailed:   5,
+			skipped:     7,
+		},
+	}
+	for i, tCase := range testCases {
+		var (
+			running    int
+			maxRunning int
+			lock       sync.Mutex
+		)
+		op := func(err error) func() error {
+			return func() error {
+				lock.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				lock.Unlock()
+				lock.Lock()
+				running--
+				lock.Unlock()
+				return err
+			}
+		}
+		executor := newConcurrentExecutor(tCase.concurrency)
+		for j := 0; j < tCase.successed; j++ {
+			executor.execute(op(nil))
+		}
+		for j := 0; j < tCase.failed; j++ {
+			executor.execute(op(fmt.Errorf("error")))
+		}
+		for j := 0; j < tCase.preFailed; j++ {
+			executor.fail()
+		}
+		for j := 0; j < tCase.skipped; j++ {
+			executor.execute(op(errOperationSkipped))
+		}
+		ret := executor.wait()
+		util.ExpectEqual("concurrent_executor.go I", i+1, t.Errorf, tCase.successed,
+			ret.successed)
+		util.ExpectEqual("concurrent_executor.go II", i+1, t.Errorf, tCase.failed+tCase.preFailed,
+			ret.failed)
+		util.ExpectEqual("concurrent_executor.go III", i+1, t.Errorf, true,
+			maxRunning <= tCase.concurrency || maxRunning <= 1)
+		util.ExpectEqual("concurrent_executor.go IV", i+1, t.Errorf, tCase.skipped, ret.skipped)
+	}
+}
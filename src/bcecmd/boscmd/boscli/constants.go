@@ -31,6 +31,9 @@ const (
 	SIGNED_URL_EXPIRE_TIME      = 1800
 	GAP_GET_OBJECT_INFO_AGAIN   = 60 //60s
 	MAX_PARTS                   = 10000
+	MAX_LIST_PAGE_SIZE          = 1000
+	LIST_SYMLINK_BATCH_SIZE     = 100     // objects printed together by ls --symlink
+	LIST_SYMLINK_CONCURRENCY    = 10      // objects headed concurrently by ls --symlink
 	MAX_STREAM_UPLOAD_SIZE      = 5 << 30 // 5G
 	STREAM_DOWNLOAD_BUF_SIZE    = 2 << 20
 	SYNC_COMPARATOR_TIME_OUT    = 36000 * 1000 // 10 hours
@@ -56,8 +59,16 @@ const (
 	SYNC_OP_UPLOAD   = "Upload"
 	SYNC_OP_DELETE   = "Delete" // delete local file
 	SYNC_OP_REMOVE   = "Remove" // delete bos object
+	SYNC_OP_MOVE     = "Move"   // copy then delete the source, used by mv
+	SYNC_OP_RESTORE  = "Restore"
+	SYNC_OP_UNDELETE = "Undelete" // copy object in trash back to its original key
+	SYNC_OP_LINK     = "Link"     // create symlink object
+	SYNC_OP_APPEND   = "Append"   // append data to appendable object
+	SYNC_OP_FETCH    = "Fetch"    // get object from url
 	SYNC_OP_ERROR    = "Error"
 
+	SYNC_OP_SET_STORAGE_CLASS = "SetStorageClass"
+
 	IS_BOS         = "bos"
 	IS_LOCAL       = "local"
 	BOS_TO_BOS     = "bosbos"
@@ -65,3 +76,74 @@ const (
 	LOCAL_TO_LOCAL = "locallocal"
 	LOCAL_TO_BOS   = "localbos"
 )
+
+// sort types of du
+const (
+	DU_SORT_BY_NAME  = "name"
+	DU_SORT_BY_SIZE  = "size"
+	DU_SORT_BY_COUNT = "count"
+)
+
+// metadata of objects
+const (
+	USER_META_HEADER_PREFIX = "x-bce-meta-"
+
+	OBJECT_TYPE_NORMAL     = "Normal"
+	OBJECT_TYPE_SYMLINK    = "Symlink"
+	OBJECT_TYPE_MULTIPART  = "Multipart"
+	OBJECT_TYPE_APPENDABLE = "Appendable"
+)
+
+// restore of archived objects
+const (
+	DEFAULT_RESTORE_DAYS = 7
+	MAX_RESTORE_DAYS     = 30
+
+	// status parsed from the header x-bce-restore
+	RESTORE_STATUS_NONE     = "none"
+	RESTORE_STATUS_ONGOING  = "ongoing"
+	RESTORE_STATUS_RESTORED = "restored"
+)
+
+// trash of buckets
+const (
+	// the trash directory BOS uses when it isn't specified
+	DEFAULT_TRASH_DIR = ".trash"
+)
+
+// fetch objects from url, besides the sync and async fetch of BOS, data can be transferred by
+// bcecmd when BOS can't reach the url
+const (
+	FETCH_MODE_VIA_LOCAL = "local"
+
+	// seconds to wait for the url when fetching via local, so that a stalled source fails
+	// the fetch instead of hanging it with an open multipart upload
+	FETCH_CONNECT_TIMEOUT = 30 // connecting to the server
+	FETCH_READ_TIMEOUT    = 60 // each read of response, including waiting for its header
+)
+
+// CORS configuration of buckets
+const (
+	BOS_CORS_MAX_RULE_NUM = 100
+)
+
+// headers of server-side encryption of objects
+const (
+	SSE_HEADER                 = "x-bce-server-side-encryption"
+	SSE_CUSTOMER_ALGORITHM     = "x-bce-server-side-encryption-customer-algorithm"
+	SSE_CUSTOMER_KEY           = "x-bce-server-side-encryption-customer-key"
+	SSE_CUSTOMER_KEY_MD5       = "x-bce-server-side-encryption-customer-key-md5"
+	SSE_COPY_SOURCE_ALGORITHM  = "x-bce-copy-source-server-side-encryption-customer-algorithm"
+	SSE_COPY_SOURCE_KEY        = "x-bce-copy-source-server-side-encryption-customer-key"
+	SSE_COPY_SOURCE_KEY_MD5    = "x-bce-copy-source-server-side-encryption-customer-key-md5"
+	SSE_CUSTOMER_KEY_LEN_BYTES = 32 // customer-provided keys are 256 bits
+)
+
+// methods which can be allowed by a CORS rule
+var bosCorsMethods = map[string]bool{
+	"GET":    true,
+	"PUT":    true,
+	"POST":   true,
+	"DELETE": true,
+	"HEAD":   true,
+}
@@ -57,23 +57,76 @@ const (
 	BOSCLI_UPLOAD_SRC_CANNT_BE_DIR            = "boscliUploadSrcCanntBeDir"
 	BOSCLI_DST_OBJECT_KEY_IS_EMPTY            = "boscliDstObjectKeyIsEmpty"
 	BOSCLI_UPLOAD_STREAM_TO_DIR               = "boscliUploadStreamToDir"
+	BOSCLI_DOWNLOAD_DIR_TO_STREAM             = "boscliDownloadDirToStream"
 	BOSCLI_RM_DIR_MUST_USE_RECURSIVE          = "boscliRmDirMustUseRecursive"
 	BOSCLI_EXPIRE_LESS_NONE                   = "boscliExpireLessNegativeOne"
+	BOSCLI_LIST_PAGE_SIZE_INVALID             = "boscliListPageSizeInvalid"
+	BOSCLI_LIST_MAX_ITEMS_LESS_ZERO           = "boscliListMaxItemsLessZero"
+	BOSCLI_LIST_BUCKETS_NOT_SUPPORT_PAGING    = "boscliListBucketsNotSupportPaging"
+	BOSCLI_DU_DEPTH_LESS_ZERO                 = "boscliDuDepthLessZero"
+	BOSCLI_DU_SORT_TYPE_INVALID               = "boscliDuSortTypeInvalid"
+	BOSCLI_MOVE_NOT_SUPPORT_STREAM            = "boscliMoveNotSupportStream"
+	BOSCLI_MOVE_DST_IN_SRC                    = "boscliMoveDstInSrc"
+	BOSCLI_MOVE_TO_ITSELF                     = "boscliMoveToItself"
+	BOSCLI_CAT_OBJECT_IS_DIR                  = "boscliCatObjectIsDir"
+	BOSCLI_CAT_RANGE_TAIL_TOG                 = "boscliCatRangeTailTog"
+	BOSCLI_CAT_TAIL_LESS_ZERO                 = "boscliCatTailLessZero"
+	BOSCLI_CAT_DECOMPRESS_WITH_RANGE          = "boscliCatDecompressWithRange"
+	BOSCLI_RANGE_INVALID                      = "boscliRangeInvalid"
+	BOSCLI_RANGE_ONLY_FOR_DOWNLOAD            = "boscliRangeOnlyForDownload"
+	BOSCLI_FIND_SIZE_INVALID                  = "boscliFindSizeInvalid"
+	BOSCLI_FIND_MTIME_INVALID                 = "boscliFindMtimeInvalid"
+	BOSCLI_FIND_EXEC_DELETE_TOG               = "boscliFindExecDeleteTog"
+	BOSCLI_FIND_EXEC_INVALID                  = "boscliFindExecInvalid"
+	BOSCLI_RESTORE_DIR_MUST_USE_RECURSIVE     = "boscliRestoreDirMustUseRecursive"
+	BOSCLI_RESTORE_DAYS_INVALID               = "boscliRestoreDaysInvalid"
+	BOSCLI_RESTORE_TIER_INVALID               = "boscliRestoreTierInvalid"
+	BOSCLI_SET_STORAGE_CLASS_MUST_RECURSIVE   = "boscliSetStorageClassMustRecursive"
+	BOSCLI_UNDELETE_DIR_MUST_USE_RECURSIVE    = "boscliUndeleteDirMustUseRecursive"
+	BOSCLI_UNDELETE_NOT_IN_TRASH              = "boscliUndeleteNotInTrash"
+	BOSCLI_OLDER_THAN_INVALID                 = "boscliOlderThanInvalid"
+	BOSCLI_METADATA_DIRECTIVE_INVALID         = "boscliMetadataDirectiveInvalid"
+	BOSCLI_METADATA_DIRECTIVE_ONLY_FOR_COPY   = "boscliMetadataDirectiveOnlyForCopy"
+	BOSCLI_USER_META_INVALID                  = "boscliUserMetaInvalid"
+	BOSCLI_SSE_ALGORITHM_INVALID              = "boscliSseAlgorithmInvalid"
+	BOSCLI_SSE_CUSTOMER_KEY_INVALID           = "boscliSseCustomerKeyInvalid"
+	BOSCLI_SSE_ARGS_CONFLICT                  = "boscliSseArgsConflict"
+	BOSCLI_LN_NOT_SAME_BUCKET                 = "boscliLnNotSameBucket"
+	BOSCLI_LN_TARGET_IS_DIR                   = "boscliLnTargetIsDir"
+	BOSCLI_LN_LINK_TO_ITSELF                  = "boscliLnLinkToItself"
+	BOSCLI_PRESERVE_SYMLINKS_ONLY_FOR_UPLOAD  = "boscliPreserveSymlinksOnlyForUpload"
+	BOSCLI_APPEND_DST_IS_DIR                  = "boscliAppendDstIsDir"
+	BOSCLI_APPEND_SRC_IS_DIR                  = "boscliAppendSrcIsDir"
+	BOSCLI_APPEND_OBJECT_NOT_APPENDABLE       = "boscliAppendObjectNotAppendable"
+	BOSCLI_APPEND_OFFSET_MISMATCH             = "boscliAppendOffsetMismatch"
+	BOSCLI_FETCH_URL_INVALID                  = "boscliFetchUrlInvalid"
+	BOSCLI_FETCH_OBJECT_NAME_IS_EMPTY         = "boscliFetchObjectNameIsEmpty"
+	BOSCLI_FETCH_ASYNC_VIA_LOCAL_CONFLICT     = "boscliFetchAsyncViaLocalConflict"
 	BOSCLI_SYNC_EXCLUDE_INCLUDE_TIME_TOG      = "boscliSyncExcludeIncludeTimeTog"
 	BOSCLI_SYNC_EXCLUDE_INCLUDE_TOG           = "boscliSyncEcludeIncludeTog"
 	BOSCLI_SYNC_UPLOAD_SRC_MUST_DIR           = "boscliSyncUploadSrcMustDir"
 	BOSCLI_SYNC_DOWN_DST_MUST_DIR             = "boscliSyncDownDstMustDir"
 	BOSCLI_SYNC_LOCAL_TO_LOCAL                = "boscliSyncLocalToLocal"
 	BOSCLI_SYNC_PROCESS_NUM_LESS_ZERO         = "boscliSyncProcessNumLessZero"
+	BOSCLI_CONCURRENCY_LESS_ZERO              = "boscliConcurrencyLessZero"
 	BOSCLI_INVALID_SYNY_TYPE                  = "boscliInvalidSyncType"
 	BOSCLI_GET_SYNC_PROCESSING_NUM_FAILED     = "boscliGetUploadProcessingNumFailed"
 	BOSCLI_GET_UPLOAD_THREAD_NUM_FAILED       = "boscliGetUplaodThreadNumFailed"
 	BOSCLI_PUT_LIFECYCLE_NO_CONFIG_AND_BUCKET = "boscliPutLifecycleNoConfigAndBucket"
+	BOSCLI_PUT_CORS_NO_CONFIG_AND_BUCKET      = "boscliPutCorsNoConfigAndBucket"
+	BOSCLI_CORS_CONFIG_INVALID                = "boscliCorsConfigInvalid"
+	BOSCLI_PUT_REPLICATION_NO_CONFIG          = "boscliPutReplicationNoConfig"
+	BOSCLI_REPLICATION_CONFIG_INVALID         = "boscliReplicationConfigInvalid"
+	BOSCLI_REPLICATION_SAME_REGION            = "boscliReplicationSameRegion"
+	BOSCLI_REPLICATION_DEST_REGION_MISMATCH   = "boscliReplicationDestRegionMismatch"
+	BOSCLI_PUT_WEBSITE_NO_DOCUMENT            = "boscliPutWebsiteNoDocument"
+	BOSCLI_TRASH_DIR_IS_EMPTY                 = "boscliTrashDirIsEmpty"
 	BOSCLI_PUT_LOG_NO_TARGET_BUCKET           = "boscliPutLogNoTargetBucket"
 	BOSCLI_STORAGE_CLASS_IS_EMPTY             = "boscliStorageClasssIsEmpty"
 	BOSCLI_PUT_ACL_CANNED_FILE_SAME_TIME      = "boscliPutAclCannedFileSameTime"
 	BOSCLI_PUT_ACL_CANNED_FILE_BOTH_EMPTY     = "boscliPutAclCannedFileBothEmpty"
 	BOSCLI_PUT_ACL_CANNED_DONT_SUPPORT        = "boscliPutAclCannedDontSupport"
+	BOSCLI_PUT_OBJECT_ACL_CANNED_DONT_SUPPORT = "boscliPutObjectAclCannedDontSupport"
 )
 
 var BosCliSuggetions map[BosCliErrorCode]string
@@ -103,7 +156,8 @@ func initSuggetionChinese() {
 	BosCliSuggetions[BOSCLI_INTERNAL_ERROR] =
 		"CLI 内部发生了错误，请重试！"
 	BosCliSuggetions[BOSCLI_UNSUPPORT_STORAGE_CLASS] =
-		"BOS 当前只支持 STANDARD（标准）， STANDARD_IA（低频）和 COLD（冷存储） 三种存储类型！"
+		"BOS 当前只支持 STANDARD（标准）， STANDARD_IA（低频）， COLD（冷存储）和 " +
+			"ARCHIVE（归档存储） 四种存储类型！"
 	BosCliSuggetions[BOSCLI_SRC_BUCKET_IS_EMPTY] =
 		"请检查源 bucket 名字是否为空！"
 	BosCliSuggetions[BOSCLI_DST_BUCKET_IS_EMPTY] =
@@ -137,11 +191,104 @@ func initSuggetionChinese() {
 		"请指定上传的文件在BOS上保存的名称!"
 	BosCliSuggetions[BOSCLI_UPLOAD_STREAM_TO_DIR] =
 		"通过流上传文件时， 你需要指定文件保存的名称!"
+	BosCliSuggetions[BOSCLI_DOWNLOAD_DIR_TO_STREAM] =
+		"只能将单个文件下载到标准输出，请指定 object 的完整名称!"
 	BosCliSuggetions[BOSCLI_RM_DIR_MUST_USE_RECURSIVE] =
 		"如果您要删除文件夹请加上  -r" +
 			"例如：bcecmd bos rm bos:/bucket -r  或 bcecmd bos rm bos:/bucket/dir/ -r"
 	BosCliSuggetions[BOSCLI_EXPIRE_LESS_NONE] =
 		"有效时间支持1-43200间的整数。如果需要永久有效的分享链接，可以将有效时间设为-1"
+	BosCliSuggetions[BOSCLI_LIST_PAGE_SIZE_INVALID] =
+		"--page-size 支持1-1000间的整数！"
+	BosCliSuggetions[BOSCLI_LIST_MAX_ITEMS_LESS_ZERO] =
+		"--max-items 不能小于0， 0表示不限制！"
+	BosCliSuggetions[BOSCLI_LIST_BUCKETS_NOT_SUPPORT_PAGING] =
+		"列举 bucket 时不支持 --page-size, --max-items 和 --starting-marker！"
+	BosCliSuggetions[BOSCLI_DU_DEPTH_LESS_ZERO] =
+		"--depth 不能小于0， 0表示只统计总量！"
+	BosCliSuggetions[BOSCLI_DU_SORT_TYPE_INVALID] =
+		"--sort 必需是 'name', 'size' 或 'count'！"
+	BosCliSuggetions[BOSCLI_MOVE_NOT_SUPPORT_STREAM] =
+		"mv 不支持标准输入和标准输出，请使用 cp 上传或下载流！"
+	BosCliSuggetions[BOSCLI_MOVE_DST_IN_SRC] =
+		"不能将目录移动到它的子目录中，请指定其他的目标路径！"
+	BosCliSuggetions[BOSCLI_MOVE_TO_ITSELF] =
+		"不能将文件移动到它自身，修改存储类型请使用 set-storage-class！"
+	BosCliSuggetions[BOSCLI_CAT_OBJECT_IS_DIR] =
+		"cat 只能显示单个文件的内容，BOS 路径必需包含文件名且不能以 '/' 结尾！"
+	BosCliSuggetions[BOSCLI_CAT_RANGE_TAIL_TOG] =
+		"--range 和 --tail 不能同时使用！"
+	BosCliSuggetions[BOSCLI_CAT_TAIL_LESS_ZERO] =
+		"--tail 不能小于0！"
+	BosCliSuggetions[BOSCLI_CAT_DECOMPRESS_WITH_RANGE] =
+		"gzip 文件只能从头解压，--decompress 不能与 --range 或 --tail 同时使用！"
+	BosCliSuggetions[BOSCLI_RANGE_INVALID] =
+		"--range 的格式必需是 START-END 或 START-，START 和 END 是从0开始的字节偏移，且 END 不能小于 START！"
+	BosCliSuggetions[BOSCLI_RANGE_ONLY_FOR_DOWNLOAD] =
+		"--range 只支持下载单个文件！"
+	BosCliSuggetions[BOSCLI_FIND_SIZE_INVALID] =
+		"--size 的格式必需是 [+|-]N[B|K|M|G|T]，例如 +100M 表示大于100M，-1K 表示小于1K！"
+	BosCliSuggetions[BOSCLI_FIND_MTIME_INVALID] =
+		"--mtime 的格式必需是 [+|-]N[s|m|h|d|w]，例如 -7d 表示7天内修改过，+30d 表示30天前修改过！"
+	BosCliSuggetions[BOSCLI_FIND_EXEC_DELETE_TOG] =
+		"--exec 和 --delete 不能同时使用！"
+	BosCliSuggetions[BOSCLI_FIND_EXEC_INVALID] =
+		"--exec 的命令中引号或转义符未结束，例如： --exec \"sh -c 'echo {} | wc -c'\""
+	BosCliSuggetions[BOSCLI_RESTORE_DIR_MUST_USE_RECURSIVE] =
+		"取回目录下的归档文件需要使用 -r 参数，例如： bcecmd bos restore bos:/bucket/dir/ -r"
+	BosCliSuggetions[BOSCLI_RESTORE_DAYS_INVALID] =
+		"--days 表示取回后文件可读的天数，取值范围是1到30！"
+	BosCliSuggetions[BOSCLI_RESTORE_TIER_INVALID] =
+		"--tier 只支持 Standard（标准取回）和 Expedited（加急取回）！"
+	BosCliSuggetions[BOSCLI_SET_STORAGE_CLASS_MUST_RECURSIVE] =
+		"修改目录下文件的存储类型需要使用 -r 参数，例如： " +
+			"bcecmd bos set-storage-class bos:/bucket/dir/ --storage-class COLD -r"
+	BosCliSuggetions[BOSCLI_UNDELETE_DIR_MUST_USE_RECURSIVE] =
+		"恢复回收站目录下的文件需要使用 -r 参数，例如： bcecmd bos undelete bos:/bucket/.trash/dir/ -r"
+	BosCliSuggetions[BOSCLI_UNDELETE_NOT_IN_TRASH] =
+		"只能恢复回收站目录下的文件，路径必须以 bucket 的回收站目录开头，例如： " +
+			"bcecmd bos undelete bos:/bucket/.trash/key （回收站目录可以通过 " +
+			"bcecmd bosapi get-bucket-trash 查看）"
+	BosCliSuggetions[BOSCLI_OLDER_THAN_INVALID] =
+		"--older-than 的格式必需是 N[s|m|h|d|w]，例如 30d 表示30天前修改过的文件！"
+	BosCliSuggetions[BOSCLI_METADATA_DIRECTIVE_INVALID] =
+		"--metadata-directive 只能是 copy 或 replace，设置 HTTP 头或 --meta 时不能使用 copy！"
+	BosCliSuggetions[BOSCLI_METADATA_DIRECTIVE_ONLY_FOR_COPY] =
+		"--metadata-directive 只能在 BOS 之间复制文件时使用！"
+	BosCliSuggetions[BOSCLI_USER_META_INVALID] =
+		"--meta 的格式必需是 key=value，例如 --meta author=bce，可以重复使用设置多个元数据！"
+	BosCliSuggetions[BOSCLI_SSE_ALGORITHM_INVALID] =
+		"服务端加密算法只支持 AES256！"
+	BosCliSuggetions[BOSCLI_SSE_CUSTOMER_KEY_INVALID] =
+		"用户提供的加密密钥文件必须包含32字节的密钥，或者该密钥的base64编码！"
+	BosCliSuggetions[BOSCLI_SSE_ARGS_CONFLICT] =
+		"服务端加密参数的使用方法：\n" +
+			"    1. --sse 和 --sse-c-key-file 不能同时使用，下载文件时只能使用 --sse-c-key-file；\n" +
+			"    2. --sse-c-copy-source-key-file 只能在 BOS 之间复制文件时使用。"
+	BosCliSuggetions[BOSCLI_LN_NOT_SAME_BUCKET] =
+		"软链接和目标文件必须在同一个 bucket 中，例如： bcecmd bos ln bos:/bucket/target bos:/bucket/link"
+	BosCliSuggetions[BOSCLI_LN_TARGET_IS_DIR] =
+		"软链接的目标必须是文件，不能是 bucket 或目录！"
+	BosCliSuggetions[BOSCLI_LN_LINK_TO_ITSELF] =
+		"软链接不能指向自己，请指定另一个软链接路径！"
+	BosCliSuggetions[BOSCLI_PRESERVE_SYMLINKS_ONLY_FOR_UPLOAD] =
+		"--preserve-symlinks 只能在上传目录时使用，例如： " +
+			"bcecmd bos cp ./dir bos:/bucket/dir -r --preserve-symlinks"
+	BosCliSuggetions[BOSCLI_APPEND_DST_IS_DIR] =
+		"追加的目标必须是文件，不能是 bucket 或目录！"
+	BosCliSuggetions[BOSCLI_APPEND_SRC_IS_DIR] =
+		"只能追加单个本地文件或标准输入（-）的内容，不能追加目录！"
+	BosCliSuggetions[BOSCLI_APPEND_OBJECT_NOT_APPENDABLE] =
+		"只能向 Appendable 类型的文件追加数据，可以通过 bcecmd bos stat 查看文件类型！"
+	BosCliSuggetions[BOSCLI_APPEND_OFFSET_MISMATCH] =
+		"文件在追加过程中被其他程序修改了，请通过 bcecmd bos stat 确认文件内容后重试！"
+	BosCliSuggetions[BOSCLI_FETCH_URL_INVALID] =
+		"只能抓取 http 或 https 地址的文件，例如： " +
+			"bcecmd bos fetch https://example.com/file.bin bos:/bucket/key"
+	BosCliSuggetions[BOSCLI_FETCH_OBJECT_NAME_IS_EMPTY] =
+		"无法从 URL 中获取文件名，请在目的路径中指定 object 名称！"
+	BosCliSuggetions[BOSCLI_FETCH_ASYNC_VIA_LOCAL_CONFLICT] =
+		"--async 和 --via-local 不能同时使用，通过本地中转时只能同步抓取！"
 	BosCliSuggetions[BOSCLI_SYNC_EXCLUDE_INCLUDE_TIME_TOG] =
 		"exclude-time 和 include-time 不能同时使用！"
 	BosCliSuggetions[BOSCLI_SYNC_EXCLUDE_INCLUDE_TOG] =
@@ -155,12 +302,45 @@ func initSuggetionChinese() {
 		"Sync 不支持同步本地文件到本地，你可以使用其他工具（比如 rsync）来本地同步文件！"
 	BosCliSuggetions[BOSCLI_SYNC_PROCESS_NUM_LESS_ZERO] =
 		"Sync并发数不能小于1， 请你使用 bcecmd -c 重新配置！"
+	BosCliSuggetions[BOSCLI_CONCURRENCY_LESS_ZERO] =
+		"并发数不能小于0， 请通过 --concurrency 重新指定！"
 	BosCliSuggetions[BOSCLI_INVALID_SYNY_TYPE] =
 		"Sync 类型必需是 'time-size', 'time-size-crc32' 或 'only-crc32'！"
 	BosCliSuggetions[BOSCLI_PUT_LIFECYCLE_NO_CONFIG_AND_BUCKET] =
 		"请指定要配置生命周期的bucekt name，和生命周期配置文件的地址, 操作示例:\n" +
 			"bce bosapi put-lifecycle --lifecycle-config-file lifecycle_bj.json --bucket-name " +
 			"bucket1"
+	BosCliSuggetions[BOSCLI_PUT_CORS_NO_CONFIG_AND_BUCKET] =
+		"请指定要配置跨域访问（CORS）规则的bucket name，和CORS配置文件的地址, 操作示例:\n" +
+			"bce bosapi put-bucket-cors --cors-config-file cors.json --bucket-name bucket1\n" +
+			"您可以通过 bce bosapi put-bucket-cors --template 获取配置文件模板"
+	BosCliSuggetions[BOSCLI_CORS_CONFIG_INVALID] =
+		"CORS配置文件无效，请检查：\n" +
+			"    1. 文件为json格式，且只包含模板中的字段；\n" +
+			"    2. 规则数量在1到100之间，每条规则都指定了 allowedOrigins 和 allowedMethods；\n" +
+			"    3. 每个 origin 最多包含一个 '*'，method 只能是 GET、PUT、POST、DELETE 或 HEAD。"
+	BosCliSuggetions[BOSCLI_PUT_REPLICATION_NO_CONFIG] =
+		"请指定要配置数据同步的bucket name，和同步规则配置文件的地址, 操作示例:\n" +
+			"bce bosapi put-bucket-replication --replication-config-file replication.json " +
+			"--bucket-name bucket1\n" +
+			"您可以通过 bce bosapi put-bucket-replication --template 获取配置文件模板"
+	BosCliSuggetions[BOSCLI_REPLICATION_CONFIG_INVALID] =
+		"同步规则配置文件无效，请检查：\n" +
+			"    1. 文件为json格式，且只包含模板中的字段；\n" +
+			"    2. id 只能包含数字、字母、'_' 和 '-'，status 和 replicateDeletes 只能是 " +
+			"enabled 或 disabled；\n" +
+			"    3. resource 必须以源 bucket name 开头，destination 必须指定另一个 bucket。"
+	BosCliSuggetions[BOSCLI_REPLICATION_SAME_REGION] =
+		"源 bucket 和目的 bucket 在同一个区域，跨区域同步的目的 bucket 必须在其他区域！"
+	BosCliSuggetions[BOSCLI_REPLICATION_DEST_REGION_MISMATCH] =
+		"同步规则中的 destRegion 与目的 bucket 所在的区域不一致，请修改 destRegion 或者删除该字段！"
+	BosCliSuggetions[BOSCLI_PUT_WEBSITE_NO_DOCUMENT] =
+		"请至少指定索引文档或404文档中的一个，操作示例:\n" +
+			"bce bosapi put-bucket-static-website --index index.html --not-found 404.html " +
+			"--bucket-name bucket1"
+	BosCliSuggetions[BOSCLI_TRASH_DIR_IS_EMPTY] =
+		"回收站目录不能为空，操作示例:\n" +
+			"bce bosapi put-bucket-trash --trash-dir .trash --bucket-name bucket1"
 	BosCliSuggetions[BOSCLI_PUT_LOG_NO_TARGET_BUCKET] =
 		"请指定用于保存日志的bucket (Prefix可选)，操作示例:\n" +
 			"    指定Prefix: bce bosapi put-logging --target-bucket bucket2 --target-prefix log " +
@@ -168,13 +348,15 @@ func initSuggetionChinese() {
 			"    不指定Prefix: bce bosapi put-logging --target-bucket bucket2 --bucket-name bucket1"
 	BosCliSuggetions[BOSCLI_STORAGE_CLASS_IS_EMPTY] =
 		"Storage class（存储类型）为空， 请指定storage class. BOS 当前支持 STANDARD（标准），" +
-			"STANDARD_IA（低频）和 COLD（冷存储） 三种存储类型！"
+			"STANDARD_IA（低频）， COLD（冷存储）和 ARCHIVE（归档存储） 四种存储类型！"
 	BosCliSuggetions[BOSCLI_PUT_ACL_CANNED_FILE_SAME_TIME] =
-		"不能同时通过canned ACL和ACL文件来设置bucket的ACL"
+		"不能同时通过canned ACL和ACL文件来设置bucket或object的ACL"
 	BosCliSuggetions[BOSCLI_PUT_ACL_CANNED_DONT_SUPPORT] =
 		"Canned ACL 仅支持 private、public-read、public-read-write三种"
+	BosCliSuggetions[BOSCLI_PUT_OBJECT_ACL_CANNED_DONT_SUPPORT] =
+		"Object 的 Canned ACL 仅支持 private、public-read两种"
 	BosCliSuggetions[BOSCLI_PUT_ACL_CANNED_FILE_BOTH_EMPTY] =
-		"请指定Bucket 的 ACL配置信息，您可以通过 --canned 指定 canned ACL，或者通过 " +
+		"请指定Bucket或Object的 ACL配置信息，您可以通过 --canned 指定 canned ACL，或者通过 " +
 			"--acl-config-file 从文件中上传ACL"
 
 }
@@ -224,10 +406,10 @@ func printSuggAndErr(code BosCliErrorCode, err error, format string, args ...int
 	// print error message
 	if format != "" {
 		if firstPrint {
-			fmt.Printf("\n")
+			fmt.Fprintf(msgOutput, "\n")
 			firstPrint = false
 		}
-		fmt.Printf("Error: "+format+"\n", args...)
+		fmt.Fprintf(msgOutput, "Error: "+format+"\n", args...)
 	}
 
 	// get suggetion accroding to error code
@@ -235,17 +417,18 @@ func printSuggAndErr(code BosCliErrorCode, err error, format string, args ...int
 		suggetion := getCliSuggetions(code, err)
 		if suggetion != "" {
 			if firstPrint {
-				fmt.Printf("\n")
+				fmt.Fprintf(msgOutput, "\n")
 				firstPrint = false
 			}
 			if suggetion != "" {
-				fmt.Printf("%s: %s\n", BOSCLI_SUGGETION_PROPMT, suggetion)
+				fmt.Fprintf(msgOutput, "%s: %s\n", BOSCLI_SUGGETION_PROPMT, suggetion)
 			}
 		}
 	}
 	if !firstPrint {
-		fmt.Printf("\n")
+		fmt.Fprintf(msgOutput, "\n")
 	}
+	finishRecords()
 	os.Exit(1)
 }
 
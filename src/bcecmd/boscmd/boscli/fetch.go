@@ -0,0 +1,214 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+// This module fetches objects from http or https urls into BOS.
+
+package boscli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+import (
+	"bcecmd/boscmd"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+type fetchArgs struct {
+	srcUrl       string
+	bucketName   string
+	objectKey    string
+	storageClass string
+	mode         string // sync, async or local
+}
+
+type fetchResult struct {
+	mode    string
+	jobId   string // only returned by async fetch
+	size    int64
+	etag    string
+	elapsed time.Duration
+}
+
+// fetch: get an object from url and store it in BOS.
+// PARAMS:
+//   srcUrl  : http or https url of the object
+//   dstPath : bos path of the object, the name in url is used when it ends with "/"
+//   async   : BOS fetches the object in background, only the id of job is returned
+//   viaLocal: get the object by bcecmd and upload it, for urls which BOS can't reach
+func (b *BosCli) Fetch(srcUrl, dstPath, storageClass string, async, viaLocal, quiet bool) {
+	defer finishRecords()
+	Quiet = quiet
+
+	args, retCode, err := b.fetchPreProcess(srcUrl, dstPath, storageClass, async, viaLocal)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+
+	dstPath = BOS_PATH_PREFIX + args.bucketName + "/" + args.objectKey
+	ret, err := b.fetchExecute(args)
+	if err != nil {
+		printOperationRecord(SYNC_OP_FETCH, OPERATION_STATUS_FAILED, srcUrl, dstPath, err)
+		bcecliAbnormalExistErr(err)
+	}
+	elapsed := ret.elapsed.Round(time.Millisecond)
+	if ret.mode == api.FETCH_MODE_ASYNC {
+		printIfNotQuiet("%s: %s to %s, job %s is submitted, elapsed %s\n", SYNC_OP_FETCH,
+			srcUrl, dstPath, ret.jobId, elapsed)
+	} else {
+		printIfNotQuiet("%s: %s to %s, size %d, etag %s, elapsed %s\n", SYNC_OP_FETCH, srcUrl,
+			dstPath, ret.size, ret.etag, elapsed)
+	}
+	printFetchRecord(srcUrl, dstPath, ret)
+}
+
+// check the request of fetch
+func (b *BosCli) fetchPreProcess(srcUrl, dstPath, storageClass string, async,
+	viaLocal bool) (*fetchArgs, BosCliErrorCode, error) {
+
+	if async && viaLocal {
+		return nil, BOSCLI_FETCH_ASYNC_VIA_LOCAL_CONFLICT, fmt.Errorf("--async and --via-local " +
+			"can't be used together")
+	}
+	srcInfo, err := url.Parse(srcUrl)
+	if err != nil || (srcInfo.Scheme != "http" && srcInfo.Scheme != "https") ||
+		srcInfo.Host == "" {
+		return nil, BOSCLI_FETCH_URL_INVALID, fmt.Errorf("invalid url %s", srcUrl)
+	}
+
+	retCode, err := checkBosPath(dstPath)
+	if err != nil {
+		return nil, retCode, err
+	}
+	bucketName, objectKey := splitBosBucketKey(dstPath)
+	if bucketName == "" {
+		return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("The bucket name is empty")
+	}
+
+	// like cp, the object is stored in the directory with the name in url
+	if objectKey == "" || strings.HasSuffix(objectKey, boscmd.BOS_PATH_SEPARATOR) {
+		if srcInfo.Path == "" || strings.HasSuffix(srcInfo.Path, "/") {
+			return nil, BOSCLI_FETCH_OBJECT_NAME_IS_EMPTY, fmt.Errorf("there is no file name "+
+				"in url %s", srcUrl)
+		}
+		objectKey += path.Base(srcInfo.Path)
+	}
+
+	dstStorageClass, retCode := getStorageClassFromStr(storageClass)
+	if retCode != BOSCLI_OK {
+		return nil, retCode, fmt.Errorf("don't support storage-class %s", storageClass)
+	}
+
+	mode := api.FETCH_MODE_SYNC
+	if async {
+		mode = api.FETCH_MODE_ASYNC
+	} else if viaLocal {
+		mode = FETCH_MODE_VIA_LOCAL
+	}
+	return &fetchArgs{
+		srcUrl:       srcUrl,
+		bucketName:   bucketName,
+		objectKey:    objectKey,
+		storageClass: dstStorageClass,
+		mode:         mode,
+	}, BOSCLI_OK, nil
+}
+
+// fetch the object, its size and etag are got by head object after it is stored
+func (b *BosCli) fetchExecute(args *fetchArgs) (*fetchResult, error) {
+	ret := &fetchResult{mode: args.mode}
+	start := time.Now()
+	if args.mode == FETCH_MODE_VIA_LOCAL {
+		if err := b.fetchViaLocal(args); err != nil {
+			return nil, err
+		}
+	} else {
+		result, err := b.bosClient.FetchObject(args.bucketName, args.objectKey, args.srcUrl,
+			&api.FetchObjectArgs{FetchMode: args.mode, StorageClass: args.storageClass})
+		if err != nil {
+			return nil, err
+		}
+		ret.jobId = result.JobId
+	}
+	ret.elapsed = time.Since(start)
+
+	// the object doesn't exist until the job of async fetch is finished
+	if args.mode == api.FETCH_MODE_ASYNC {
+		return ret, nil
+	}
+	stat, err := b.getObjectStat(args.bucketName, args.objectKey)
+	if err != nil {
+		return nil, err
+	}
+	ret.size = stat.contentLength
+	ret.etag = stat.etag
+	return ret, nil
+}
+
+// get the object by http and upload the response body as a stream, the multipart upload is
+// used for large objects, so the object isn't saved in local disk. When the url stalls, the
+// read of body fails and the multipart upload is aborted by utilUploadStream.
+func (b *BosCli) fetchViaLocal(args *fetchArgs) error {
+	client := newFetchHttpClient(FETCH_CONNECT_TIMEOUT*time.Second,
+		FETCH_READ_TIMEOUT*time.Second)
+	resp, err := client.Get(args.srcUrl)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get %s: %s", args.srcUrl, resp.Status)
+	}
+
+	meta := &objectMetaArgs{contentType: resp.Header.Get("Content-Type")}
+	return b.handler.utilUploadStream(b.bosClient, resp.Body, args.bucketName, args.objectKey,
+		args.storageClass, meta)
+}
+
+// http client whose connections fail a read when no data is received in readTimeout, the
+// timeout only counts the time of reading, not the time of uploading what has been read.
+func newFetchHttpClient(connectTimeout, readTimeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: connectTimeout}
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			return &deadlineConn{Conn: conn, readTimeout: readTimeout}, nil
+		},
+		TLSHandshakeTimeout:   connectTimeout,
+		ResponseHeaderTimeout: readTimeout,
+	}
+	return &http.Client{Transport: transport}
+}
+
+// connection which sets the read deadline before each read
+type deadlineConn struct {
+	net.Conn
+	readTimeout time.Duration
+}
+
+func (c *deadlineConn) Read(b []byte) (int, error) {
+	if err := c.Conn.SetReadDeadline(time.Now().Add(c.readTimeout)); err != nil {
+		return 0, err
+	}
+	return c.Conn.Read(b)
+}
@@ -0,0 +1,307 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+package boscli
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+import (
+	"utils/util"
+)
+
+type fetchPreProcessType struct {
+	srcUrl       string
+	dstPath      string
+	storageClass string
+	async        bool
+	viaLocal     bool
+	out          *fetchArgs
+	code         BosCliErrorCode
+}
+
+func TestFetchPreProcess(t *testing.T) {
+	testCases := []fetchPreProcessType{
+		//1
+		fetchPreProcessType{
+			srcUrl:   "http://example.com/a.bin",
+			dstPath:  "bos:/bucket/key",
+			async:    true,
+			viaLocal: true,
+			code:     BOSCLI_FETCH_ASYNC_VIA_LOCAL_CONFLICT,
+		},
+		//2
+		fetchPreProcessType{
+			srcUrl:  "ftp://example.com/a.bin",
+			dstPath: "bos:/bucket/key",
+			code:    BOSCLI_FETCH_URL_INVALID,
+		},
+		//3
+		fetchPreProcessType{
+			srcUrl:  "./a.bin",
+			dstPath: "bos:/bucket/key",
+			code:    BOSCLI_FETCH_URL_INVALID,
+		},
+		//4
+		fetchPreProcessType{
+			srcUrl:  "http://example.com/a.bin",
+			dstPath: "/bucket/key",
+			code:    BOSCLI_BOSPATH_IS_INVALID,
+		},
+		//5
+		fetchPreProcessType{
+			srcUrl:  "http://example.com/a.bin",
+			dstPath: "bos:/",
+			code:    BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		//6
+		fetchPreProcessType{
+			srcUrl:  "http://example.com/dir/",
+			dstPath: "bos:/bucket/dir/",
+			code:    BOSCLI_FETCH_OBJECT_NAME_IS_EMPTY,
+		},
+		//7
+		fetchPreProcessType{
+			srcUrl:  "https://example.com",
+			dstPath: "bos:/bucket",
+			code:    BOSCLI_FETCH_OBJECT_NAME_IS_EMPTY,
+		},
+		//8
+		fetchPreProcessType{
+			srcUrl:       "http://example.com/a.bin",
+			dstPath:      "bos:/bucket/key",
+			storageClass: "HOT",
+			code:         BOSCLI_UNSUPPORT_STORAGE_CLASS,
+		},
+		//9
+		fetchPreProcessType{
+			srcUrl:  "http://example.com/a.bin",
+			dstPath: "bos:/bucket/key",
+			out: &fetchArgs{
+				srcUrl:     "http://example.com/a.bin",
+				bucketName: "bucket",
+				objectKey:  "key",
+				mode:       "sync",
+			},
+			code: BOSCLI_OK,
+		},
+		//10 the name in url is used
+		fetchPreProcessType{
+			srcUrl:       "https://example.com/data/a.bin?v=1",
+			dstPath:      "bos:/bucket/dir/",
+			storageClass: "cold",
+			async:        true,
+			out: &fetchArgs{
+				srcUrl:       "https://example.com/data/a.bin?v=1",
+				bucketName:   "bucket",
+				objectKey:    "dir/a.bin",
+				storageClass: "COLD",
+				mode:         "async",
+			},
+			code: BOSCLI_OK,
+		},
+		//11
+		fetchPreProcessType{
+			srcUrl:   "http://example.com/a.bin",
+			dstPath:  "bos:/bucket",
+			viaLocal: true,
+			out: &fetchArgs{
+				srcUrl:     "http://example.com/a.bin",
+				bucketName: "bucket",
+				objectKey:  "a.bin",
+				mode:       FETCH_MODE_VIA_LOCAL,
+			},
+			code: BOSCLI_OK,
+		},
+	}
+	for i, tCase := range testCases {
+		ret, code, err := testBosCli.fetchPreProcess(tCase.srcUrl, tCase.dstPath,
+			tCase.storageClass, tCase.async, tCase.viaLocal)
+		util.ExpectEqual("fetch.go fetchPreProcess I", i+1, t.Errorf, tCase.code, code)
+		if code != BOSCLI_OK {
+			util.ExpectEqual("fetch.go fetchPreProcess II", i+1, t.Errorf, true, err != nil)
+			continue
+		}
+		util.ExpectEqual("fetch.go fetchPreProcess III", i+1, t.Errorf, tCase.out, ret)
+	}
+}
+
+type fetchExecuteType struct {
+	args        *fetchArgs
+	fetches     []string
+	uploadArg   string
+	contentType string
+	jobId       string
+	size        int64
+	isSuc       bool
+}
+
+func TestFetchExecute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		if r.URL.Path != "/a.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, "fetch")
+	}))
+	defer server.Close()
+
+	testCases := []fetchExecuteType{
+		//1
+		fetchExecuteType{
+			args: &fetchArgs{srcUrl: "http://example.com/a.bin", bucketName: "bucket",
+				objectKey: "key", mode: "sync"},
+			fetches: []string{"bucket/key<-http://example.com/a.bin:sync"},
+			size:    100,
+			isSuc:   true,
+		},
+		//2 size is unknown until the job is finished
+		fetchExecuteType{
+			args: &fetchArgs{srcUrl: "http://example.com/a.bin", bucketName: "bucket",
+				objectKey: "key", mode: "async"},
+			fetches: []string{"bucket/key<-http://example.com/a.bin:async"},
+			jobId:   "job",
+			isSuc:   true,
+		},
+		//3
+		fetchExecuteType{
+			args: &fetchArgs{srcUrl: "http://example.com/a.bin", bucketName: "error",
+				objectKey: "key", mode: "sync"},
+			isSuc: false,
+		},
+		//4 fetched object doesn't exist
+		fetchExecuteType{
+			args: &fetchArgs{srcUrl: "http://example.com/a.bin", bucketName: "bucket",
+				objectKey: "404", mode: "sync"},
+			fetches: []string{"bucket/404<-http://example.com/a.bin:sync"},
+			isSuc:   false,
+		},
+		//5
+		fetchExecuteType{
+			args: &fetchArgs{srcUrl: server.URL + "/a.txt", bucketName: "bucket",
+				objectKey: "key", storageClass: "COLD", mode: FETCH_MODE_VIA_LOCAL},
+			uploadArg:   "bucketkeyCOLD",
+			contentType: "text/plain",
+			size:        100,
+			isSuc:       true,
+		},
+		//6 the url is not found
+		fetchExecuteType{
+			args: &fetchArgs{srcUrl: server.URL + "/b.txt", bucketName: "bucket",
+				objectKey: "key", mode: FETCH_MODE_VIA_LOCAL},
+			isSuc: false,
+		},
+		//7 failed to upload
+		fetchExecuteType{
+			args: &fetchArgs{srcUrl: server.URL + "/a.txt", bucketName: "error",
+				objectKey: "key", mode: FETCH_MODE_VIA_LOCAL},
+			uploadArg:   "errorkey",
+			contentType: "text/plain",
+			isSuc:       false,
+		},
+	}
+	fakeClient, ok := testBosCli.bosClient.(*fakeBosClientForBos)
+	if !ok {
+		t.Errorf("fetch.go fetchExecute: bos client is not fake")
+		return
+	}
+	for i, tCase := range testCases {
+		fakeClient.fetches = nil
+		testBosHandler.utilUploadStreamArgVal = ""
+		testBosHandler.objectMetaArgVal = nil
+		ret, err := testBosCli.fetchExecute(tCase.args)
+		util.ExpectEqual("fetch.go fetchExecute I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		util.ExpectEqual("fetch.go fetchExecute II", i+1, t.Errorf, tCase.fetches,
+			fakeClient.fetches)
+		util.ExpectEqual("fetch.go fetchExecute III", i+1, t.Errorf, tCase.uploadArg,
+			testBosHandler.utilUploadStreamArgVal)
+		if tCase.contentType != "" {
+			util.ExpectEqual("fetch.go fetchExecute IV", i+1, t.Errorf, tCase.contentType,
+				testBosHandler.objectMetaArgVal.contentType)
+		}
+		if err != nil {
+			continue
+		}
+		util.ExpectEqual("fetch.go fetchExecute V", i+1, t.Errorf, tCase.args.mode, ret.mode)
+		util.ExpectEqual("fetch.go fetchExecute VI", i+1, t.Errorf, tCase.jobId, ret.jobId)
+		util.ExpectEqual("fetch.go fetchExecute VII", i+1, t.Errorf, tCase.size, ret.size)
+	}
+}
+
+type newFetchHttpClientType struct {
+	path    string
+	getSuc  bool
+	readSuc bool
+}
+
+func TestNewFetchHttpClient(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		switch r.URL.Path {
+		case "/header":
+			// stall before the header is sent
+			<-done
+		case "/body":
+			// stall after a part of body is sent
+			w.Header().Set("Content-Length", "100")
+			io.WriteString(w, "fetch")
+			w.(http.Flusher).Flush()
+			<-done
+		default:
+			io.WriteString(w, "fetch")
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	testCases := []newFetchHttpClientType{
+		//1
+		newFetchHttpClientType{
+			path:    "/a.txt",
+			getSuc:  true,
+			readSuc: true,
+		},
+		//2
+		newFetchHttpClientType{
+			path:   "/header",
+			getSuc: false,
+		},
+		//3
+		newFetchHttpClientType{
+			path:    "/body",
+			getSuc:  true,
+			readSuc: false,
+		},
+	}
+	client := newFetchHttpClient(time.Second, 100*time.Millisecond)
+	for i, tCase := range testCases {
+		resp, err := client.Get(server.URL + tCase.path)
+		util.ExpectEqual("fetch.go newFetchHttpClient I", i+1, t.Errorf, tCase.getSuc,
+			err == nil)
+		if err != nil {
+			continue
+		}
+		_, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		util.ExpectEqual("fetch.go newFetchHttpClient II", i+1, t.Errorf, tCase.readSuc,
+			err == nil)
+	}
+}
@@ -19,6 +19,7 @@ package boscli
 import (
 	// 	"fmt"
 	"strings"
+	"time"
 )
 
 import (
@@ -205,3 +206,106 @@ func (b *bosFilter) TimeFilter(mtime int64) bool {
 	}
 	return false
 }
+
+var (
+	// units of --size of find, size without unit is in bytes
+	findSizeUnits = map[string]int64{
+		"B": 1,
+		"K": 1 << 10,
+		"M": 1 << 20,
+		"G": 1 << 30,
+		"T": 1 << 40,
+	}
+	// units of --mtime of find, time without unit is in days
+	findTimeUnits = map[string]int64{
+		"S": 1,
+		"M": 60,
+		"H": 60 * 60,
+		"D": 24 * 60 * 60,
+		"W": 7 * 24 * 60 * 60,
+	}
+)
+
+// condition of find in format [+|-]N[unit], e.g., +100M, -7d
+// +N means greater than N, -N means less than N, and N means in [N, N+1) units.
+type findCondition struct {
+	sign  byte
+	value int64 // N * unit
+	unit  int64
+}
+
+func (c *findCondition) match(val int64) bool {
+	switch c.sign {
+	case '+':
+		return val > c.value
+	case '-':
+		return val < c.value
+	}
+	return val >= c.value && val < c.value+c.unit
+}
+
+// predicates of find, an object is matched only when it satisfies all of them.
+type findFilter struct {
+	namePattern  string
+	size         *findCondition
+	mtime        *findCondition // compared with the seconds since the object was modified
+	storageClass string
+	now          int64
+}
+
+func newFindFilter(name, size, mtime, storageClass string) (*findFilter, BosCliErrorCode,
+	error) {
+
+	var (
+		retCode BosCliErrorCode
+		err     error
+	)
+
+	filter := &findFilter{
+		namePattern: name,
+		now:         time.Now().Unix(),
+	}
+	if size != "" {
+		if filter.size, err = parseFindCondition(size, findSizeUnits, "B"); err != nil {
+			return nil, BOSCLI_FIND_SIZE_INVALID, err
+		}
+	}
+	if mtime != "" {
+		if filter.mtime, err = parseFindCondition(mtime, findTimeUnits, "D"); err != nil {
+			return nil, BOSCLI_FIND_MTIME_INVALID, err
+		}
+	}
+	if filter.storageClass, retCode = getStorageClassFromStr(storageClass); retCode !=
+		BOSCLI_OK {
+		return nil, retCode, nil
+	}
+	return filter, BOSCLI_OK, nil
+}
+
+// Whether object satisfies all predicates, name pattern is matched with the last component of
+// object key.
+func (f *findFilter) match(object *fileDetail) (bool, error) {
+	if f.namePattern != "" {
+		name := object.path[strings.LastIndex(object.path, boscmd.BOS_PATH_SEPARATOR)+1:]
+		matched, err := util.Match(f.namePattern, name)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	if f.size != nil && !f.size.match(object.size) {
+		return false, nil
+	}
+	if f.mtime != nil && !f.mtime.match(f.now-object.mtime) {
+		return false, nil
+	}
+	if f.storageClass != "" {
+		storageClass := object.storageClass
+		if storageClass == "" {
+			storageClass = DEFAULT_STORAGE_CLASS
+		}
+		if storageClass != f.storageClass {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -425,3 +425,120 @@ func TestPatternFilter(t *testing.T) {
 		}
 	}
 }
+
+type findFilterMatchType struct {
+	filter *findFilter
+	object *fileDetail
+	isSuc  bool
+	match  bool
+}
+
+func TestFindFilterMatch(t *testing.T) {
+	var now int64 = 1000000
+	object := &fileDetail{
+		path:  "logs/2018/access.log",
+		size:  2 << 20,
+		mtime: now - 3*24*60*60,
+	}
+	testCases := []findFilterMatchType{
+		//1 no predicate
+		findFilterMatchType{
+			filter: &findFilter{now: now},
+			object: object,
+			isSuc:  true,
+			match:  true,
+		},
+		//2 name is matched with the last component of key
+		findFilterMatchType{
+			filter: &findFilter{namePattern: "*.log", now: now},
+			object: object,
+			isSuc:  true,
+			match:  true,
+		},
+		//3
+		findFilterMatchType{
+			filter: &findFilter{namePattern: "logs*", now: now},
+			object: object,
+			isSuc:  true,
+			match:  false,
+		},
+		//4
+		findFilterMatchType{
+			filter: &findFilter{
+				size: &findCondition{sign: '+', value: 1 << 20, unit: 1 << 20},
+				now:  now,
+			},
+			object: object,
+			isSuc:  true,
+			match:  true,
+		},
+		//5
+		findFilterMatchType{
+			filter: &findFilter{
+				size: &findCondition{sign: '-', value: 1 << 20, unit: 1 << 20},
+				now:  now,
+			},
+			object: object,
+			isSuc:  true,
+			match:  false,
+		},
+		//6 modified 3 days ago
+		findFilterMatchType{
+			filter: &findFilter{
+				mtime: &findCondition{sign: '-', value: 7 * 86400, unit: 86400},
+				now:   now,
+			},
+			object: object,
+			isSuc:  true,
+			match:  true,
+		},
+		//7
+		findFilterMatchType{
+			filter: &findFilter{
+				mtime: &findCondition{value: 3 * 86400, unit: 86400},
+				now:   now,
+			},
+			object: object,
+			isSuc:  true,
+			match:  true,
+		},
+		//8 empty storage class is STANDARD
+		findFilterMatchType{
+			filter: &findFilter{storageClass: "STANDARD", now: now},
+			object: object,
+			isSuc:  true,
+			match:  true,
+		},
+		//9
+		findFilterMatchType{
+			filter: &findFilter{storageClass: "COLD", now: now},
+			object: object,
+			isSuc:  true,
+			match:  false,
+		},
+		//10 all predicates must be satisfied
+		findFilterMatchType{
+			filter: &findFilter{
+				namePattern: "*.log",
+				mtime:       &findCondition{sign: '+', value: 7 * 86400, unit: 86400},
+				now:         now,
+			},
+			object: object,
+			isSuc:  true,
+			match:  false,
+		},
+		//11
+		findFilterMatchType{
+			filter: &findFilter{namePattern: "[", now: now},
+			object: object,
+			isSuc:  false,
+		},
+	}
+	for i, tCase := range testCases {
+		match, err := tCase.filter.match(tCase.object)
+		util.ExpectEqual("filter_strategy.go findFilter match I", i+1, t.Errorf, tCase.isSuc,
+			err == nil)
+		util.ExpectEqual("filter_strategy.go findFilter match II", i+1, t.Errorf, tCase.match,
+			match)
+	}
+}
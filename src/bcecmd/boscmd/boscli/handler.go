@@ -15,6 +15,7 @@
 package boscli
 
 import (
+	"compress/gzip"
 	"crypto/md5"
 	"encoding/hex"
 	"fmt"
@@ -25,6 +26,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -132,6 +134,10 @@ func (o *objectListIterator) listAllObjects(bucketName, objectKey, marker string
 		// throw dir
 		if len(response.CommonPrefixes) != 0 {
 			for _, item := range response.CommonPrefixes {
+				// the marker may be a dir thrown by the previous page
+				if item.Prefix == marker {
+					continue
+				}
 				o.objectsChan <- listFileResult{
 					dir: &dirDetail{
 						path: item.Prefix,
@@ -168,6 +174,7 @@ func (o *objectListIterator) listAllObjects(bucketName, objectKey, marker string
 							gtime:        gtime,
 							size:         int64(item.Size),
 							storageClass: item.StorageClass,
+							etag:         item.ETag,
 						},
 						isDir: strings.HasSuffix(item.Key, boscmd.BOS_PATH_SEPARATOR),
 					}
@@ -201,6 +208,7 @@ func (o *objectListIterator) listAllObjects(bucketName, objectKey, marker string
 					gtime:        gtime,
 					size:         int64(item.Size),
 					storageClass: item.StorageClass,
+					etag:         item.ETag,
 				},
 				isDir: false,
 			}
@@ -267,7 +275,8 @@ func (h *cliHandler) multiDeleteDir(bosClient bosClientInterface, bucketName,
 
 	var (
 		keyList             [MAX_DELETE_NUM_EACH_TIME]string
-		unDelObjects        []api.DeleteObjectResult
+		failedNum           int
+		deletedNum          int
 		curIndex            int
 		err                 error
 		listResult          *listFileResult
@@ -291,33 +300,29 @@ func (h *cliHandler) multiDeleteDir(bosClient bosClientInterface, bucketName,
 		curIndex += 1
 
 		if curIndex == MAX_DELETE_NUM_EACH_TIME {
-			unDelObjects, err = h.multiDeleteObjectsWithRetry(bosClient,
-				keyList[:curIndex], bucketName)
+			deletedNum, failedNum, err = h.utilDeleteObjects(bosClient, bucketName,
+				keyList[:curIndex])
 			if err != nil {
 				goto END
 			}
-			lenUnDelObjects := len(unDelObjects)
-			if lenUnDelObjects != 0 {
+			if failedNum != 0 {
 				successDeleteObject = false
 			}
-			successDeleteNum += (curIndex - lenUnDelObjects)
-			h.printDelResult(bucketName, keyList[:curIndex], unDelObjects)
+			successDeleteNum += deletedNum
 			curIndex = 0
 		}
 	}
 
 	if curIndex != 0 {
-		unDelObjects, err = h.multiDeleteObjectsWithRetry(bosClient, keyList[:curIndex],
-			bucketName)
+		deletedNum, failedNum, err = h.utilDeleteObjects(bosClient, bucketName,
+			keyList[:curIndex])
 		if err != nil {
 			goto END
 		}
-		lenUnDelObjects := len(unDelObjects)
-		if lenUnDelObjects != 0 {
+		if failedNum != 0 {
 			successDeleteObject = false
 		}
-		successDeleteNum += (curIndex - lenUnDelObjects)
-		h.printDelResult(bucketName, keyList[:curIndex], unDelObjects)
+		successDeleteNum += deletedNum
 	}
 
 END:
@@ -330,6 +335,22 @@ END:
 	return successDeleteNum, fmt.Errorf("Failed to delete some objects")
 }
 
+// Delete a batch of objects and print the result of each object.
+// Return:
+//       number of success deleted objects
+//       number of objects failed to delete
+//       error infomation
+func (h *cliHandler) utilDeleteObjects(bosClient bosClientInterface, bucketName string,
+	keyList []string) (int, int, error) {
+
+	unDelObjects, err := h.multiDeleteObjectsWithRetry(bosClient, keyList, bucketName)
+	if err != nil {
+		return 0, 0, err
+	}
+	h.printDelResult(bucketName, keyList, unDelObjects)
+	return len(keyList) - len(unDelObjects), len(unDelObjects), nil
+}
+
 // Delete objects, retry twice.
 func (h *cliHandler) multiDeleteObjectsWithRetry(bosClient bosClientInterface, objectList []string,
 	bucketName string) ([]api.DeleteObjectResult, error) {
@@ -401,6 +422,8 @@ func (h *cliHandler) utilDeleteObject(bosClient bosClientInterface, bucketName,
 	err := bosClient.DeleteObject(bucketName, objectKey)
 	if err == nil {
 		printIfNotQuiet("Delete object: %s%s/%s\n", BOS_PATH_PREFIX, bucketName, objectKey)
+		printOperationRecord(SYNC_OP_REMOVE, OPERATION_STATUS_SUCCESS, "",
+			BOS_PATH_PREFIX+bucketName+"/"+objectKey, nil)
 	}
 	return err
 }
@@ -408,7 +431,54 @@ func (h *cliHandler) utilDeleteObject(bosClient bosClientInterface, bucketName,
 // copy single object
 func (h *cliHandler) utilCopyObject(srcBosClient, bosClient bosClientInterface, srcBucketName,
 	srcObjectKey, dstBucketName, dstObjectKey, storageClass string, fileSize, fileMtime,
-	timeOfgetObjectInfo int64, restart bool) error {
+	timeOfgetObjectInfo int64, restart bool, meta *objectMetaArgs) error {
+
+	err := h.copyObject(srcBosClient, bosClient, srcBucketName, srcObjectKey, dstBucketName,
+		dstObjectKey, storageClass, fileSize, fileMtime, timeOfgetObjectInfo, restart, meta)
+	if err == nil {
+		printIfNotQuiet("Copy: %s%s/%s to %s%s/%s\n", BOS_PATH_PREFIX, srcBucketName, srcObjectKey,
+			BOS_PATH_PREFIX, dstBucketName, dstObjectKey)
+		printOperationRecord(SYNC_OP_COPY, OPERATION_STATUS_SUCCESS,
+			BOS_PATH_PREFIX+srcBucketName+"/"+srcObjectKey,
+			BOS_PATH_PREFIX+dstBucketName+"/"+dstObjectKey, nil)
+	}
+	return err
+}
+
+// change the storage class of an object by copying it to itself
+func (h *cliHandler) utilSetStorageClass(bosClient bosClientInterface, bucketName, objectKey,
+	storageClass string, fileSize, fileMtime, timeOfgetObjectInfo int64, restart bool) error {
+
+	err := h.copyObject(bosClient, bosClient, bucketName, objectKey, bucketName, objectKey,
+		storageClass, fileSize, fileMtime, timeOfgetObjectInfo, restart, nil)
+	if err == nil {
+		bosPath := BOS_PATH_PREFIX + bucketName + "/" + objectKey
+		printIfNotQuiet("%s: %s to %s\n", SYNC_OP_SET_STORAGE_CLASS, bosPath, storageClass)
+		printOperationRecord(SYNC_OP_SET_STORAGE_CLASS, OPERATION_STATUS_SUCCESS, bosPath,
+			bosPath, nil)
+	}
+	return err
+}
+
+// recover an object in the trash of bucket by copying it back to its original key
+func (h *cliHandler) utilUndeleteObject(bosClient bosClientInterface, bucketName, trashKey,
+	objectKey, storageClass string, fileSize, fileMtime, timeOfgetObjectInfo int64) error {
+
+	err := h.copyObject(bosClient, bosClient, bucketName, trashKey, bucketName, objectKey,
+		storageClass, fileSize, fileMtime, timeOfgetObjectInfo, false, nil)
+	if err == nil {
+		srcPath := BOS_PATH_PREFIX + bucketName + "/" + trashKey
+		dstPath := BOS_PATH_PREFIX + bucketName + "/" + objectKey
+		printIfNotQuiet("%s: %s to %s\n", SYNC_OP_UNDELETE, srcPath, dstPath)
+		printOperationRecord(SYNC_OP_UNDELETE, OPERATION_STATUS_SUCCESS, srcPath, dstPath, nil)
+	}
+	return err
+}
+
+// copy an object by CopyObject, or by multipart copy when it is larger than the threshold
+func (h *cliHandler) copyObject(srcBosClient, bosClient bosClientInterface, srcBucketName,
+	srcObjectKey, dstBucketName, dstObjectKey, storageClass string, fileSize, fileMtime,
+	timeOfgetObjectInfo int64, restart bool, meta *objectMetaArgs) error {
 
 	var (
 		err error
@@ -418,26 +488,49 @@ func (h *cliHandler) utilCopyObject(srcBosClient, bosClient bosClientInterface,
 		// multi copy
 		err = h.CopySuperFile(srcBosClient, bosClient, srcBucketName, srcObjectKey, dstBucketName,
 			dstObjectKey, storageClass, fileSize, fileMtime, timeOfgetObjectInfo, restart,
-			"Copying")
+			"Copying", meta)
 		// retry?
 		if err != nil && multiUploadNeedRetry(err) {
 			// this upload id might have been aborted or completed, so, retry and restart!
 			err = h.CopySuperFile(srcBosClient, bosClient, srcBucketName, srcObjectKey,
 				dstBucketName, dstObjectKey, storageClass, fileSize, fileMtime,
-				timeOfgetObjectInfo, true, "Retry Copying")
+				timeOfgetObjectInfo, true, "Retry Copying", meta)
 		}
 	} else {
 		// common copy
-		args := new(api.CopyObjectArgs)
-		args.StorageClass = storageClass
-		_, err = bosClient.CopyObject(dstBucketName, dstObjectKey, srcBucketName, srcObjectKey, args)
+		args := meta.copyObjectArgs(storageClass)
+		_, err = newMetaBosClient(bosClient, meta).CopyObject(dstBucketName, dstObjectKey,
+			srcBucketName, srcObjectKey, args)
 	}
+	return err
+}
 
-	if err == nil {
-		printIfNotQuiet("Copy: %s%s/%s to %s%s/%s\n", BOS_PATH_PREFIX, srcBucketName, srcObjectKey,
-			BOS_PATH_PREFIX, dstBucketName, dstObjectKey)
+// metadata of the object created by multipart copy, BOS doesn't copy the metadata of source
+// by multipart copy as it does by copy object, so it is got by head object unless it is
+// replaced by the given metadata. The canned ACL given by --acl is kept in both cases.
+func multipartCopyMeta(srcBosClient bosClientInterface, srcBucketName, srcObjectKey string,
+	meta *objectMetaArgs) (*objectMetaArgs, error) {
+
+	if meta != nil && meta.metadataDirective == api.METADATA_DIRECTIVE_REPLACE {
+		return meta, nil
 	}
-	return err
+	ret, err := srcBosClient.GetObjectMeta(srcBucketName, srcObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	cannedAcl := ""
+	if meta != nil {
+		cannedAcl = meta.cannedAcl
+	}
+	return &objectMetaArgs{
+		contentType:        ret.ContentType,
+		cacheControl:       ret.CacheControl,
+		contentDisposition: ret.ContentDisposition,
+		contentEncoding:    ret.ContentEncoding,
+		expires:            ret.Expires,
+		userMeta:           ret.UserMeta,
+		cannedAcl:          cannedAcl,
+	}, nil
 }
 
 // download an object to local
@@ -445,15 +538,52 @@ func (h *cliHandler) utilDownloadObject(bosClient bosClientInterface, srcBucketN
 	dstFilePath, downLoadTmp string, yes bool, fileSize, mtime, timeOfgetObjectInfo int64,
 	restart bool) error {
 
+	finalFileName, err := getDownloadFileName(srcObjectKey, dstFilePath, yes)
+	if err != nil {
+		return err
+	}
+
+	// need to get the basic information of this file
+	if fileSize == 0 && mtime == 0 {
+		ret, err := getObjectMeta(bosClient, srcBucketName, srcObjectKey)
+		if err != nil {
+			return err
+		}
+		fileSize = ret.size
+		mtime = ret.mtime
+		timeOfgetObjectInfo = ret.gtime
+	}
+
+	// start to download object to local
+	if fileSize < MULTI_DOWNLOAD_THRESHOLD {
+		// download small file
+		err = bosClient.BasicGetObjectToFile(srcBucketName, srcObjectKey, finalFileName)
+	} else {
+		// download super file
+		err = h.DownloadSuperFile(bosClient, srcBucketName, srcObjectKey, finalFileName,
+			"Downloading", downLoadTmp, fileSize, mtime, timeOfgetObjectInfo, restart)
+	}
+	if err != nil {
+		return err
+	}
+	printIfNotQuiet("Download: %s%s/%s to %s\n", BOS_PATH_PREFIX, srcBucketName, srcObjectKey,
+		finalFileName)
+	printOperationRecord(SYNC_OP_DOWNLOAD, OPERATION_STATUS_SUCCESS,
+		BOS_PATH_PREFIX+srcBucketName+"/"+srcObjectKey, finalFileName, nil)
+	return nil
+}
+
+// get the local file name which an object is downloaded to, and make sure its parent directory
+// exists. When the file exists, the user is asked whether to cover it unless yes is true.
+func getDownloadFileName(srcObjectKey, dstFilePath string, yes bool) (string, error) {
 	var (
 		dstPathEndwithSep bool
 		finalFileName     string
-		err               error
 	)
 
 	srcObjectName := getObjectNameFromObjectKey(srcObjectKey)
 	if srcObjectName == "" {
-		return fmt.Errorf("Object name error %s", srcObjectKey)
+		return "", fmt.Errorf("Object name error %s", srcObjectKey)
 	}
 
 	dstFilePath = replaceToOsPath(dstFilePath)
@@ -463,7 +593,7 @@ func (h *cliHandler) utilDownloadObject(bosClient bosClientInterface, srcBucketN
 
 	absFileName, err := util.Abs(dstFilePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// generate final file name
@@ -472,7 +602,7 @@ func (h *cliHandler) utilDownloadObject(bosClient bosClientInterface, srcBucketN
 		if util.DoesDirExist(absFileName) {
 			finalFileName = filepath.Join(absFileName, srcObjectName)
 		} else if dstPathEndwithSep {
-			return fmt.Errorf("Can't download file, because file %s exists", absFileName)
+			return "", fmt.Errorf("Can't download file, because file %s exists", absFileName)
 		} else {
 			finalFileName = absFileName
 		}
@@ -480,14 +610,14 @@ func (h *cliHandler) utilDownloadObject(bosClient bosClientInterface, srcBucketN
 		if dstPathEndwithSep {
 			err = util.TryMkdir(absFileName)
 			if err != nil {
-				return err
+				return "", err
 			}
 			finalFileName = filepath.Join(absFileName, srcObjectName)
 		} else {
 			splitPath, _ := splitPathAndFile(absFileName)
 			err = util.TryMkdir(splitPath)
 			if err != nil {
-				return err
+				return "", err
 			}
 			finalFileName = absFileName
 		}
@@ -499,37 +629,201 @@ func (h *cliHandler) utilDownloadObject(bosClient bosClientInterface, srcBucketN
 			yes = util.PromptConfirm("Will you cover the existing file %s?", finalFileName)
 		}
 		if !yes {
-			return fmt.Errorf("Download abort for existing file.")
+			return "", fmt.Errorf("Download abort for existing file.")
 		} else if !util.IsFileWritable(finalFileName) {
-			return fmt.Errorf("Download abort for covering on a existing file not writeable.")
+			return "", fmt.Errorf("Download abort for covering on a existing file not " +
+				"writeable.")
 		}
 	}
+	return finalFileName, nil
+}
 
-	// need to get the basic information of this file
-	if fileSize == 0 && mtime == 0 {
-		ret, err := getObjectMeta(bosClient, srcBucketName, srcObjectKey)
+// download a byte range of an object to local file.
+// end < 0 means reading to the end of the object.
+func (h *cliHandler) utilDownloadObjectRange(bosClient bosClientInterface, srcBucketName,
+	srcObjectKey, dstFilePath string, yes bool, start, end int64) error {
+
+	finalFileName, err := getDownloadFileName(srcObjectKey, dstFilePath, yes)
+	if err != nil {
+		return err
+	}
+	fd, err := os.OpenFile(finalFileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	err = h.utilGetObjectRange(bosClient, srcBucketName, srcObjectKey, start, end, false, fd)
+	if closeErr := fd.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// do not leave a partial file which looks like a complete range
+		os.Remove(finalFileName)
+		return err
+	}
+	printIfNotQuiet("Download: %s%s/%s to %s\n", BOS_PATH_PREFIX, srcBucketName, srcObjectKey,
+		finalFileName)
+	printOperationRecord(SYNC_OP_DOWNLOAD, OPERATION_STATUS_SUCCESS,
+		BOS_PATH_PREFIX+srcBucketName+"/"+srcObjectKey, finalFileName, nil)
+	return nil
+}
+
+// read a byte range of an object and write it to stream.
+// start = 0 and end < 0 means the whole object, end < 0 means reading to the end of the object.
+// When decompress is true, the content is decompressed by gzip before written to stream.
+func (h *cliHandler) utilGetObjectRange(bosClient bosClientInterface, srcBucketName,
+	srcObjectKey string, start, end int64, decompress bool, stream io.Writer) error {
+
+	var ranges []int64
+	if end >= 0 {
+		ranges = []int64{start, end}
+	} else if start > 0 {
+		ranges = []int64{start}
+	}
+
+	res, err := bosClient.GetObject(srcBucketName, srcObjectKey, nil, ranges...)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var reader io.Reader = res.Body
+	if decompress {
+		gzipReader, err := gzip.NewReader(res.Body)
+		if err != nil {
+			return fmt.Errorf("Can not decompress object %s: %s", srcObjectKey, err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	buf := make([]byte, STREAM_DOWNLOAD_BUF_SIZE)
+	_, err = io.CopyBuffer(stream, reader, buf)
+	return err
+}
+
+// download an object and write it to stream.
+// Large object is downloaded by ranged get in parallel, and the parts are written to stream in
+// order. At most multi_upload_thread_num parts are kept in memory.
+func (h *cliHandler) utilDownloadToStream(bosClient bosClientInterface, srcBucketName,
+	srcObjectKey string, stream io.Writer) error {
+
+	objectMeta, err := getObjectMeta(bosClient, srcBucketName, srcObjectKey)
+	if err != nil {
+		return err
+	}
+
+	if objectMeta.size < MULTI_DOWNLOAD_THRESHOLD {
+		res, err := bosClient.GetObject(srcBucketName, srcObjectKey, nil)
 		if err != nil {
 			return err
 		}
-		fileSize = ret.size
-		mtime = ret.mtime
-		timeOfgetObjectInfo = ret.gtime
+		defer res.Body.Close()
+		buf := make([]byte, STREAM_DOWNLOAD_BUF_SIZE)
+		if _, err := io.CopyBuffer(stream, res.Body, buf); err != nil {
+			return err
+		}
+	} else if err := h.downloadStreamParts(bosClient, srcBucketName, srcObjectKey,
+		objectMeta.size, stream); err != nil {
+		return err
 	}
+	printIfNotQuiet("Download: %s%s/%s to -\n", BOS_PATH_PREFIX, srcBucketName, srcObjectKey)
+	printOperationRecord(SYNC_OP_DOWNLOAD, OPERATION_STATUS_SUCCESS,
+		BOS_PATH_PREFIX+srcBucketName+"/"+srcObjectKey, "-", nil)
+	return nil
+}
 
-	// start to download object to local
-	if fileSize < MULTI_DOWNLOAD_THRESHOLD {
-		// download small file
-		err = bosClient.BasicGetObjectToFile(srcBucketName, srcObjectKey, finalFileName)
-	} else {
-		// download super file
-		err = h.DownloadSuperFile(bosClient, srcBucketName, srcObjectKey, finalFileName,
-			"Downloading", downLoadTmp, fileSize, mtime, timeOfgetObjectInfo, restart)
+type streamPart struct {
+	body []byte
+	err  error
+}
+
+// download parts of object in parallel and write them to stream in order.
+// A part is downloaded only when there are less than `threadNum' parts waiting to be written.
+func (h *cliHandler) downloadStreamParts(bosClient bosClientInterface, srcBucketName,
+	srcObjectKey string, fileSize int64, stream io.Writer) error {
+
+	// get multi download part size
+	multiDownloadPartSize, ok := bceconf.ServerConfigProvider.GetMultiUploadPartSize()
+	if !ok {
+		return fmt.Errorf("There is no info about multi download part size found!")
+	}
+	partSize := multiDownloadPartSize * (1 << 20)
+	if partSize <= 0 {
+		partSize = PART_SIZE_BASE
 	}
+	partsNum := (fileSize + partSize - 1) / partSize
+
+	multiDownloadThreadNum, ok := bceconf.ServerConfigProvider.GetMultiUploadThreadNum()
+	if !ok {
+		return fmt.Errorf("There is no info about multi download thread Num found!")
+	}
+
+	// for progress bar
+	bar, err := util.NewBar(int(partsNum), "Downloading", Quiet || DisableBar)
 	if err != nil {
 		return err
 	}
-	printIfNotQuiet("Download: %s%s/%s to %s\n", BOS_PATH_PREFIX, srcBucketName, srcObjectKey,
-		finalFileName)
+	bar.SetOutput(msgOutput)
+	util.GFinisher.Insert(bar)
+	defer func() {
+		bar.Exit()
+		util.GFinisher.Remove(bar)
+	}()
+
+	downloadPart := func(partId int64, partChan chan *streamPart) {
+		rangeStart := partId * partSize
+		rangeEnd := rangeStart + partSize
+		if rangeEnd > fileSize {
+			rangeEnd = fileSize
+		}
+		res, err := bosClient.GetObject(srcBucketName, srcObjectKey, nil, rangeStart,
+			rangeEnd-1)
+		if err != nil {
+			partChan <- &streamPart{err: err}
+			return
+		}
+		defer res.Body.Close()
+		body := make([]byte, rangeEnd-rangeStart)
+		if _, err := io.ReadFull(res.Body, body); err != nil {
+			partChan <- &streamPart{err: err}
+			return
+		}
+		log.Debugf("bos:/%s/%s download part %d with offset=%d done", srcBucketName,
+			srcObjectKey, partId, rangeStart)
+		partChan <- &streamPart{body: body}
+	}
+
+	// every part has its own channel, so that the parts can be written in order
+	partChans := make([]chan *streamPart, partsNum)
+	for i := range partChans {
+		partChans[i] = make(chan *streamPart, 1)
+	}
+	window := make(chan struct{}, multiDownloadThreadNum)
+	quit := make(chan struct{})
+	defer close(quit)
+
+	go func() {
+		for partId := int64(0); partId < partsNum; partId++ {
+			select {
+			case window <- struct{}{}:
+				go downloadPart(partId, partChans[partId])
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	for partId := int64(0); partId < partsNum; partId++ {
+		part := <-partChans[partId]
+		if part.err != nil {
+			return part.err
+		}
+		if _, err := stream.Write(part.body); err != nil {
+			return err
+		}
+		<-window
+		bar.Finish(int(partId + 1))
+	}
 	return nil
 }
 
@@ -614,6 +908,7 @@ func (h *cliHandler) DownloadSuperFile(bosClient bosClientInterface, srcBucketNa
 	if err != nil {
 		return err
 	}
+	bar.SetOutput(msgOutput)
 	util.GFinisher.Insert(bar)
 	defer func() {
 		bar.Exit()
@@ -751,23 +1046,25 @@ func (h *cliHandler) DownloadSuperFile(bosClient bosClientInterface, srcBucketNa
 // upload a file
 func (h *cliHandler) utilUploadFile(bosClient bosClientInterface, srcPath, relSrcPath,
 	dstBucketName, dstObjectKey, storageClass string, fileSize, fileMtime,
-	timeOfgetObjectInfo int64, restart bool) error {
+	timeOfgetObjectInfo int64, restart bool, meta *objectMetaArgs) error {
 
 	var (
 		err error
 	)
 
+	meta = meta.withGuessedContentType(srcPath, relSrcPath)
+	bosClient = newMetaBosClient(bosClient, meta)
 	if fileSize > MULTI_UPLOAD_THRESHOLD {
 		err = h.UploadSuperFile(bosClient, relSrcPath, dstBucketName, dstObjectKey, storageClass,
-			fileSize, fileMtime, timeOfgetObjectInfo, restart, "Uploading")
+			fileSize, fileMtime, timeOfgetObjectInfo, restart, "Uploading", meta)
 		if err != nil && multiUploadNeedRetry(err) {
 			//this upload id might have been aborted or completed, so, retry and restart!
 			err = h.UploadSuperFile(bosClient, relSrcPath, dstBucketName, dstObjectKey,
-				storageClass, fileSize, fileMtime, timeOfgetObjectInfo, true, "Retry Uploading")
+				storageClass, fileSize, fileMtime, timeOfgetObjectInfo, true, "Retry Uploading",
+				meta)
 		}
 	} else {
-		// TODO putObject of go sdk don't have interface for storage-class
-		args := &api.PutObjectArgs{StorageClass: storageClass}
+		args := meta.putObjectArgs(storageClass)
 		_, err = bosClient.PutObjectFromFile(dstBucketName, dstObjectKey, relSrcPath, args)
 	}
 
@@ -776,13 +1073,15 @@ func (h *cliHandler) utilUploadFile(bosClient bosClientInterface, srcPath, relSr
 	}
 	printIfNotQuiet("Upload: %s to %s%s/%s\n", srcPath, BOS_PATH_PREFIX, dstBucketName,
 		dstObjectKey)
+	printOperationRecord(SYNC_OP_UPLOAD, OPERATION_STATUS_SUCCESS, srcPath,
+		BOS_PATH_PREFIX+dstBucketName+"/"+dstObjectKey, nil)
 	return nil
 }
 
 // UploadSuperFile - parallel upload the super file by using the multipart upload interface
 func (h *cliHandler) UploadSuperFile(bosClient bosClientInterface, srcPath, dstBucketName,
 	dstObjectKey, storageClass string, fileSize, mtime, timeOfgetObjectInfo int64,
-	restart bool, testPrefix string) error {
+	restart bool, testPrefix string, meta *objectMetaArgs) error {
 
 	var (
 		content *MultiTaskContent
@@ -814,7 +1113,7 @@ func (h *cliHandler) UploadSuperFile(bosClient bosClientInterface, srcPath, dstB
 	}
 
 	if fileSize < MULTI_UPLOAD_THRESHOLD {
-		args := &api.PutObjectArgs{StorageClass: storageClass}
+		args := meta.putObjectArgs(storageClass)
 		_, err := bosClient.PutObjectFromFile(dstBucketName, dstObjectKey, srcPath, args)
 		return err
 	}
@@ -851,8 +1150,9 @@ func (h *cliHandler) UploadSuperFile(bosClient bosClientInterface, srcPath, dstB
 
 	// Do the parallel multipart upload
 	if content.needRestart {
-		resp, err := bosClient.InitiateMultipartUpload(dstBucketName, dstObjectKey, "",
-			&api.InitiateMultipartUploadArgs{StorageClass: storageClass})
+		contentType, initArgs := meta.initiateMultipartUploadArgs(storageClass)
+		resp, err := bosClient.InitiateMultipartUpload(dstBucketName, dstObjectKey, contentType,
+			initArgs)
 		if err != nil {
 			return err
 		}
@@ -864,6 +1164,7 @@ func (h *cliHandler) UploadSuperFile(bosClient bosClientInterface, srcPath, dstB
 	if err != nil {
 		return err
 	}
+	bar.SetOutput(msgOutput)
 
 	util.GFinisher.Insert(bar)
 	defer func() {
@@ -977,6 +1278,193 @@ func (h *cliHandler) UploadSuperFile(bosClient bosClientInterface, srcPath, dstB
 	return nil
 }
 
+// streamUploadTask record a multipart upload of stream, the upload will be aborted when CLI is
+// interrupted.
+type streamUploadTask struct {
+	bosClient     bosClientInterface
+	dstBucketName string
+	dstObjectKey  string
+	uploadId      string
+}
+
+// abort the multipart upload
+func (s *streamUploadTask) Exit() error {
+	return s.bosClient.AbortMultipartUpload(s.dstBucketName, s.dstObjectKey, s.uploadId)
+}
+
+func (s *streamUploadTask) GetId() (string, error) {
+	if s.uploadId == "" {
+		return "", fmt.Errorf("upload ID is empty!")
+	}
+	return s.uploadId, nil
+}
+
+// upload data read from stream to bos.
+// When the size of stream is smaller than multi upload part size, use put object, otherwise
+// use multipart upload. At most multi_upload_thread_num parts are kept in memory.
+func (h *cliHandler) utilUploadStream(bosClient bosClientInterface, stream io.Reader,
+	dstBucketName, dstObjectKey, storageClass string, meta *objectMetaArgs) error {
+
+	// get multi upload part size
+	multiUploadPartSize, ok := bceconf.ServerConfigProvider.GetMultiUploadPartSize()
+	if !ok {
+		return fmt.Errorf("There is no info about multi upload part size found!")
+	}
+	partSize := multiUploadPartSize * (1 << 20)
+	if partSize <= 0 {
+		partSize = PART_SIZE_BASE
+	}
+
+	// get multi upload thread num
+	multiUploadThreadNum, ok := bceconf.ServerConfigProvider.GetMultiUploadThreadNum()
+	if !ok {
+		return fmt.Errorf("There is no info about multi upload thread Num found!")
+	}
+
+	// read the first part, if stream ends in it, just put object
+	bosClient = newMetaBosClient(bosClient, meta)
+	firstPart := make([]byte, partSize)
+	n, err := io.ReadFull(stream, firstPart)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		args := meta.putObjectArgs(storageClass)
+		_, err := bosClient.PutObjectFromBytes(dstBucketName, dstObjectKey, firstPart[:n], args)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	contentType, initArgs := meta.initiateMultipartUploadArgs(storageClass)
+	resp, err := bosClient.InitiateMultipartUpload(dstBucketName, dstObjectKey, contentType,
+		initArgs)
+	if err != nil {
+		return err
+	}
+	task := &streamUploadTask{
+		bosClient:     bosClient,
+		dstBucketName: dstBucketName,
+		dstObjectKey:  dstObjectKey,
+		uploadId:      resp.UploadId,
+	}
+	util.GFinisher.Insert(task)
+	defer util.GFinisher.Remove(task)
+
+	completeArgs, err := h.uploadStreamParts(bosClient, stream, task, firstPart,
+		multiUploadThreadNum)
+	if err == nil {
+		_, err = bosClient.CompleteMultipartUploadFromStruct(dstBucketName, dstObjectKey,
+			task.uploadId, completeArgs)
+	}
+	if err != nil {
+		if abortErr := task.Exit(); abortErr != nil {
+			log.Debugf("abort upload %s of - => bos:/%s/%s failed: %s", task.uploadId,
+				dstBucketName, dstObjectKey, abortErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// read stream part by part and upload them in parallel.
+// The buffer of a part is reused after it has been uploaded, so there are no more than
+// `threadNum' buffers.
+func (h *cliHandler) uploadStreamParts(bosClient bosClientInterface, stream io.Reader,
+	task *streamUploadTask, firstPart []byte, threadNum int64) (
+	*api.CompleteMultipartUploadArgs, error) {
+
+	var (
+		partsLock sync.Mutex
+		uploadErr error
+		readErr   error
+		wg        sync.WaitGroup
+	)
+	completeArgs := &api.CompleteMultipartUploadArgs{}
+
+	// free buffers, nil means the buffer has not been allocated yet
+	bufPool := make(chan []byte, threadNum)
+	for i := int64(1); i < threadNum; i++ {
+		bufPool <- nil
+	}
+
+	uploadPart := func(partNumber int, partBody []byte) {
+		defer wg.Done()
+		etag, err := bosClient.UploadPartFromBytes(task.dstBucketName, task.dstObjectKey,
+			task.uploadId, partNumber, partBody, nil)
+		if err == nil && etag == "" {
+			err = fmt.Errorf("get a empty etag when upload part %d", partNumber)
+		}
+
+		partsLock.Lock()
+		if err != nil {
+			log.Debugf("failed upload part %d from - => bos:/%s/%s, error is %s", partNumber,
+				task.dstBucketName, task.dstObjectKey, err)
+			if uploadErr == nil {
+				uploadErr = err
+			}
+		} else {
+			log.Debugf("finish upload part %d from - => bos:/%s/%s, etag is %s", partNumber,
+				task.dstBucketName, task.dstObjectKey, etag)
+			completeArgs.Parts = append(completeArgs.Parts, api.UploadInfoType{
+				PartNumber: partNumber,
+				ETag:       etag,
+			})
+		}
+		partsLock.Unlock()
+		bufPool <- partBody[:cap(partBody)]
+	}
+
+	partBody := firstPart
+	streamEnded := false
+	for partNumber := 1; ; partNumber++ {
+		if partNumber > MAX_PARTS {
+			readErr = fmt.Errorf("The number of parts exceeds %d, please increase "+
+				"multi_upload_part_size", MAX_PARTS)
+			break
+		}
+		wg.Add(1)
+		go uploadPart(partNumber, partBody)
+		if streamEnded {
+			break
+		}
+
+		// wait until get a free buffer
+		buf := <-bufPool
+		if buf == nil {
+			buf = make([]byte, len(firstPart))
+		}
+
+		// stop reading when any part failed
+		partsLock.Lock()
+		failed := uploadErr != nil
+		partsLock.Unlock()
+		if failed {
+			break
+		}
+
+		n, err := io.ReadFull(stream, buf)
+		if err == io.EOF {
+			break
+		} else if err == io.ErrUnexpectedEOF {
+			streamEnded = true
+		} else if err != nil {
+			readErr = err
+			break
+		}
+		partBody = buf[:n]
+	}
+	wg.Wait()
+
+	if readErr != nil {
+		return nil, readErr
+	}
+	if uploadErr != nil {
+		return nil, uploadErr
+	}
+	sort.Slice(completeArgs.Parts, func(i, j int) bool {
+		return completeArgs.Parts[i].PartNumber < completeArgs.Parts[j].PartNumber
+	})
+	return completeArgs, nil
+}
+
 // delete local file
 func (h *cliHandler) utilDeleteLocalFile(localPath string) error {
 	if util.DoesDirExist(localPath) {
@@ -985,6 +1473,7 @@ func (h *cliHandler) utilDeleteLocalFile(localPath string) error {
 	err := os.Remove(localPath)
 	if err == nil {
 		printIfNotQuiet("Delete file: %s\n", localPath)
+		printOperationRecord(SYNC_OP_DELETE, OPERATION_STATUS_SUCCESS, "", localPath, nil)
 	}
 	return err
 }
@@ -1022,7 +1511,7 @@ func (h *cliHandler) printDelResult(bucketName string, keyList []string,
 		delListIndex int
 	)
 
-	if Quiet {
+	if Quiet && !isStructuredOutput() {
 		return
 	}
 
@@ -1030,9 +1519,11 @@ func (h *cliHandler) printDelResult(bucketName string, keyList []string,
 	lenDelList := len(unDelObjects)
 
 	unDelList := make([]string, lenDelList)
+	unDelErrors := make(map[string]string, lenDelList)
 
 	for i, unDelObject := range unDelObjects {
 		unDelList[i] = unDelObject.Key
+		unDelErrors[unDelObject.Key] = unDelObject.Message
 	}
 	sort.Strings(unDelList)
 
@@ -1041,11 +1532,16 @@ func (h *cliHandler) printDelResult(bucketName string, keyList []string,
 			if keyList[keyListIndex] == unDelList[delListIndex] {
 				printIfNotQuiet("Failed delete object: %s%s/%s\n", BOS_PATH_PREFIX, bucketName,
 					keyList[keyListIndex])
+				printOperationRecord(SYNC_OP_REMOVE, OPERATION_STATUS_FAILED, "",
+					BOS_PATH_PREFIX+bucketName+"/"+keyList[keyListIndex],
+					fmt.Errorf("%s", unDelErrors[keyList[keyListIndex]]))
 				delListIndex += 1
 				keyListIndex += 1
 			} else if keyList[keyListIndex] < unDelList[delListIndex] {
 				printIfNotQuiet("Delete object: %s%s/%s\n", BOS_PATH_PREFIX, bucketName,
 					keyList[keyListIndex])
+				printOperationRecord(SYNC_OP_REMOVE, OPERATION_STATUS_SUCCESS, "",
+					BOS_PATH_PREFIX+bucketName+"/"+keyList[keyListIndex], nil)
 				keyListIndex += 1
 			} else {
 				// TODO TEST print info
@@ -1055,9 +1551,11 @@ func (h *cliHandler) printDelResult(bucketName string, keyList []string,
 		} else if keyListIndex < lenKeyList {
 			printIfNotQuiet("Delete object: %s%s/%s\n", BOS_PATH_PREFIX, bucketName,
 				keyList[keyListIndex])
+			printOperationRecord(SYNC_OP_REMOVE, OPERATION_STATUS_SUCCESS, "",
+				BOS_PATH_PREFIX+bucketName+"/"+keyList[keyListIndex], nil)
 			keyListIndex += 1
 		} else if delListIndex < lenDelList {
-			fmt.Printf("Del list: '%s'\n", unDelList[delListIndex])
+			printIfNotQuiet("Del list: '%s'\n", unDelList[delListIndex])
 			delListIndex += 1
 		} else {
 			break
@@ -1096,7 +1594,7 @@ func (h *cliHandler) GetObjectMd5(bosClient bosClientInterface, bucketName, obje
 // CopySuperFile - parallel upload the super file by using the multipart upload interface
 func (h *cliHandler) CopySuperFile(srcBosClient, bosClient bosClientInterface, srcBucketName,
 	srcObjectKey, dstBucketName, dstObjectKey, storageClass string, fileSize, mtime,
-	timeOfgetObjectInfo int64, restart bool, testPrefix string) error {
+	timeOfgetObjectInfo int64, restart bool, testPrefix string, meta *objectMetaArgs) error {
 
 	var (
 		content *MultiTaskContent
@@ -1126,8 +1624,7 @@ func (h *cliHandler) CopySuperFile(srcBosClient, bosClient bosClientInterface, s
 	}
 
 	if fileSize < MULTI_COPY_THRESHOLD {
-		args := new(api.CopyObjectArgs)
-		args.StorageClass = storageClass
+		args := meta.copyObjectArgs(storageClass)
 		_, err := bosClient.CopyObject(dstBucketName, dstObjectKey, srcBucketName, srcObjectKey, args)
 		return err
 	}
@@ -1151,8 +1648,13 @@ func (h *cliHandler) CopySuperFile(srcBosClient, bosClient bosClientInterface, s
 
 	// Do the parallel multipart upload
 	if content.needRestart {
-		resp, err := bosClient.InitiateMultipartUpload(dstBucketName, dstObjectKey, "",
-			&api.InitiateMultipartUploadArgs{StorageClass: storageClass})
+		initMeta, err := multipartCopyMeta(srcBosClient, srcBucketName, srcObjectKey, meta)
+		if err != nil {
+			return err
+		}
+		contentType, initArgs := initMeta.initiateMultipartUploadArgs(storageClass)
+		resp, err := newMetaBosClient(bosClient, initMeta).InitiateMultipartUpload(dstBucketName,
+			dstObjectKey, contentType, initArgs)
 		if err != nil {
 			return err
 		}
@@ -1164,6 +1666,7 @@ func (h *cliHandler) CopySuperFile(srcBosClient, bosClient bosClientInterface, s
 	if err != nil {
 		return err
 	}
+	bar.SetOutput(msgOutput)
 	util.GFinisher.Insert(bar)
 	defer func() {
 		bar.Exit()
@@ -1305,6 +1808,8 @@ func getObjectMeta(bosClient bosClientInterface, bucketName,
 		gtime:        time.Now().Unix(),
 		size:         getMetaRet.ContentLength,
 		storageClass: getMetaRet.StorageClass,
+		restore:      getMetaRet.BceRestore,
 		crc32:        getMetaRet.ContentCrc32,
+		etag:         getMetaRet.ETag,
 	}, nil
 }
@@ -13,7 +13,11 @@
 package boscli
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"strconv"
@@ -23,7 +27,7 @@ import (
 )
 
 import (
-	// 	"bceconf"
+	"bceconf"
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/services/bos/api"
 	"utils/util"
@@ -40,8 +44,13 @@ func init() {
 }
 
 type fakeBosClient struct {
-	results    []*api.ListObjectsResult
-	objectMeta *api.GetObjectMetaResult
+	results         []*api.ListObjectsResult
+	objectMeta      *api.GetObjectMetaResult
+	completedParts  []api.UploadInfoType
+	abortedUploadId string
+	content         []byte
+	initContentType string
+	copyObjectArgs  *api.CopyObjectArgs
 }
 
 func (b *fakeBosClient) HeadBucket(bucket string) error {
@@ -141,6 +150,21 @@ func (b *fakeBosClient) DeleteMultipleObjectsFromKeyList(bucket string,
 	return nil, nil
 }
 
+// Fake RestoreObject - restore an archived object
+func (b *fakeBosClient) RestoreObject(bucket, object string, restoreDays int,
+	restoreTier string) error {
+	return nil
+}
+
+func (b *fakeBosClient) PutSymlink(bucket, object, symlinkKey string,
+	symlinkArgs *api.PutSymlinkArgs) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) GetSymlink(bucket, object string) (string, error) {
+	return "", fmt.Errorf("Not support")
+}
+
 // Fake DeleteObject - delete the given object
 func (b *fakeBosClient) DeleteObject(bucket, object string) error {
 	if bucket == "error" {
@@ -171,6 +195,7 @@ func (b *fakeBosClient) CopyObject(bucket, object, srcBucket, srcObject string,
 	if bucket == "" || object == "" || srcBucket == "" || srcObject == "" {
 		return nil, fmt.Errorf("args error")
 	}
+	b.copyObjectArgs = args
 	return nil, nil
 }
 
@@ -191,6 +216,27 @@ func (b *fakeBosClient) PutObjectFromFile(bucket, object, fileName string,
 	return "", fmt.Errorf("smail" + fileName + bucket + object + args.StorageClass)
 }
 
+// Fake of PutObjectFromBytes
+func (b *fakeBosClient) PutObjectFromBytes(bucket, object string, bytesArr []byte,
+	args *api.PutObjectArgs) (string, error) {
+	if bucket == "success" {
+		return "", nil
+	}
+	return "", fmt.Errorf("bytes%s%s%d", bucket, object, len(bytesArr))
+}
+
+// Fake of AppendObject
+func (b *fakeBosClient) AppendObject(bucket, object string, content *bce.Body,
+	args *api.AppendObjectArgs) (*api.AppendObjectResult, error) {
+	return nil, fmt.Errorf("Not support")
+}
+
+// Fake of FetchObject
+func (b *fakeBosClient) FetchObject(bucket, object, source string,
+	args *api.FetchObjectArgs) (*api.FetchObjectResult, error) {
+	return nil, fmt.Errorf("Not support")
+}
+
 // Fake of UploadSuperFile
 func (b *fakeBosClient) UploadSuperFile(bucket, object, fileName, storageClass string) error {
 	if fileName == "success" {
@@ -206,6 +252,9 @@ func (b *fakeBosClient) BasicUploadPart(bucket, object, uploadId string, partNum
 
 func (b *fakeBosClient) UploadPartFromBytes(bucket, object, uploadId string, partNumber int,
 	content []byte, args *api.UploadPartArgs) (string, error) {
+	if bucket == "stream" && object != "partError" {
+		return "etag" + strconv.Itoa(partNumber), nil
+	}
 	return "", fmt.Errorf("Not support")
 }
 
@@ -251,6 +300,79 @@ func (b *fakeBosClient) GetBucketStorageclass(bucket string) (string, error) {
 	return "", nil
 }
 
+func (b *fakeBosClient) PutBucketCorsFromString(bucket, cors string) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) GetBucketCors(bucket string) (*api.GetBucketCorsResult, error) {
+	return nil, fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) DeleteBucketCors(bucket string) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) PutBucketReplicationFromString(bucket, conf, ruleId string) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) GetBucketReplication(bucket, ruleId string) (
+	*api.GetBucketReplicationResult, error) {
+	return nil, fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) ListBucketReplication(bucket string) (
+	*api.ListBucketReplicationResult, error) {
+	return nil, fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) DeleteBucketReplication(bucket, ruleId string) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) GetBucketReplicationProgress(bucket, ruleId string) (
+	*api.GetBucketReplicationProgressResult, error) {
+	return nil, fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) PutBucketEncryption(bucket, algorithm string) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) GetBucketEncryption(bucket string) (string, error) {
+	return "", fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) DeleteBucketEncryption(bucket string) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) PutBucketStaticWebsiteFromStruct(bucket string,
+	args *api.PutBucketStaticWebsiteArgs) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) GetBucketStaticWebsite(bucket string) (
+	*api.GetBucketStaticWebsiteResult, error) {
+	return nil, fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) DeleteBucketStaticWebsite(bucket string) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) PutBucketTrash(bucket string, trashReq api.PutBucketTrashReq) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) GetBucketTrash(bucket string) (*api.GetBucketTrashResult, error) {
+	return nil, fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) DeleteBucketTrash(bucket string) error {
+	return fmt.Errorf("Not support")
+}
+
 // Fake of PutBucketAclFromCanned
 func (b *fakeBosClient) PutBucketAclFromCanned(bucket, cannedAcl string) error {
 	return nil
@@ -261,6 +383,22 @@ func (b *fakeBosClient) PutBucketAclFromString(bucket, acl string) error {
 	return nil
 }
 
+func (b *fakeBosClient) PutObjectAclFromCanned(bucket, object, cannedAcl string) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) PutObjectAclFromString(bucket, object, acl string) error {
+	return fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) GetObjectAcl(bucket, object string) (*api.GetObjectAclResult, error) {
+	return nil, fmt.Errorf("Not support")
+}
+
+func (b *fakeBosClient) DeleteObjectAcl(bucket, object string) error {
+	return fmt.Errorf("Not support")
+}
+
 // Fake of GetBucketAcl
 func (b *fakeBosClient) GetBucketAcl(bucket string) (*api.GetBucketAclResult, error) {
 	return nil, nil
@@ -276,24 +414,47 @@ func (b *fakeBosClient) UploadPartCopy(bucket, object, srcBucket, srcObject, upl
 func (b *fakeBosClient) InitiateMultipartUpload(bucket, object, contentType string,
 	args *api.InitiateMultipartUploadArgs) (*api.InitiateMultipartUploadResult, error) {
 
+	if bucket == "stream" {
+		b.initContentType = contentType
+		return &api.InitiateMultipartUploadResult{UploadId: "streamUploadId"}, nil
+	}
 	return nil, fmt.Errorf("Not support")
 }
 
 func (b *fakeBosClient) AbortMultipartUpload(bucket, object, uploadId string) error {
+	if bucket == "stream" {
+		b.abortedUploadId = uploadId
+		return nil
+	}
 	return fmt.Errorf("Not support")
 }
 
 func (b *fakeBosClient) CompleteMultipartUploadFromStruct(bucket, object, uploadId string,
-	parts *api.CompleteMultipartUploadArgs,
-	meta map[string]string) (*api.CompleteMultipartUploadResult, error) {
+	parts *api.CompleteMultipartUploadArgs) (*api.CompleteMultipartUploadResult, error) {
 
+	if bucket == "stream" {
+		b.completedParts = parts.Parts
+		return &api.CompleteMultipartUploadResult{}, nil
+	}
 	return nil, fmt.Errorf("Not support")
 }
 
 func (b *fakeBosClient) GetObject(bucket, object string, responseHeaders map[string]string,
 	ranges ...int64) (*api.GetObjectResult, error) {
 
-	return nil, fmt.Errorf("Not support")
+	if b.content == nil || object == "error" {
+		return nil, fmt.Errorf("Not support")
+	}
+	body := b.content
+	if len(ranges) == 2 {
+		body = body[ranges[0] : ranges[1]+1]
+	} else if len(ranges) == 1 {
+		body = body[ranges[0]:]
+	}
+	return &api.GetObjectResult{
+		ObjectMeta: api.ObjectMeta{ContentLength: int64(len(body))},
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	}, nil
 }
 
 type listObjectIteratorType struct {
@@ -925,6 +1086,44 @@ func TestListObjectIterator(t *testing.T) {
 	}
 }
 
+func TestListObjectIteratorSkipMarkerDir(t *testing.T) {
+	// the second page is listed with marker "1", which is a dir thrown by the first page
+	bosClient := &fakeBosClient{
+		results: []*api.ListObjectsResult{
+			&api.ListObjectsResult{
+				CommonPrefixes: []api.PrefixType{
+					api.PrefixType{Prefix: "0/"},
+					api.PrefixType{Prefix: "1"},
+				},
+				IsTruncated: true,
+				NextMarker:  "1",
+			},
+			&api.ListObjectsResult{
+				CommonPrefixes: []api.PrefixType{
+					api.PrefixType{Prefix: "1"},
+					api.PrefixType{Prefix: "2/"},
+				},
+				IsTruncated: false,
+			},
+		},
+	}
+	newIter := NewObjectListIterator(bosClient, nil, "0", "", "", true, false, true, false, 1000)
+	dirs := []string{}
+	for {
+		ret, err := newIter.next()
+		if err != nil {
+			t.Errorf("list objects failed: %s", err)
+			return
+		}
+		if ret.ended {
+			break
+		}
+		dirs = append(dirs, ret.dir.key)
+	}
+	util.ExpectEqual("handler.go list skip marker dir", 1, t.Errorf, "0/,1,2/",
+		strings.Join(dirs, ","))
+}
+
 type multiDeleteObjectsWithRetryType struct {
 	bosClient  bosClientInterface
 	bucketName string
@@ -1205,7 +1404,7 @@ func TestUtilCopyObject(t *testing.T) {
 
 		ret := handler.utilCopyObject(srcBosClient, bosClient, tCase.srcBucket, tCase.srcObject,
 			tCase.dstBucket, tCase.dstObject, tCase.storageClass, tCase.fileSize, tCase.fileMtime,
-			time.Now().Unix(), tCase.restart)
+			time.Now().Unix(), tCase.restart, nil)
 
 		if tCase.isSuc {
 			util.ExpectEqual("handler.go utilCopyObject I", i+1, t.Errorf,
@@ -1217,6 +1416,106 @@ func TestUtilCopyObject(t *testing.T) {
 	}
 }
 
+type multipartCopyMetaType struct {
+	srcObject string
+	meta      *objectMetaArgs
+	out       *objectMetaArgs
+	isSuc     bool
+}
+
+func TestMultipartCopyMeta(t *testing.T) {
+	srcMeta := &objectMetaArgs{contentType: "text/plain", cacheControl: "no-cache",
+		contentEncoding: "gzip", userMeta: map[string]string{"a": "1"}}
+	replaceMeta := &objectMetaArgs{contentType: "text/css", metadataDirective: "replace"}
+	aclMeta := &objectMetaArgs{contentType: "text/plain", cacheControl: "no-cache",
+		contentEncoding: "gzip", userMeta: map[string]string{"a": "1"}, cannedAcl: "private"}
+	testCases := []multipartCopyMetaType{
+		//1 metadata of source is copied by default
+		multipartCopyMetaType{
+			srcObject: "object",
+			out:       srcMeta,
+			isSuc:     true,
+		},
+		//2
+		multipartCopyMetaType{
+			srcObject: "object",
+			meta:      &objectMetaArgs{metadataDirective: "copy", cannedAcl: "private"},
+			out:       aclMeta,
+			isSuc:     true,
+		},
+		//3 source isn't read when metadata is replaced
+		multipartCopyMetaType{
+			srcObject: "404",
+			meta:      replaceMeta,
+			out:       replaceMeta,
+			isSuc:     true,
+		},
+		//4
+		multipartCopyMetaType{
+			srcObject: "404",
+			isSuc:     false,
+		},
+	}
+	srcBosClient := &fakeBosClient{objectMeta: &api.GetObjectMetaResult{}}
+	srcBosClient.objectMeta.ContentType = "text/plain"
+	srcBosClient.objectMeta.CacheControl = "no-cache"
+	srcBosClient.objectMeta.ContentEncoding = "gzip"
+	srcBosClient.objectMeta.ContentLength = 100 << 30
+	srcBosClient.objectMeta.UserMeta = map[string]string{"a": "1"}
+	for i, tCase := range testCases {
+		ret, err := multipartCopyMeta(srcBosClient, "bucket", tCase.srcObject, tCase.meta)
+		util.ExpectEqual("handler.go multipartCopyMeta I", i+1, t.Errorf, tCase.isSuc,
+			err == nil)
+		util.ExpectEqual("handler.go multipartCopyMeta II", i+1, t.Errorf, tCase.out, ret)
+	}
+}
+
+type utilSetStorageClassType struct {
+	bucket   string
+	object   string
+	fileSize int64
+	isSuc    bool
+}
+
+func TestUtilSetStorageClass(t *testing.T) {
+	testCases := []utilSetStorageClassType{
+		utilSetStorageClassType{bucket: "bucket", object: "object", fileSize: 100, isSuc: true},
+		utilSetStorageClassType{bucket: "error", object: "object", fileSize: 100, isSuc: false},
+		// multipart copy
+		utilSetStorageClassType{bucket: "bucket", object: "object", fileSize: 100 << 30,
+			isSuc: false},
+	}
+	bosClient := &fakeBosClient{}
+	for i, tCase := range testCases {
+		ret := handler.utilSetStorageClass(bosClient, tCase.bucket, tCase.object, "COLD",
+			tCase.fileSize, time.Now().Unix(), time.Now().Unix(), false)
+		util.ExpectEqual("handler.go utilSetStorageClass", i+1, t.Errorf, tCase.isSuc,
+			ret == nil)
+	}
+}
+
+type utilUndeleteObjectType struct {
+	bucket   string
+	fileSize int64
+	isSuc    bool
+}
+
+func TestUtilUndeleteObject(t *testing.T) {
+	testCases := []utilUndeleteObjectType{
+		utilUndeleteObjectType{bucket: "bucket", fileSize: 100, isSuc: true},
+		utilUndeleteObjectType{bucket: "error", fileSize: 100, isSuc: false},
+		// multipart copy
+		utilUndeleteObjectType{bucket: "bucket", fileSize: 100 << 30, isSuc: false},
+	}
+	bosClient := &fakeBosClient{}
+	for i, tCase := range testCases {
+		ret := handler.utilUndeleteObject(bosClient, tCase.bucket, ".trash/object", "object",
+			"", tCase.fileSize, time.Now().Unix(), time.Now().Unix())
+		util.ExpectEqual("handler.go utilUndeleteObject", i+1, t.Errorf, tCase.isSuc,
+			ret == nil)
+	}
+}
+
 type utilDownloadObjectType struct {
 	srcBucket           string
 	srcObject           string
@@ -1483,7 +1782,7 @@ func TestUtilUploadFile(t *testing.T) {
 	for i, tCase := range testCases {
 		ret := handler.utilUploadFile(bosClient, tCase.srcPath, tCase.relSrcPath, tCase.dstBucket,
 			tCase.dstObject, tCase.storageClass, tCase.fileSize, tCase.fileMtime,
-			tCase.timeOfgetObjectInfo, tCase.restart)
+			tCase.timeOfgetObjectInfo, tCase.restart, nil)
 		if tCase.err == "" {
 			util.ExpectEqual("handler.go utilUploadFile I", i+1, t.Errorf,
 				true, ret == nil)
@@ -1494,6 +1793,285 @@ func TestUtilUploadFile(t *testing.T) {
 	}
 }
 
+type utilUploadStreamType struct {
+	dstBucket   string
+	dstObject   string
+	size        int64
+	partsNum    int
+	aborted     bool
+	err         string
+	meta        *objectMetaArgs
+	contentType string
+	readErr     bool
+}
+
+// reader which always fails
+type failedReader struct{}
+
+func (r *failedReader) Read(p []byte) (int, error) {
+	return 0, fmt.Errorf("read timeout")
+}
+
+func TestUtilUploadStream(t *testing.T) {
+	multiUploadPartSize, _ := bceconf.ServerConfigProvider.GetMultiUploadPartSize()
+	partSize := multiUploadPartSize * (1 << 20)
+	testCases := []utilUploadStreamType{
+		utilUploadStreamType{
+			dstBucket: "success",
+			dstObject: "empty",
+		},
+		utilUploadStreamType{
+			dstBucket: "success",
+			dstObject: "small",
+			size:      100,
+		},
+		utilUploadStreamType{
+			dstBucket: "error",
+			dstObject: "small",
+			size:      100,
+			err:       "byteserrorsmall100",
+		},
+		utilUploadStreamType{
+			dstBucket: "stream",
+			dstObject: "big",
+			size:      partSize*2 + 10,
+			partsNum:  3,
+		},
+		utilUploadStreamType{
+			dstBucket: "stream",
+			dstObject: "exact",
+			size:      partSize * 2,
+			partsNum:  2,
+		},
+		utilUploadStreamType{
+			dstBucket: "stream",
+			dstObject: "partError",
+			size:      partSize * 3,
+			aborted:   true,
+			err:       "Not support",
+		},
+		utilUploadStreamType{
+			dstBucket: "error",
+			dstObject: "big",
+			size:      partSize + 1,
+			err:       "Not support",
+		},
+		// the multipart upload is aborted when stream fails, e.g. the url of fetch stalls
+		utilUploadStreamType{
+			dstBucket: "stream",
+			dstObject: "readError",
+			size:      partSize + 1,
+			readErr:   true,
+			aborted:   true,
+			err:       "read timeout",
+		},
+		// user metadata is sent as headers, which can't be sent by the fake client
+		utilUploadStreamType{
+			dstBucket: "stream",
+			dstObject: "meta",
+			size:      partSize + 1,
+			meta: &objectMetaArgs{contentType: "text/plain",
+				userMeta: map[string]string{"k": "v"}},
+			err: "headers can not be sent by this client",
+		},
+		utilUploadStreamType{
+			dstBucket:   "stream",
+			dstObject:   "contentType",
+			size:        partSize + 1,
+			partsNum:    2,
+			meta:        &objectMetaArgs{contentType: "text/plain"},
+			contentType: "text/plain",
+		},
+		// canned acl is sent as a header with put object
+		utilUploadStreamType{
+			dstBucket: "success",
+			dstObject: "acl",
+			size:      10,
+			meta:      &objectMetaArgs{cannedAcl: "public-read"},
+			err:       "headers can not be sent by this client",
+		},
+	}
+	for i, tCase := range testCases {
+		bosClient := &fakeBosClient{}
+		var stream io.Reader = strings.NewReader(strings.Repeat("a", int(tCase.size)))
+		if tCase.readErr {
+			stream = io.MultiReader(stream, &failedReader{})
+		}
+		ret := handler.utilUploadStream(bosClient, stream, tCase.dstBucket, tCase.dstObject, "",
+			tCase.meta)
+		if tCase.err == "" {
+			util.ExpectEqual("handler.go utilUploadStream I", i+1, t.Errorf, true, ret == nil)
+		} else if ret == nil {
+			t.Errorf("handler.go utilUploadStream I case %d: expect error %s", i+1, tCase.err)
+		} else {
+			util.ExpectEqual("handler.go utilUploadStream I", i+1, t.Errorf, tCase.err,
+				ret.Error())
+		}
+		util.ExpectEqual("handler.go utilUploadStream II", i+1, t.Errorf, tCase.partsNum,
+			len(bosClient.completedParts))
+		for j, part := range bosClient.completedParts {
+			util.ExpectEqual("handler.go utilUploadStream III", i+1, t.Errorf, j+1,
+				part.PartNumber)
+		}
+		util.ExpectEqual("handler.go utilUploadStream IV", i+1, t.Errorf, tCase.aborted,
+			bosClient.abortedUploadId == "streamUploadId")
+		util.ExpectEqual("handler.go utilUploadStream V", i+1, t.Errorf, tCase.contentType,
+			bosClient.initContentType)
+	}
+}
+
+type utilDownloadToStreamType struct {
+	object string
+	size   int64
+	err    string
+}
+
+func TestUtilDownloadToStream(t *testing.T) {
+	partSize, _ := bceconf.ServerConfigProvider.GetMultiUploadPartSize()
+	testCases := []utilDownloadToStreamType{
+		utilDownloadToStreamType{
+			object: "empty",
+		},
+		utilDownloadToStreamType{
+			object: "small",
+			size:   1000,
+		},
+		utilDownloadToStreamType{
+			object: "error",
+			size:   1000,
+			err:    "Not support",
+		},
+		utilDownloadToStreamType{
+			object: "404",
+			err:    "Object don't exist!",
+		},
+		utilDownloadToStreamType{
+			object: "big",
+			size:   MULTI_DOWNLOAD_THRESHOLD + partSize*(1<<20)/2,
+		},
+	}
+	for i, tCase := range testCases {
+		content := make([]byte, tCase.size)
+		for j := range content {
+			content[j] = byte(j % 251)
+		}
+		bosClient := &fakeBosClient{
+			content: content,
+			objectMeta: &api.GetObjectMetaResult{
+				ObjectMeta: api.ObjectMeta{
+					ContentLength: tCase.size,
+					LastModified:  "Wed, 06 Apr 2016 06:34:40 GMT",
+				},
+			},
+		}
+		stream := &bytes.Buffer{}
+		ret := handler.utilDownloadToStream(bosClient, "bucket", tCase.object, stream)
+		if tCase.err == "" {
+			util.ExpectEqual("handler.go utilDownloadToStream I", i+1, t.Errorf, true,
+				ret == nil)
+			util.ExpectEqual("handler.go utilDownloadToStream II", i+1, t.Errorf, true,
+				bytes.Equal(content, stream.Bytes()))
+		} else if ret == nil {
+			t.Errorf("handler.go utilDownloadToStream I case %d: expect error %s", i+1,
+				tCase.err)
+		} else {
+			util.ExpectEqual("handler.go utilDownloadToStream I", i+1, t.Errorf, tCase.err,
+				getErrorMsg(ret))
+		}
+	}
+}
+
+type utilGetObjectRangeType struct {
+	object     string
+	start      int64
+	end        int64
+	decompress bool
+	out        string
+	isSuc      bool
+}
+
+func TestUtilGetObjectRange(t *testing.T) {
+	gzipContent := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(gzipContent)
+	gzipWriter.Write([]byte("line1\nline2\n"))
+	gzipWriter.Close()
+
+	testCases := []utilGetObjectRangeType{
+		utilGetObjectRangeType{
+			object: "plain",
+			end:    -1,
+			out:    "0123456789",
+			isSuc:  true,
+		},
+		utilGetObjectRangeType{
+			object: "plain",
+			start:  2,
+			end:    4,
+			out:    "234",
+			isSuc:  true,
+		},
+		utilGetObjectRangeType{
+			object: "plain",
+			start:  7,
+			end:    -1,
+			out:    "789",
+			isSuc:  true,
+		},
+		utilGetObjectRangeType{
+			object:     "gzip",
+			end:        -1,
+			decompress: true,
+			out:        "line1\nline2\n",
+			isSuc:      true,
+		},
+		// not a gzip object
+		utilGetObjectRangeType{
+			object:     "plain",
+			end:        -1,
+			decompress: true,
+			isSuc:      false,
+		},
+		utilGetObjectRangeType{
+			object: "error",
+			end:    -1,
+			isSuc:  false,
+		},
+	}
+	for i, tCase := range testCases {
+		bosClient := &fakeBosClient{content: []byte("0123456789")}
+		if tCase.object == "gzip" {
+			bosClient.content = gzipContent.Bytes()
+		}
+		stream := &bytes.Buffer{}
+		err := handler.utilGetObjectRange(bosClient, "bucket", tCase.object, tCase.start,
+			tCase.end, tCase.decompress, stream)
+		util.ExpectEqual("handler.go utilGetObjectRange I", i+1, t.Errorf, tCase.isSuc,
+			err == nil)
+		if tCase.isSuc {
+			util.ExpectEqual("handler.go utilGetObjectRange II", i+1, t.Errorf, tCase.out,
+				stream.String())
+		}
+	}
+}
+
+func TestUtilDownloadObjectRange(t *testing.T) {
+	bosClient := &fakeBosClient{content: []byte("0123456789")}
+	dstPath := "./test_download_range/part"
+	defer os.RemoveAll("./test_download_range")
+
+	err := handler.utilDownloadObjectRange(bosClient, "bucket", "key", dstPath, true, 3, 5)
+	util.ExpectEqual("handler.go utilDownloadObjectRange I", 1, t.Errorf, true, err == nil)
+	content, _ := ioutil.ReadFile(dstPath)
+	util.ExpectEqual("handler.go utilDownloadObjectRange II", 1, t.Errorf, "345",
+		string(content))
+
+	// the partial file is removed when download failed
+	err = handler.utilDownloadObjectRange(bosClient, "bucket", "error", dstPath, true, 3, 5)
+	util.ExpectEqual("handler.go utilDownloadObjectRange I", 2, t.Errorf, false, err == nil)
+	util.ExpectEqual("handler.go utilDownloadObjectRange II", 2, t.Errorf, false,
+		util.DoesFileExist(dstPath))
+}
+
 type utilDeleteLocalFileType struct {
 	srcPath string
 	err     string
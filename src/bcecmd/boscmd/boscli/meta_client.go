@@ -0,0 +1,137 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+// This module sends the metadata of objects which can't be set by the args of go sdk, e.g. the
+// content encoding and user metadata of multipart upload, and the canned ACL of new objects.
+
+package boscli
+
+import (
+	"fmt"
+)
+
+import (
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/http"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+// headers of the metadata which are not in the args of put object or initiate multipart upload,
+// the canned ACL is set by the request creating the object, so that the object is never
+// visible with the ACL of its bucket.
+func (m *objectMetaArgs) extraHeaders() map[string]string {
+	headers := make(map[string]string)
+	if m == nil {
+		return headers
+	}
+	if m.contentEncoding != "" {
+		headers[http.CONTENT_ENCODING] = m.contentEncoding
+	}
+	for key, val := range m.userMeta {
+		headers[http.BCE_USER_METADATA_PREFIX+key] = val
+	}
+	if m.cannedAcl != "" {
+		headers[http.BCE_ACL] = m.cannedAcl
+	}
+	return headers
+}
+
+// bos client which sends the extra headers of metadata with requests creating objects, other
+// requests are sent by the client it decorates
+type metaBosClient struct {
+	bosClientInterface
+	headers map[string]string
+}
+
+// decorate the client only when there are extra headers to send
+func newMetaBosClient(bosClient bosClientInterface, meta *objectMetaArgs) bosClientInterface {
+	headers := meta.extraHeaders()
+	if len(headers) == 0 {
+		return bosClient
+	}
+	return &metaBosClient{bosClientInterface: bosClient, headers: headers}
+}
+
+// the encryption headers given by sseHeaders are sent together when the decorated client is a
+// *sseBosClient
+func (m *metaBosClient) send(bucket string, sseHeaders func(s *sseArgs) map[string]string,
+	call func(cli bce.Client) error) error {
+
+	sseClient, ok := m.bosClientInterface.(*sseBosClient)
+	if !ok {
+		return sendWithHeaders(m.bosClientInterface, bucket, m.headers, call)
+	}
+	headers := sseHeaders(sseClient.sse)
+	for key, val := range m.headers {
+		headers[key] = val
+	}
+	return sseClient.send(bucket, headers, call)
+}
+
+func (m *metaBosClient) PutObjectFromFile(bucket, object, fileName string,
+	args *api.PutObjectArgs) (string, error) {
+
+	var etag string
+	err := m.send(bucket, (*sseArgs).writeHeaders, func(cli bce.Client) error {
+		body, err := bce.NewBodyFromFile(fileName)
+		if err != nil {
+			return err
+		}
+		etag, err = api.PutObject(cli, bucket, object, body, args)
+		return err
+	})
+	return etag, err
+}
+
+func (m *metaBosClient) PutObjectFromBytes(bucket, object string, bytesArr []byte,
+	args *api.PutObjectArgs) (string, error) {
+
+	var etag string
+	err := m.send(bucket, (*sseArgs).writeHeaders, func(cli bce.Client) error {
+		body, err := bce.NewBodyFromBytes(bytesArr)
+		if err != nil {
+			return err
+		}
+		etag, err = api.PutObject(cli, bucket, object, body, args)
+		return err
+	})
+	return etag, err
+}
+
+func (m *metaBosClient) InitiateMultipartUpload(bucket, object, contentType string,
+	args *api.InitiateMultipartUploadArgs) (*api.InitiateMultipartUploadResult, error) {
+
+	var ret *api.InitiateMultipartUploadResult
+	err := m.send(bucket, (*sseArgs).writeHeaders, func(cli bce.Client) error {
+		var err error
+		ret, err = api.InitiateMultipartUpload(cli, bucket, object, contentType, args)
+		return err
+	})
+	return ret, err
+}
+
+func (m *metaBosClient) CopyObject(bucket, object, srcBucket, srcObject string,
+	args *api.CopyObjectArgs) (*api.CopyObjectResult, error) {
+
+	var ret *api.CopyObjectResult
+	selfCopy := bucket == srcBucket && object == srcObject
+	sseHeaders := func(s *sseArgs) map[string]string {
+		return s.withCopySourceHeaders(s.writeHeaders(), selfCopy)
+	}
+	err := m.send(bucket, sseHeaders, func(cli bce.Client) error {
+		var err error
+		source := fmt.Sprintf("/%s/%s", srcBucket, srcObject)
+		ret, err = api.CopyObject(cli, bucket, object, source, args)
+		return err
+	})
+	return ret, err
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+package boscli
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+	"utils/util"
+)
+
+type extraHeadersType struct {
+	meta    *objectMetaArgs
+	headers map[string]string
+}
+
+func TestExtraHeaders(t *testing.T) {
+	testCases := []extraHeadersType{
+		//1
+		extraHeadersType{
+			headers: map[string]string{},
+		},
+		//2 headers in args of go sdk
+		extraHeadersType{
+			meta:    &objectMetaArgs{contentType: "text/plain", cacheControl: "no-cache"},
+			headers: map[string]string{},
+		},
+		//3
+		extraHeadersType{
+			meta: &objectMetaArgs{contentEncoding: "gzip",
+				userMeta: map[string]string{"a": "1", "b": "x=y"}},
+			headers: map[string]string{"Content-Encoding": "gzip", "x-bce-meta-a": "1",
+				"x-bce-meta-b": "x=y"},
+		},
+		//4
+		extraHeadersType{
+			meta:    &objectMetaArgs{cannedAcl: "public-read"},
+			headers: map[string]string{"x-bce-acl": "public-read"},
+		},
+	}
+	for i, tCase := range testCases {
+		util.ExpectEqual("meta_client.go extraHeaders", i+1, t.Errorf, tCase.headers,
+			tCase.meta.extraHeaders())
+	}
+}
+
+func TestMetaBosClient(t *testing.T) {
+	fake := &fakeSseClient{fakeBosClient: &fakeBosClient{}}
+	meta := &objectMetaArgs{contentEncoding: "gzip", userMeta: map[string]string{"a": "1"}}
+
+	// client is not decorated when there is no extra header
+	util.ExpectEqual("meta_client.go newMetaBosClient I", 1, t.Errorf, fake,
+		newMetaBosClient(fake, nil))
+	util.ExpectEqual("meta_client.go newMetaBosClient II", 1, t.Errorf, fake,
+		newMetaBosClient(fake, &objectMetaArgs{contentType: "text/plain"}))
+
+	metaClient := newMetaBosClient(fake, meta)
+	metaClient.PutObjectFromBytes("bucket", "object", []byte("content"), nil)
+	util.ExpectEqual("meta_client.go PutObjectFromBytes I", 1, t.Errorf, "gzip",
+		fake.headers["Content-Encoding"])
+	util.ExpectEqual("meta_client.go PutObjectFromBytes II", 1, t.Errorf, "1",
+		fake.headers["x-bce-meta-a"])
+	fake.headers = nil
+	metaClient.InitiateMultipartUpload("bucket", "object", "text/plain", nil)
+	util.ExpectEqual("meta_client.go InitiateMultipartUpload I", 1, t.Errorf, "gzip",
+		fake.headers["Content-Encoding"])
+	util.ExpectEqual("meta_client.go InitiateMultipartUpload II", 1, t.Errorf, "1",
+		fake.headers["x-bce-meta-a"])
+
+	// encryption headers are sent together
+	metaClient = newMetaBosClient(newSseBosClient(fake, &sseArgs{algorithm: "AES256"}), meta)
+	metaClient.InitiateMultipartUpload("bucket", "object", "", nil)
+	util.ExpectEqual("meta_client.go InitiateMultipartUpload III", 1, t.Errorf, "AES256",
+		fake.headers[SSE_HEADER])
+	util.ExpectEqual("meta_client.go InitiateMultipartUpload IV", 1, t.Errorf, "1",
+		fake.headers["x-bce-meta-a"])
+
+	// canned acl and the key of the source are sent with copy object
+	fake.headers = nil
+	metaClient = newMetaBosClient(newSseBosClient(fake, &sseArgs{customerKey: []byte("key")}),
+		&objectMetaArgs{cannedAcl: "private"})
+	metaClient.CopyObject("bucket", "object", "bucket", "object", nil)
+	util.ExpectEqual("meta_client.go CopyObject I", 1, t.Errorf, "private",
+		fake.headers["x-bce-acl"])
+	util.ExpectEqual("meta_client.go CopyObject II", 1, t.Errorf, api.ENCRYPTION_AES256,
+		fake.headers[SSE_COPY_SOURCE_ALGORITHM])
+
+	// other requests are sent by the decorated client
+	_, err := metaClient.ListBuckets()
+	util.ExpectEqual("meta_client.go ListBuckets", 1, t.Errorf, "test", err.Error())
+
+	// client which can't send requests with headers
+	metaClient = newMetaBosClient(&fakeBosClient{}, meta)
+	_, err = metaClient.InitiateMultipartUpload("bucket", "object", "", nil)
+	util.ExpectEqual("meta_client.go InitiateMultipartUpload V", 1, t.Errorf, true, err != nil)
+}
@@ -0,0 +1,206 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+// This module guesses the content type of local files which are uploaded to BOS.
+
+package boscli
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+import (
+	"bceconf"
+	"github.com/baidubce/bce-sdk-go/util/log"
+)
+
+const (
+	// http.DetectContentType considers at most the first 512 bytes
+	MIME_SNIFF_LEN = 512
+)
+
+var (
+	// don't guess the content type of uploaded files, set by --no-guess-mime-type
+	NoGuessMimeType bool
+
+	// extensions from the mime types file of user, loaded at the first time of guessing
+	userMimeTypes     map[string]string
+	userMimeTypesOnce sync.Once
+
+	// built-in content types, the extensions are in lower case
+	builtinMimeTypes = map[string]string{
+		".7z":    "application/x-7z-compressed",
+		".apk":   "application/vnd.android.package-archive",
+		".avi":   "video/x-msvideo",
+		".avif":  "image/avif",
+		".bmp":   "image/bmp",
+		".bz2":   "application/x-bzip2",
+		".css":   "text/css",
+		".csv":   "text/csv",
+		".doc":   "application/msword",
+		".docx":  "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		".eot":   "application/vnd.ms-fontobject",
+		".exe":   "application/x-msdownload",
+		".flac":  "audio/flac",
+		".flv":   "video/x-flv",
+		".gif":   "image/gif",
+		".gz":    "application/gzip",
+		".htm":   "text/html",
+		".html":  "text/html",
+		".ico":   "image/x-icon",
+		".jpeg":  "image/jpeg",
+		".jpg":   "image/jpeg",
+		".js":    "application/javascript",
+		".json":  "application/json",
+		".m3u8":  "application/vnd.apple.mpegurl",
+		".m4a":   "audio/mp4",
+		".map":   "application/json",
+		".md":    "text/markdown",
+		".mjs":   "application/javascript",
+		".mkv":   "video/x-matroska",
+		".mov":   "video/quicktime",
+		".mp3":   "audio/mpeg",
+		".mp4":   "video/mp4",
+		".ogg":   "audio/ogg",
+		".otf":   "font/otf",
+		".pdf":   "application/pdf",
+		".png":   "image/png",
+		".ppt":   "application/vnd.ms-powerpoint",
+		".pptx":  "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		".rar":   "application/vnd.rar",
+		".svg":   "image/svg+xml",
+		".tar":   "application/x-tar",
+		".tgz":   "application/gzip",
+		".tif":   "image/tiff",
+		".tiff":  "image/tiff",
+		".ts":    "video/mp2t",
+		".ttf":   "font/ttf",
+		".txt":   "text/plain",
+		".wasm":  "application/wasm",
+		".wav":   "audio/wav",
+		".webm":  "video/webm",
+		".webp":  "image/webp",
+		".woff":  "font/woff",
+		".woff2": "font/woff2",
+		".xls":   "application/vnd.ms-excel",
+		".xlsx":  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		".xml":   "application/xml",
+		".yaml":  "application/x-yaml",
+		".yml":   "application/x-yaml",
+		".zip":   "application/zip",
+	}
+)
+
+// guess the content type of a local file.
+// The mime types file of user is looked up first, then the built-in table, both by the
+// extension of name. Content type is detected from the first 512 bytes of the file at path
+// when the extension is unknown. Return "" when the type can not be guessed.
+func guessMimeType(name, path string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext != "" {
+		userMimeTypesOnce.Do(loadUserMimeTypes)
+		if contentType, ok := userMimeTypes[ext]; ok {
+			return contentType
+		}
+		if contentType, ok := builtinMimeTypes[ext]; ok {
+			return contentType
+		}
+	}
+	return sniffMimeType(path)
+}
+
+// detect the content type from the beginning of a file
+func sniffMimeType(path string) string {
+	fd, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer fd.Close()
+
+	buf := make([]byte, MIME_SNIFF_LEN)
+	n, err := io.ReadFull(fd, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return ""
+	}
+	if n == 0 {
+		return ""
+	}
+	return http.DetectContentType(buf[:n])
+}
+
+// load the mime types file of user in the config folder, it is not required.
+func loadUserMimeTypes() {
+	if bceconf.MimeTypesPath == "" {
+		return
+	}
+	fd, err := os.Open(bceconf.MimeTypesPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debugf("can't open mime types file %s: %s", bceconf.MimeTypesPath, err)
+		}
+		return
+	}
+	defer fd.Close()
+
+	userMimeTypes, err = parseMimeTypes(fd)
+	if err != nil {
+		log.Debugf("can't read mime types file %s: %s", bceconf.MimeTypesPath, err)
+	}
+}
+
+// parse mime types in the format of mime.types of apache:
+//   # comment
+//   text/html  html htm
+// The extensions are mapped to the type on the same line, the later line wins when an
+// extension appears more than once.
+func parseMimeTypes(reader io.Reader) (map[string]string, error) {
+	mimeTypes := make(map[string]string)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if pos := strings.Index(line, "#"); pos >= 0 {
+			line = line[:pos]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, ext := range fields[1:] {
+			mimeTypes["."+strings.ToLower(strings.TrimPrefix(ext, "."))] = fields[0]
+		}
+	}
+	return mimeTypes, scanner.Err()
+}
+
+// return meta with the content type guessed from the local file, when the content type is not
+// set by user and guessing is not disabled.
+func (m *objectMetaArgs) withGuessedContentType(name, path string) *objectMetaArgs {
+	if NoGuessMimeType || (m != nil && m.contentType != "") {
+		return m
+	}
+	contentType := guessMimeType(name, path)
+	if contentType == "" {
+		return m
+	}
+	ret := &objectMetaArgs{}
+	if m != nil {
+		*ret = *m
+	}
+	ret.contentType = contentType
+	return ret
+}
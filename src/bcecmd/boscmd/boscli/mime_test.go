@@ -0,0 +1,118 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+package boscli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+import (
+	"utils/util"
+)
+
+type guessMimeTypeType struct {
+	name    string
+	content string
+	out     string
+}
+
+func TestGuessMimeType(t *testing.T) {
+	localDir := "./test_guess_mime"
+	util.TryMkdir(localDir)
+	defer os.RemoveAll(localDir)
+
+	// don't load mime types file of the tester
+	userMimeTypesOnce.Do(func() {})
+	tempUserMimeTypes := userMimeTypes
+	userMimeTypes = map[string]string{".tpl": "text/x-template", ".js": "text/javascript"}
+	defer func() { userMimeTypes = tempUserMimeTypes }()
+
+	testCases := []guessMimeTypeType{
+		//1 built-in table
+		guessMimeTypeType{name: "index.html", content: "{}", out: "text/html"},
+		//2 extension is case insensitive
+		guessMimeTypeType{name: "A.CSS", content: "", out: "text/css"},
+		//3 mime types file of user
+		guessMimeTypeType{name: "a.tpl", content: "", out: "text/x-template"},
+		//4 user overrides built-in
+		guessMimeTypeType{name: "a.js", content: "", out: "text/javascript"},
+		//5 unknown extension, sniff content
+		guessMimeTypeType{name: "page.unknown", content: "<html><body></body></html>",
+			out: "text/html; charset=utf-8"},
+		//6 no extension, sniff content
+		guessMimeTypeType{name: "png", content: "\x89PNG\x0D\x0A\x1A\x0A", out: "image/png"},
+		//7 empty file without extension
+		guessMimeTypeType{name: "empty", content: "", out: ""},
+	}
+	for i, tCase := range testCases {
+		path := filepath.Join(localDir, tCase.name)
+		if err := ioutil.WriteFile(path, []byte(tCase.content), 0644); err != nil {
+			t.Errorf("mime.go guessMimeType create file %s failed: %s", path, err)
+			continue
+		}
+		util.ExpectEqual("mime.go guessMimeType I", i+1, t.Errorf, tCase.out,
+			guessMimeType(tCase.name, path))
+	}
+	// file doesn't exist
+	util.ExpectEqual("mime.go guessMimeType II", 1, t.Errorf, "",
+		guessMimeType("none", filepath.Join(localDir, "none")))
+}
+
+func TestParseMimeTypes(t *testing.T) {
+	content := "# comment line\n" +
+		"text/html html HTM # inline comment\n" +
+		"\n" +
+		"application/x-empty\n" +
+		"text/x-c .c\n" +
+		"text/plain c\n"
+	ret, err := parseMimeTypes(strings.NewReader(content))
+	util.ExpectEqual("mime.go parseMimeTypes I", 1, t.Errorf, true, err == nil)
+	util.ExpectEqual("mime.go parseMimeTypes II", 1, t.Errorf, map[string]string{
+		".html": "text/html", ".htm": "text/html", ".c": "text/plain"}, ret)
+}
+
+type withGuessedContentTypeType struct {
+	meta    *objectMetaArgs
+	noGuess bool
+	out     *objectMetaArgs
+}
+
+func TestWithGuessedContentType(t *testing.T) {
+	testCases := []withGuessedContentTypeType{
+		//1 nil meta
+		withGuessedContentTypeType{out: &objectMetaArgs{contentType: "text/html"}},
+		//2 other headers are kept
+		withGuessedContentTypeType{
+			meta: &objectMetaArgs{cacheControl: "no-cache"},
+			out:  &objectMetaArgs{cacheControl: "no-cache", contentType: "text/html"},
+		},
+		//3 content type set by user
+		withGuessedContentTypeType{
+			meta: &objectMetaArgs{contentType: "text/plain"},
+			out:  &objectMetaArgs{contentType: "text/plain"},
+		},
+		//4 guessing is disabled
+		withGuessedContentTypeType{noGuess: true},
+	}
+	tempNoGuessMimeType := NoGuessMimeType
+	for i, tCase := range testCases {
+		NoGuessMimeType = tCase.noGuess
+		ret := tCase.meta.withGuessedContentType("index.html", "./none/index.html")
+		util.ExpectEqual("mime.go withGuessedContentType I", i+1, t.Errorf, tCase.out, ret)
+	}
+	NoGuessMimeType = tempNoGuessMimeType
+}
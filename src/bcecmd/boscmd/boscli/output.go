@@ -0,0 +1,560 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+// This module renders the results of bos commands in machine-readable formats.
+
+package boscli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// output formats
+const (
+	OUTPUT_FORMAT_TEXT  = "text"
+	OUTPUT_FORMAT_JSON  = "json"
+	OUTPUT_FORMAT_JSONL = "jsonl"
+	OUTPUT_FORMAT_CSV   = "csv"
+	OUTPUT_FORMAT_TSV   = "tsv"
+	OUTPUT_FORMAT_YAML  = "yaml"
+	OUTPUT_FORMAT_TABLE = "table"
+)
+
+// record types
+const (
+	RECORD_TYPE_BUCKET      = "bucket"
+	RECORD_TYPE_OBJECT      = "object"
+	RECORD_TYPE_PREFIX      = "prefix"
+	RECORD_TYPE_SUMMARY     = "summary"
+	RECORD_TYPE_NEXT_MARKER = "next_marker"
+	RECORD_TYPE_OPERATION   = "operation"
+	RECORD_TYPE_USAGE       = "usage"
+	RECORD_TYPE_OBJECT_META = "object_meta"
+	RECORD_TYPE_FETCH       = "fetch"
+)
+
+// status of operation records
+const (
+	OPERATION_STATUS_SUCCESS = "success"
+	OPERATION_STATUS_FAILED  = "failed"
+	OPERATION_STATUS_SKIPPED = "skipped"
+	OPERATION_STATUS_DRYRUN  = "dryrun"
+)
+
+var (
+	OutputFormats = []string{OUTPUT_FORMAT_TEXT, OUTPUT_FORMAT_JSON, OUTPUT_FORMAT_JSONL,
+		OUTPUT_FORMAT_CSV, OUTPUT_FORMAT_TSV}
+
+	recordOutput = newRecordPrinter(OUTPUT_FORMAT_TEXT, os.Stdout)
+
+	// output formats of bosapi get-* commands
+	ApiOutputFormats = []string{OUTPUT_FORMAT_JSON, OUTPUT_FORMAT_YAML, OUTPUT_FORMAT_TABLE}
+
+	apiOutputFormat = OUTPUT_FORMAT_JSON
+)
+
+// Set the output format of bos commands.
+// When the format is not text, records are printed to stdout, other messages and progress
+// bars are printed to stderr.
+func SetOutputFormat(format string) {
+	recordOutput.format = format
+	if isStructuredOutput() {
+		msgOutput = os.Stderr
+	}
+}
+
+func isStructuredOutput() bool {
+	return recordOutput.format != OUTPUT_FORMAT_TEXT
+}
+
+type outputField struct {
+	name  string
+	value interface{} // nil is omitted in json and empty in csv
+}
+
+// a record is a list of fields, the order of fields is kept in all formats
+type outputRecord []outputField
+
+// recordPrinter print records in the given format, it is safe for concurrent use.
+// json records are printed as an array, the array is closed by finish().
+// csv and tsv print a header line before the first record, so all records printed by a
+// command must have the same fields, see printTrailerRecord.
+type recordPrinter struct {
+	format     string
+	out        io.Writer
+	printedNum int
+	lock       sync.Mutex
+}
+
+func newRecordPrinter(format string, out io.Writer) *recordPrinter {
+	return &recordPrinter{
+		format: format,
+		out:    out,
+	}
+}
+
+func (p *recordPrinter) print(record outputRecord) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	switch p.format {
+	case OUTPUT_FORMAT_JSON:
+		if p.printedNum == 0 {
+			fmt.Fprintf(p.out, "[\n")
+		} else {
+			fmt.Fprintf(p.out, ",\n")
+		}
+		p.out.Write(record.marshalJSON())
+	case OUTPUT_FORMAT_JSONL:
+		p.out.Write(record.marshalJSON())
+		fmt.Fprintf(p.out, "\n")
+	case OUTPUT_FORMAT_CSV, OUTPUT_FORMAT_TSV:
+		writer := csv.NewWriter(p.out)
+		if p.format == OUTPUT_FORMAT_TSV {
+			writer.Comma = '\t'
+		}
+		names := make([]string, len(record))
+		values := make([]string, len(record))
+		for i, field := range record {
+			names[i] = field.name
+			if meta, ok := field.value.(map[string]string); ok {
+				values[i] = formatMeta(meta)
+			} else if field.value != nil {
+				values[i] = fmt.Sprint(field.value)
+			}
+		}
+		if p.printedNum == 0 {
+			writer.Write(names)
+		}
+		writer.Write(values)
+		writer.Flush()
+	default:
+		return
+	}
+	p.printedNum++
+}
+
+// finish printing records of a command, and reset the printer
+func (p *recordPrinter) finish() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.format == OUTPUT_FORMAT_JSON {
+		if p.printedNum == 0 {
+			fmt.Fprintf(p.out, "[]\n")
+		} else {
+			fmt.Fprintf(p.out, "\n]\n")
+		}
+	}
+	p.printedNum = 0
+}
+
+func (p *recordPrinter) isTable() bool {
+	return p.format == OUTPUT_FORMAT_CSV || p.format == OUTPUT_FORMAT_TSV
+}
+
+// marshal record to a json object, fields with nil value are omitted
+func (r outputRecord) marshalJSON() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	first := true
+	for _, field := range r {
+		if field.value == nil {
+			continue
+		}
+		name, _ := json.Marshal(field.name)
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprint(field.value))
+		}
+		if !first {
+			buf.WriteString(",")
+		}
+		buf.Write(name)
+		buf.WriteString(":")
+		buf.Write(value)
+		first = false
+	}
+	buf.WriteString("}")
+	return buf.Bytes()
+}
+
+// print a record if output format is not text
+func printRecord(record outputRecord) {
+	if isStructuredOutput() {
+		recordOutput.print(record)
+	}
+}
+
+// print a record whose fields differ from the other records of a command, e.g. a summary or
+// the next marker. In csv and tsv it is printed with its own header to msgOutput, so stdout
+// is a single table which can be read by any csv reader.
+func printTrailerRecord(record outputRecord) {
+	if !isStructuredOutput() {
+		return
+	}
+	if recordOutput.isTable() {
+		newRecordPrinter(recordOutput.format, msgOutput).print(record)
+		return
+	}
+	recordOutput.print(record)
+}
+
+// finish printing records, must be called before a command exits
+func finishRecords() {
+	if isStructuredOutput() {
+		recordOutput.finish()
+	}
+}
+
+// transform timestamp to RFC3339 time in UTC
+func timestampToRFC3339(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+}
+
+func printBucketRecord(name, location, creationDate string) {
+	if creationTime, err := time.Parse(BOS_TIME_FORMT, creationDate); err == nil {
+		creationDate = creationTime.UTC().Format(time.RFC3339)
+	}
+	printRecord(outputRecord{
+		{"type", RECORD_TYPE_BUCKET},
+		{"name", name},
+		{"location", location},
+		{"creation_date", creationDate},
+	})
+}
+
+// objects and prefixes share the same fields, so they can be printed in one csv table.
+// symlink_target is a field of every record listed by ls --symlink, it is empty for objects
+// which are not symlinks.
+func printObjectRecord(bucketName string, object *fileDetail, symlink bool) {
+	record := outputRecord{
+		{"type", RECORD_TYPE_OBJECT},
+		{"bucket", bucketName},
+		{"key", object.path},
+		{"size", object.size},
+		{"storage_class", object.storageClass},
+		{"mtime", timestampToRFC3339(object.mtime)},
+		{"etag", object.etag},
+	}
+	if symlink {
+		record = append(record, outputField{"symlink_target", object.linkTarget})
+	}
+	printRecord(record)
+}
+
+func printPrefixRecord(bucketName string, dir *dirDetail, symlink bool) {
+	record := outputRecord{
+		{"type", RECORD_TYPE_PREFIX},
+		{"bucket", bucketName},
+		{"key", dir.path},
+		{"size", nil},
+		{"storage_class", nil},
+		{"mtime", nil},
+		{"etag", nil},
+	}
+	if symlink {
+		record = append(record, outputField{"symlink_target", nil})
+	}
+	printRecord(record)
+}
+
+func printNextMarkerRecord(marker string) {
+	printTrailerRecord(outputRecord{
+		{"type", RECORD_TYPE_NEXT_MARKER},
+		{"marker", marker},
+	})
+}
+
+// print the record of an upload, download, copy or delete operation.
+// operation is one of SYNC_OP_*, src or dst is empty when the operation doesn't have it.
+func printOperationRecord(operation, status, src, dst string, err error) {
+	record := outputRecord{
+		{"type", RECORD_TYPE_OPERATION},
+		{"operation", strings.ToLower(operation)},
+		{"status", status},
+		{"source", src},
+		{"destination", dst},
+		{"error", nil},
+	}
+	if err != nil {
+		record[len(record)-1].value = getErrorMsg(err)
+	}
+	printRecord(record)
+}
+
+// print the result of a transfer command
+func printOperationSummaryRecord(src, dst string, ret *executeResult, interrupted bool) {
+	printTrailerRecord(outputRecord{
+		{"type", RECORD_TYPE_SUMMARY},
+		{"source", src},
+		{"destination", dst},
+		{"success", ret.successed},
+		{"failure", ret.failed},
+		{"skipped", ret.skipped},
+		{"interrupted", interrupted},
+	})
+}
+
+// print the usage of a prefix, the usage of the whole bos path is printed as a summary record.
+// the fields of all storage classes are printed, so that records are in one csv table.
+func printUsageRecord(recordType, bucketName string, usage *prefixUsage) {
+	record := outputRecord{
+		{"type", recordType},
+		{"bucket", bucketName},
+		{"prefix", usage.prefix},
+		{"objects", usage.objects},
+		{"size", usage.size},
+	}
+	for _, storageClass := range duStorageClasses {
+		name := strings.ToLower(storageClass)
+		record = append(record,
+			outputField{name + "_objects", usage.classObjects[storageClass]},
+			outputField{name + "_size", usage.classSize[storageClass]})
+	}
+	printRecord(record)
+}
+
+// print the metadata of an object, user meta is an object in json and "k1=v1;k2=v2" in csv
+func printObjectStatRecord(stat *objectStat) {
+	printRecord(outputRecord{
+		{"type", RECORD_TYPE_OBJECT_META},
+		{"bucket", stat.bucketName},
+		{"key", stat.key},
+		{"content_type", stat.contentType},
+		{"content_length", stat.contentLength},
+		{"etag", stat.etag},
+		{"crc32", stat.crc32},
+		{"storage_class", stat.storageClass},
+		{"restore_status", stat.restoreStatus},
+		{"restore_expiry", stat.restoreExpiry},
+		{"mtime", timestampToRFC3339(stat.mtime)},
+		{"server_side_encryption", stat.serverSideEncryption},
+		{"object_type", stat.objectType},
+		{"symlink", stat.isSymlink},
+		{"symlink_target", stat.symlinkTarget},
+		{"multipart", stat.isMultipart},
+		{"appendable", stat.isAppendable},
+		{"next_append_offset", stat.nextAppendOffset},
+		{"user_meta", stat.userMeta},
+	})
+}
+
+// print the result of fetch, size and etag are unknown until an async fetch is finished
+func printFetchRecord(src, dst string, ret *fetchResult) {
+	printRecord(outputRecord{
+		{"type", RECORD_TYPE_FETCH},
+		{"source", src},
+		{"destination", dst},
+		{"mode", ret.mode},
+		{"job_id", ret.jobId},
+		{"size", ret.size},
+		{"etag", ret.etag},
+		{"elapsed_seconds", ret.elapsed.Seconds()},
+	})
+}
+
+// format metadata as "k1=v1;k2=v2" in the order of names
+func formatMeta(meta map[string]string) string {
+	pairs := make([]string, 0, len(meta))
+	for _, name := range sortedMetaNames(meta) {
+		pairs = append(pairs, name+"="+meta[name])
+	}
+	return strings.Join(pairs, ";")
+}
+
+func sortedMetaNames(meta map[string]string) []string {
+	names := make([]string, 0, len(meta))
+	for name := range meta {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Set the output format of bosapi get-* commands.
+func SetApiOutputFormat(format string) {
+	apiOutputFormat = format
+}
+
+// print the result of a bosapi get-* command to stdout
+func printApiResult(result interface{}) error {
+	return renderApiResult(os.Stdout, apiOutputFormat, result)
+}
+
+// render the result of a bosapi get-* command.
+// json keeps the field order of result, so it can be passed to the matching put-* command.
+// yaml and table sort the keys of objects.
+func renderApiResult(out io.Writer, format string, result interface{}) error {
+	resultJson, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if format == OUTPUT_FORMAT_JSON {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, resultJson, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteString("\n")
+		_, err = out.Write(buf.Bytes())
+		return err
+	}
+
+	var val interface{}
+	decoder := json.NewDecoder(bytes.NewReader(resultJson))
+	decoder.UseNumber()
+	if err := decoder.Decode(&val); err != nil {
+		return err
+	}
+
+	switch format {
+	case OUTPUT_FORMAT_YAML:
+		var buf bytes.Buffer
+		writeYaml(&buf, val, 0)
+		_, err = out.Write(buf.Bytes())
+	case OUTPUT_FORMAT_TABLE:
+		writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(writer, "KEY\tVALUE\n")
+		writeTable(writer, "", val)
+		err = writer.Flush()
+	default:
+		err = fmt.Errorf("unsupported output format: %s", format)
+	}
+	return err
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// write a value decoded from json in yaml, objects and arrays are written in block style
+func writeYaml(buf *bytes.Buffer, val interface{}, indent int) {
+	prefix := strings.Repeat(" ", indent)
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			buf.WriteString(prefix + "{}\n")
+			return
+		}
+		for _, key := range sortedKeys(v) {
+			buf.WriteString(prefix + yamlScalar(key) + ":")
+			writeYamlValue(buf, v[key], indent+2)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteString(prefix + "[]\n")
+			return
+		}
+		for _, item := range v {
+			buf.WriteString(prefix + "-")
+			writeYamlValue(buf, item, indent+2)
+		}
+	default:
+		buf.WriteString(prefix + yamlScalar(v) + "\n")
+	}
+}
+
+// write the value after "key:" or "-"
+func writeYamlValue(buf *bytes.Buffer, val interface{}, indent int) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+		buf.WriteString("\n")
+		writeYaml(buf, v, indent)
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+		buf.WriteString("\n")
+		writeYaml(buf, v, indent)
+	default:
+		buf.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+// quote strings which would be parsed as other types or contain special characters
+func yamlScalar(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		needQuote := v == "" || strings.TrimSpace(v) != v ||
+			strings.ContainsAny(v, ":#{}[],&*?|<>=!%@`\"'\\\n\t") ||
+			strings.HasPrefix(v, "-")
+		switch strings.ToLower(v) {
+		case "true", "false", "yes", "no", "on", "off", "null", "~":
+			needQuote = true
+		}
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			needQuote = true
+		}
+		if needQuote {
+			quoted, _ := json.Marshal(v)
+			return string(quoted)
+		}
+		return v
+	}
+	return fmt.Sprint(val)
+}
+
+// write a value decoded from json as rows of key and value, keys of nested values are joined
+// with '.', and the index of an array item is in '[]'.
+func writeTable(writer io.Writer, key string, val interface{}) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			fmt.Fprintf(writer, "%s\t{}\n", key)
+		}
+		for _, subKey := range sortedKeys(v) {
+			if key != "" {
+				writeTable(writer, key+"."+subKey, v[subKey])
+			} else {
+				writeTable(writer, subKey, v[subKey])
+			}
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			fmt.Fprintf(writer, "%s\t[]\n", key)
+		}
+		for i, item := range v {
+			writeTable(writer, key+"["+strconv.Itoa(i)+"]", item)
+		}
+	case nil:
+		fmt.Fprintf(writer, "%s\t\n", key)
+	default:
+		fmt.Fprintf(writer, "%s\t%v\n", key, v)
+	}
+}
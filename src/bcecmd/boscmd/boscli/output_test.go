@@ -0,0 +1,317 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+package boscli
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+import (
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+	"utils/util"
+)
+
+var (
+	testOutputRecords = []outputRecord{
+		outputRecord{
+			{"type", RECORD_TYPE_PREFIX},
+			{"key", "a/"},
+			{"size", nil},
+		},
+		outputRecord{
+			{"type", RECORD_TYPE_OBJECT},
+			{"key", "a,\"b"},
+			{"size", 100},
+		},
+		outputRecord{
+			{"type", RECORD_TYPE_SUMMARY},
+			{"objects", 1},
+		},
+	}
+)
+
+type recordPrinterType struct {
+	format  string
+	records []outputRecord
+	out     string
+}
+
+func TestRecordPrinter(t *testing.T) {
+	testCases := []recordPrinterType{
+		recordPrinterType{
+			format:  OUTPUT_FORMAT_TEXT,
+			records: testOutputRecords,
+			out:     "",
+		},
+		recordPrinterType{
+			format: OUTPUT_FORMAT_JSON,
+			out:    "[]\n",
+		},
+		recordPrinterType{
+			format:  OUTPUT_FORMAT_JSON,
+			records: testOutputRecords,
+			out: "[\n{\"type\":\"prefix\",\"key\":\"a/\"},\n" +
+				"{\"type\":\"object\",\"key\":\"a,\\\"b\",\"size\":100},\n" +
+				"{\"type\":\"summary\",\"objects\":1}\n]\n",
+		},
+		recordPrinterType{
+			format:  OUTPUT_FORMAT_JSONL,
+			records: testOutputRecords,
+			out: "{\"type\":\"prefix\",\"key\":\"a/\"}\n" +
+				"{\"type\":\"object\",\"key\":\"a,\\\"b\",\"size\":100}\n" +
+				"{\"type\":\"summary\",\"objects\":1}\n",
+		},
+		recordPrinterType{
+			format:  OUTPUT_FORMAT_CSV,
+			records: testOutputRecords[:2],
+			out:     "type,key,size\nprefix,a/,\nobject,\"a,\"\"b\",100\n",
+		},
+		recordPrinterType{
+			format:  OUTPUT_FORMAT_TSV,
+			records: testOutputRecords[:2],
+			out:     "type\tkey\tsize\nprefix\ta/\t\nobject\t\"a,\"\"b\"\t100\n",
+		},
+	}
+	for i, tCase := range testCases {
+		buf := &bytes.Buffer{}
+		printer := newRecordPrinter(tCase.format, buf)
+		for _, record := range tCase.records {
+			printer.print(record)
+		}
+		printer.finish()
+		util.ExpectEqual("output.go recordPrinter I", i+1, t.Errorf, tCase.out, buf.String())
+
+		// the printer is reset after finish
+		buf.Reset()
+		printer.finish()
+		if tCase.format == OUTPUT_FORMAT_JSON {
+			util.ExpectEqual("output.go recordPrinter II", i+1, t.Errorf, "[]\n", buf.String())
+		} else {
+			util.ExpectEqual("output.go recordPrinter II", i+1, t.Errorf, "", buf.String())
+		}
+	}
+}
+
+type printTrailerRecordType struct {
+	format string
+	out    string
+	msg    string
+}
+
+func TestPrintTrailerRecord(t *testing.T) {
+	testCases := []printTrailerRecordType{
+		printTrailerRecordType{
+			format: OUTPUT_FORMAT_TEXT,
+		},
+		printTrailerRecordType{
+			format: OUTPUT_FORMAT_JSONL,
+			out: "{\"type\":\"object\",\"key\":\"a,\\\"b\",\"size\":100}\n" +
+				"{\"type\":\"summary\",\"objects\":1}\n",
+		},
+		//3 summary is not a row of the table in stdout
+		printTrailerRecordType{
+			format: OUTPUT_FORMAT_CSV,
+			out:    "type,key,size\nobject,\"a,\"\"b\",100\n",
+			msg:    "type,objects\nsummary,1\n",
+		},
+		printTrailerRecordType{
+			format: OUTPUT_FORMAT_TSV,
+			out:    "type\tkey\tsize\nobject\t\"a,\"\"b\"\t100\n",
+			msg:    "type\tobjects\nsummary\t1\n",
+		},
+	}
+	oldOutput := recordOutput
+	oldMsgOutput := msgOutput
+	defer func() {
+		recordOutput = oldOutput
+		msgOutput = oldMsgOutput
+	}()
+	for i, tCase := range testCases {
+		buf := &bytes.Buffer{}
+		msgBuf := &bytes.Buffer{}
+		recordOutput = newRecordPrinter(tCase.format, buf)
+		msgOutput = msgBuf
+		printRecord(testOutputRecords[1])
+		printTrailerRecord(testOutputRecords[2])
+		util.ExpectEqual("output.go printTrailerRecord I", i+1, t.Errorf, tCase.out,
+			buf.String())
+		util.ExpectEqual("output.go printTrailerRecord II", i+1, t.Errorf, tCase.msg,
+			msgBuf.String())
+	}
+}
+
+type printOperationRecordType struct {
+	operation string
+	status    string
+	src       string
+	dst       string
+	err       error
+	out       string
+}
+
+func TestPrintOperationRecord(t *testing.T) {
+	testCases := []printOperationRecordType{
+		printOperationRecordType{
+			operation: SYNC_OP_UPLOAD,
+			status:    OPERATION_STATUS_SUCCESS,
+			src:       "a.txt",
+			dst:       "bos:/bucket/a.txt",
+			out: "{\"type\":\"operation\",\"operation\":\"upload\",\"status\":\"success\"," +
+				"\"source\":\"a.txt\",\"destination\":\"bos:/bucket/a.txt\"}\n",
+		},
+		printOperationRecordType{
+			operation: SYNC_OP_REMOVE,
+			status:    OPERATION_STATUS_FAILED,
+			dst:       "bos:/bucket/a.txt",
+			err:       fmt.Errorf("access denied"),
+			out: "{\"type\":\"operation\",\"operation\":\"remove\",\"status\":\"failed\"," +
+				"\"source\":\"\",\"destination\":\"bos:/bucket/a.txt\",\"error\":\"access denied\"}\n",
+		},
+	}
+	oldOutput := recordOutput
+	defer func() {
+		recordOutput = oldOutput
+	}()
+	for i, tCase := range testCases {
+		buf := &bytes.Buffer{}
+		recordOutput = newRecordPrinter(OUTPUT_FORMAT_JSONL, buf)
+		printOperationRecord(tCase.operation, tCase.status, tCase.src, tCase.dst, tCase.err)
+		util.ExpectEqual("output.go printOperationRecord", i+1, t.Errorf, tCase.out, buf.String())
+	}
+}
+
+func TestListObjectsRecords(t *testing.T) {
+	oldOutput := recordOutput
+	oldMsgOutput := msgOutput
+	defer func() {
+		recordOutput = oldOutput
+		msgOutput = oldMsgOutput
+	}()
+	buf := &bytes.Buffer{}
+	msgBuf := &bytes.Buffer{}
+	recordOutput = newRecordPrinter(OUTPUT_FORMAT_CSV, buf)
+	msgOutput = msgBuf
+	// the next marker is not a row of the table
+	marker := "type,marker\nnext_marker,a/b\n"
+
+	args := &listArgs{
+		bucketName: "0",
+		pageSize:   MAX_LIST_PAGE_SIZE,
+		maxItems:   3,
+	}
+	if err := testBosCli.listObjects(args, false, false, false); err != nil {
+		t.Errorf("list objects failed: %s", err)
+		return
+	}
+	recordOutput.finish()
+	out := "type,bucket,key,size,storage_class,mtime,etag\n" +
+		"prefix,0,a/dir/,,,,\n" +
+		"prefix,0,a/dir2/,,,,\n" +
+		"object,0,a/b,100,,2006-01-02T15:04:05Z,\n"
+	util.ExpectEqual("output.go listObjects records", 1, t.Errorf, out, buf.String())
+	util.ExpectEqual("output.go listObjects records", 1, t.Errorf, marker, msgBuf.String())
+
+	// prefixes and objects which are not symlinks have an empty target, so there is one header
+	buf.Reset()
+	msgBuf.Reset()
+	recordOutput = newRecordPrinter(OUTPUT_FORMAT_CSV, buf)
+	args.symlink = true
+	if err := testBosCli.listObjects(args, false, false, false); err != nil {
+		t.Errorf("list objects failed: %s", err)
+		return
+	}
+	recordOutput.finish()
+	out = "type,bucket,key,size,storage_class,mtime,etag,symlink_target\n" +
+		"prefix,0,a/dir/,,,,,\n" +
+		"prefix,0,a/dir2/,,,,,\n" +
+		"object,0,a/b,100,,2006-01-02T15:04:05Z,,\n"
+	util.ExpectEqual("output.go listObjects records", 2, t.Errorf, out, buf.String())
+	util.ExpectEqual("output.go listObjects records", 2, t.Errorf, marker, msgBuf.String())
+}
+
+type renderApiResultType struct {
+	format string
+	result interface{}
+	out    string
+	isSuc  bool
+}
+
+func TestRenderApiResult(t *testing.T) {
+	result := &objectMetaOutput{
+		ContentLength: 10,
+		ContentType:   "text/plain",
+		ETag:          "-123",
+		UserMeta:      map[string]string{"b": "yes", "a": "1"},
+	}
+	lifecycle := &api.GetBucketLifecycleResult{
+		Rule: []api.LifecycleRuleType{
+			api.LifecycleRuleType{
+				Id:     "1",
+				Status: "enabled",
+			},
+		},
+	}
+	testCases := []renderApiResultType{
+		renderApiResultType{
+			format: OUTPUT_FORMAT_JSON,
+			result: &bucketStorageClassOutput{StorageClass: "COLD"},
+			out:    "{\n  \"storageClass\": \"COLD\"\n}\n",
+			isSuc:  true,
+		},
+		renderApiResultType{
+			format: OUTPUT_FORMAT_YAML,
+			result: result,
+			out: "cacheControl: \"\"\ncontentCrc32: \"\"\ncontentDisposition: \"\"\n" +
+				"contentEncoding: \"\"\ncontentLength: 10\ncontentMD5: \"\"\n" +
+				"contentType: text/plain\neTag: \"-123\"\nexpires: \"\"\nlastModified: \"\"\n" +
+				"nextAppendOffset: \"\"\nobjectType: \"\"\nrestore: \"\"\nstorageClass: \"\"\n" +
+				"userMeta:\n  a: \"1\"\n  b: \"yes\"\n",
+			isSuc: true,
+		},
+		renderApiResultType{
+			format: OUTPUT_FORMAT_YAML,
+			result: lifecycle,
+			out: "rule:\n  -\n    action:\n      name: \"\"\n    condition:\n      time:\n" +
+				"        dateGreaterThan: \"\"\n    id: \"1\"\n    resource: null\n" +
+				"    status: enabled\n",
+			isSuc: true,
+		},
+		renderApiResultType{
+			format: OUTPUT_FORMAT_TABLE,
+			result: lifecycle,
+			out: "KEY                                     VALUE\n" +
+				"rule[0].action.name                     \n" +
+				"rule[0].condition.time.dateGreaterThan  \n" +
+				"rule[0].id                              1\n" +
+				"rule[0].resource                        \n" +
+				"rule[0].status                          enabled\n",
+			isSuc: true,
+		},
+		renderApiResultType{
+			format: "xml",
+			result: lifecycle,
+			isSuc:  false,
+		},
+	}
+	for i, tCase := range testCases {
+		buf := &bytes.Buffer{}
+		err := renderApiResult(buf, tCase.format, tCase.result)
+		util.ExpectEqual("output.go renderApiResult I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if tCase.isSuc {
+			util.ExpectEqual("output.go renderApiResult II", i+1, t.Errorf, tCase.out,
+				buf.String())
+		}
+	}
+}
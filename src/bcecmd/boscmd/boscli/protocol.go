@@ -14,6 +14,10 @@
 
 package boscli
 
+import (
+	"io"
+)
+
 import (
 	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/services/bos/api"
@@ -35,6 +39,11 @@ type bosClientInterface interface {
 		*api.CopyObjectResult, error)
 	BasicGetObjectToFile(string, string, string) error
 	PutObjectFromFile(string, string, string, *api.PutObjectArgs) (string, error)
+	PutObjectFromBytes(string, string, []byte, *api.PutObjectArgs) (string, error)
+	AppendObject(bucket, object string, content *bce.Body,
+		args *api.AppendObjectArgs) (*api.AppendObjectResult, error)
+	FetchObject(bucket, object, source string,
+		args *api.FetchObjectArgs) (*api.FetchObjectResult, error)
 	UploadSuperFile(string, string, string, string) error
 	PutBucketLifecycleFromString(string, string) error
 	GetBucketLifecycle(string) (*api.GetBucketLifecycleResult, error)
@@ -42,6 +51,24 @@ type bosClientInterface interface {
 	PutBucketLoggingFromStruct(string, *api.PutBucketLoggingArgs) error
 	GetBucketLogging(string) (*api.GetBucketLoggingResult, error)
 	DeleteBucketLogging(string) error
+	PutBucketCorsFromString(string, string) error
+	GetBucketCors(string) (*api.GetBucketCorsResult, error)
+	DeleteBucketCors(string) error
+	PutBucketReplicationFromString(bucket, conf, ruleId string) error
+	GetBucketReplication(bucket, ruleId string) (*api.GetBucketReplicationResult, error)
+	ListBucketReplication(bucket string) (*api.ListBucketReplicationResult, error)
+	DeleteBucketReplication(bucket, ruleId string) error
+	GetBucketReplicationProgress(bucket, ruleId string) (*api.GetBucketReplicationProgressResult,
+		error)
+	PutBucketEncryption(bucket, algorithm string) error
+	GetBucketEncryption(bucket string) (string, error)
+	DeleteBucketEncryption(bucket string) error
+	PutBucketStaticWebsiteFromStruct(string, *api.PutBucketStaticWebsiteArgs) error
+	GetBucketStaticWebsite(string) (*api.GetBucketStaticWebsiteResult, error)
+	DeleteBucketStaticWebsite(string) error
+	PutBucketTrash(string, api.PutBucketTrashReq) error
+	GetBucketTrash(string) (*api.GetBucketTrashResult, error)
+	DeleteBucketTrash(string) error
 	PutBucketStorageclass(string, string) error
 	GetBucketStorageclass(string) (string, error)
 	PutBucketAclFromCanned(string, string) error
@@ -57,6 +84,13 @@ type bosClientInterface interface {
 	CompleteMultipartUploadFromStruct(string, string, string, *api.CompleteMultipartUploadArgs,
 	) (*api.CompleteMultipartUploadResult, error)
 	GetObject(string, string, map[string]string, ...int64) (*api.GetObjectResult, error)
+	RestoreObject(bucket, object string, restoreDays int, restoreTier string) error
+	PutSymlink(bucket, object, symlinkKey string, symlinkArgs *api.PutSymlinkArgs) error
+	GetSymlink(bucket, object string) (string, error)
+	PutObjectAclFromCanned(bucket, object, cannedAcl string) error
+	PutObjectAclFromString(bucket, object, acl string) error
+	GetObjectAcl(bucket, object string) (*api.GetObjectAclResult, error)
+	DeleteObjectAcl(bucket, object string) error
 }
 
 // Interface for bos cli handler
@@ -65,16 +99,25 @@ type handlerInterface interface {
 	multiDeleteObjectsWithRetry(bosClientInterface, []string, string) ([]api.DeleteObjectResult,
 		error)
 	utilDeleteObject(bosClientInterface, string, string) error
+	utilDeleteObjects(bosClientInterface, string, []string) (int, int, error)
 	utilCopyObject(bosClientInterface, bosClientInterface, string, string, string, string, string,
-		int64, int64, int64, bool) error
+		int64, int64, int64, bool, *objectMetaArgs) error
+	utilSetStorageClass(bosClientInterface, string, string, string, int64, int64, int64,
+		bool) error
+	utilUndeleteObject(bosClientInterface, string, string, string, string, int64, int64,
+		int64) error
 	utilDownloadObject(bosClientInterface, string, string, string, string, bool, int64, int64, int64,
 		bool) error
 	utilUploadFile(bosClientInterface, string, string, string, string, string, int64, int64,
-		int64, bool) error
+		int64, bool, *objectMetaArgs) error
+	utilDownloadToStream(bosClientInterface, string, string, io.Writer) error
+	utilDownloadObjectRange(bosClientInterface, string, string, string, bool, int64, int64) error
+	utilGetObjectRange(bosClientInterface, string, string, int64, int64, bool, io.Writer) error
+	utilUploadStream(bosClientInterface, io.Reader, string, string, string, *objectMetaArgs) error
 	utilDeleteLocalFile(string) error
 	doesBucketExist(bosClientInterface, string) (bool, error)
 	CopySuperFile(bosClientInterface, bosClientInterface, string, string, string, string,
-		string, int64, int64, int64, bool, string) error
+		string, int64, int64, int64, bool, string, *objectMetaArgs) error
 }
 
 // File Information: be used by BOS object and local file.
@@ -83,11 +126,15 @@ type fileDetail struct {
 	name         string // without replcae os sep to bos sep
 	key          string // both
 	realPath     string // local file, real path of symbolic link
+	isSymlink    bool   // local file, whether it is a symbolic link
+	linkTarget   string // bos object, target of symlink, only set by ls --symlink
 	storageClass string // bos object
+	restore      string // bos object, value of x-bce-restore, only set by getObjectMeta
 	crc32        string
-	size         int64 // both
-	mtime        int64 // both, last Modified time
-	gtime        int64 // both, the time of get info of this object
+	etag         string // bos object
+	size         int64  // both
+	mtime        int64  // both, last Modified time
+	gtime        int64  // both, the time of get info of this object
 	isDir        bool
 	err          error // both
 }
@@ -117,7 +164,41 @@ type fileListIterator interface {
 	next() (*listFileResult, error)
 }
 
+// HTTP headers, user metadata and ACL of objects uploaded or copied by cp and sync, given by
+// the command line
+type ObjectMetaOptions struct {
+	ContentType        string
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	Expires            string
+	Meta               []string // user metadata in the format of key=value
+	MetadataDirective  string   // copy or replace, only for copying between BOS
+	CannedAcl          string   // canned ACL set after objects are uploaded or copied
+}
+
+// Parsed ObjectMetaOptions, a nil *objectMetaArgs means nothing is set
+type objectMetaArgs struct {
+	contentType        string
+	cacheControl       string
+	contentDisposition string
+	contentEncoding    string
+	expires            string
+	userMeta           map[string]string
+	metadataDirective  string
+	cannedAcl          string
+}
+
+// server-side encryption of objects uploaded, copied or downloaded by cp and sync, given by
+// the command line
+type SseOptions struct {
+	Algorithm         string // encrypt objects with keys managed by BOS, only AES256
+	CustomerKeyFile   string // file of the customer-provided key of the objects transferred
+	CopySourceKeyFile string // file of the customer-provided key of source objects of copy
+}
+
 type executeResult struct {
 	failed    int
 	successed int
+	skipped   int // only counted by commands which don't take skipped objects as failures
 }
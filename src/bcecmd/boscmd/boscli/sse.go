@@ -0,0 +1,359 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+// This module sends the server-side encryption headers of objects transferred by cp and sync.
+
+package boscli
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+import (
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/baidubce/bce-sdk-go/services/bos"
+	"github.com/baidubce/bce-sdk-go/services/bos/api"
+)
+
+// Parsed SseOptions, a nil *sseArgs means no encryption header is sent
+type sseArgs struct {
+	algorithm     string // encryption of uploaded or copied objects with keys managed by BOS
+	customerKey   []byte // key of uploaded or copied objects, or of downloaded objects
+	copySourceKey []byte // key of source objects copied between BOS
+}
+
+// parse the server-side encryption set by cp and sync
+// PARAMS:
+//   srcIsBos: the source is BOS
+//   dstIsBos: the destination is BOS
+func newSseArgs(opts SseOptions, srcIsBos, dstIsBos bool) (*sseArgs, BosCliErrorCode, error) {
+	var err error
+
+	if opts.Algorithm == "" && opts.CustomerKeyFile == "" && opts.CopySourceKeyFile == "" {
+		return nil, BOSCLI_OK, nil
+	}
+
+	algorithm := strings.ToUpper(opts.Algorithm)
+	if algorithm != "" && algorithm != api.ENCRYPTION_AES256 {
+		return nil, BOSCLI_SSE_ALGORITHM_INVALID, fmt.Errorf(
+			"unsupported server-side encryption algorithm: %s", opts.Algorithm)
+	}
+	if algorithm != "" && opts.CustomerKeyFile != "" {
+		return nil, BOSCLI_SSE_ARGS_CONFLICT, fmt.Errorf("--sse and --sse-c-key-file can not " +
+			"be used together")
+	}
+	if algorithm != "" && !dstIsBos {
+		return nil, BOSCLI_SSE_ARGS_CONFLICT, fmt.Errorf("--sse can only be used when " +
+			"uploading or copying objects")
+	}
+	if opts.CopySourceKeyFile != "" && !(srcIsBos && dstIsBos) {
+		return nil, BOSCLI_SSE_ARGS_CONFLICT, fmt.Errorf("--sse-c-copy-source-key-file can only " +
+			"be used when copying between BOS")
+	}
+
+	sse := &sseArgs{algorithm: algorithm}
+	if opts.CustomerKeyFile != "" {
+		if sse.customerKey, err = readCustomerKey(opts.CustomerKeyFile); err != nil {
+			return nil, BOSCLI_SSE_CUSTOMER_KEY_INVALID, err
+		}
+	}
+	if opts.CopySourceKeyFile != "" {
+		if sse.copySourceKey, err = readCustomerKey(opts.CopySourceKeyFile); err != nil {
+			return nil, BOSCLI_SSE_CUSTOMER_KEY_INVALID, err
+		}
+	}
+	return sse, BOSCLI_OK, nil
+}
+
+// read customer-provided key, the file contains the raw key or its base64 encoding
+func readCustomerKey(keyFile string) ([]byte, error) {
+	content, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == SSE_CUSTOMER_KEY_LEN_BYTES {
+		return content, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil || len(key) != SSE_CUSTOMER_KEY_LEN_BYTES {
+		return nil, fmt.Errorf("the key in %s is neither %d bytes nor the base64 encoding of "+
+			"%d bytes", keyFile, SSE_CUSTOMER_KEY_LEN_BYTES, SSE_CUSTOMER_KEY_LEN_BYTES)
+	}
+	return key, nil
+}
+
+// headers of the customer-provided key, BOS needs them to read or write any part of objects
+// encrypted by the key
+func (s *sseArgs) customerKeyHeaders() map[string]string {
+	headers := make(map[string]string)
+	setCustomerKeyHeaders(headers, s.customerKey, SSE_CUSTOMER_ALGORITHM, SSE_CUSTOMER_KEY,
+		SSE_CUSTOMER_KEY_MD5)
+	return headers
+}
+
+// headers of requests creating objects, such as put object and initiate multipart upload
+func (s *sseArgs) writeHeaders() map[string]string {
+	headers := s.customerKeyHeaders()
+	if s.algorithm != "" {
+		headers[SSE_HEADER] = s.algorithm
+	}
+	return headers
+}
+
+// add the key of the source object to the headers of copy, an object copied to itself, e.g.
+// changing its storage class, is encrypted by the customer key
+func (s *sseArgs) withCopySourceHeaders(headers map[string]string,
+	selfCopy bool) map[string]string {
+
+	key := s.copySourceKey
+	if key == nil && selfCopy {
+		key = s.customerKey
+	}
+	setCustomerKeyHeaders(headers, key, SSE_COPY_SOURCE_ALGORITHM, SSE_COPY_SOURCE_KEY,
+		SSE_COPY_SOURCE_KEY_MD5)
+	return headers
+}
+
+func setCustomerKeyHeaders(headers map[string]string, key []byte, algorithmHeader, keyHeader,
+	md5Header string) {
+
+	if key == nil {
+		return
+	}
+	md5Sum := md5.Sum(key)
+	headers[algorithmHeader] = api.ENCRYPTION_AES256
+	headers[keyHeader] = base64.StdEncoding.EncodeToString(key)
+	headers[md5Header] = base64.StdEncoding.EncodeToString(md5Sum[:])
+}
+
+// bce client which adds headers to every request, go sdk can't set encryption headers by args
+type headerClient struct {
+	bce.Client
+	headers map[string]string
+}
+
+func (h *headerClient) SendRequest(req *bce.BceRequest, resp *bce.BceResponse) error {
+	for key, val := range h.headers {
+		req.SetHeader(key, val)
+	}
+	return h.Client.SendRequest(req, resp)
+}
+
+func (h *headerClient) SendRequestFromBytes(req *bce.BceRequest, resp *bce.BceResponse,
+	content []byte) error {
+
+	for key, val := range h.headers {
+		req.SetHeader(key, val)
+	}
+	return h.Client.SendRequestFromBytes(req, resp, content)
+}
+
+// bos client which sends the encryption headers with requests reading or writing objects,
+// other requests are sent by the client it decorates
+type sseBosClient struct {
+	bosClientInterface
+	sse *sseArgs
+}
+
+func newSseBosClient(bosClient bosClientInterface, sse *sseArgs) *sseBosClient {
+	return &sseBosClient{bosClientInterface: bosClient, sse: sse}
+}
+
+// send a request by api of go sdk with headers, it is retried with the endpoint of the bucket
+// when the client is a *bosClientWrapper
+func sendWithHeaders(bosClient bosClientInterface, bucket string, headers map[string]string,
+	call func(cli bce.Client) error) error {
+
+	switch client := bosClient.(type) {
+	case *bosClientWrapper:
+		sendFunc := func(bosClient *bos.Client, req boscliReq, resp interface{}) error {
+			return call(&headerClient{Client: bosClient, headers: headers})
+		}
+		return retryHandler(client.bosClient, sendFunc, &olnyOneStringReq{bucket: bucket}, nil)
+	case bce.Client:
+		return call(&headerClient{Client: client, headers: headers})
+	}
+	return fmt.Errorf("headers can not be sent by this client")
+}
+
+func (s *sseBosClient) send(bucket string, headers map[string]string,
+	call func(cli bce.Client) error) error {
+
+	return sendWithHeaders(s.bosClientInterface, bucket, headers, call)
+}
+
+func (s *sseBosClient) PutObjectFromFile(bucket, object, fileName string,
+	args *api.PutObjectArgs) (string, error) {
+
+	var etag string
+	err := s.send(bucket, s.sse.writeHeaders(), func(cli bce.Client) error {
+		body, err := bce.NewBodyFromFile(fileName)
+		if err != nil {
+			return err
+		}
+		etag, err = api.PutObject(cli, bucket, object, body, args)
+		return err
+	})
+	return etag, err
+}
+
+func (s *sseBosClient) PutObjectFromBytes(bucket, object string, bytesArr []byte,
+	args *api.PutObjectArgs) (string, error) {
+
+	var etag string
+	err := s.send(bucket, s.sse.writeHeaders(), func(cli bce.Client) error {
+		body, err := bce.NewBodyFromBytes(bytesArr)
+		if err != nil {
+			return err
+		}
+		etag, err = api.PutObject(cli, bucket, object, body, args)
+		return err
+	})
+	return etag, err
+}
+
+func (s *sseBosClient) InitiateMultipartUpload(bucket, object, contentType string,
+	args *api.InitiateMultipartUploadArgs) (*api.InitiateMultipartUploadResult, error) {
+
+	var ret *api.InitiateMultipartUploadResult
+	err := s.send(bucket, s.sse.writeHeaders(), func(cli bce.Client) error {
+		var err error
+		ret, err = api.InitiateMultipartUpload(cli, bucket, object, contentType, args)
+		return err
+	})
+	return ret, err
+}
+
+func (s *sseBosClient) UploadPartFromBytes(bucket, object, uploadId string, partNumber int,
+	content []byte, args *api.UploadPartArgs) (string, error) {
+
+	var etag string
+	err := s.send(bucket, s.sse.customerKeyHeaders(), func(cli bce.Client) error {
+		var err error
+		etag, err = api.UploadPartFromBytes(cli, bucket, object, uploadId, partNumber, content,
+			args)
+		return err
+	})
+	return etag, err
+}
+
+func (s *sseBosClient) CopyObject(bucket, object, srcBucket, srcObject string,
+	args *api.CopyObjectArgs) (*api.CopyObjectResult, error) {
+
+	var ret *api.CopyObjectResult
+	selfCopy := bucket == srcBucket && object == srcObject
+	headers := s.sse.withCopySourceHeaders(s.sse.writeHeaders(), selfCopy)
+	err := s.send(bucket, headers, func(cli bce.Client) error {
+		var err error
+		source := fmt.Sprintf("/%s/%s", srcBucket, srcObject)
+		ret, err = api.CopyObject(cli, bucket, object, source, args)
+		return err
+	})
+	return ret, err
+}
+
+// the encryption of the destination object is set by initiate multipart upload, so only the
+// keys are sent with part copies
+func (s *sseBosClient) UploadPartCopy(bucket, object, srcBucket, srcObject, uploadId string,
+	partNumber int, args *api.UploadPartCopyArgs) (*api.CopyObjectResult, error) {
+
+	var ret *api.CopyObjectResult
+	selfCopy := bucket == srcBucket && object == srcObject
+	headers := s.sse.withCopySourceHeaders(s.sse.customerKeyHeaders(), selfCopy)
+	err := s.send(bucket, headers, func(cli bce.Client) error {
+		var err error
+		source := fmt.Sprintf("/%s/%s", srcBucket, srcObject)
+		ret, err = api.UploadPartCopy(cli, bucket, object, source, uploadId, partNumber, args)
+		return err
+	})
+	return ret, err
+}
+
+func (s *sseBosClient) GetObject(bucket, object string, responseHeaders map[string]string,
+	ranges ...int64) (*api.GetObjectResult, error) {
+
+	var ret *api.GetObjectResult
+	err := s.send(bucket, s.sse.customerKeyHeaders(), func(cli bce.Client) error {
+		var err error
+		ret, err = api.GetObject(cli, bucket, object, responseHeaders, ranges...)
+		return err
+	})
+	return ret, err
+}
+
+func (s *sseBosClient) BasicGetObjectToFile(bucket, object, filePath string) error {
+	res, err := s.GetObject(bucket, object, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	file, err := os.OpenFile(filePath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	written, err := io.CopyN(file, res.Body, res.ContentLength)
+	if err != nil {
+		return err
+	}
+	if written != res.ContentLength {
+		return fmt.Errorf("written content size does not match the response content")
+	}
+	return nil
+}
+
+func (s *sseBosClient) GetObjectMeta(bucket, object string) (*api.GetObjectMetaResult, error) {
+	var ret *api.GetObjectMetaResult
+	err := s.send(bucket, s.sse.customerKeyHeaders(), func(cli bce.Client) error {
+		var err error
+		ret, err = api.GetObjectMeta(cli, bucket, object)
+		return err
+	})
+	return ret, err
+}
+
+// a copy of bos cli whose client sends the encryption headers of cp and sync
+func (b *BosCli) withSse(sse *sseArgs) *BosCli {
+	if sse == nil {
+		return b
+	}
+	return &BosCli{
+		bosClient: newSseBosClient(b.bosClient, sse),
+		handler:   b.handler,
+		sse:       sse,
+	}
+}
+
+// init the client of the source bucket of cp and sync, source objects are read with the copy
+// source key when they are copied to BOS, and with the customer key when they are downloaded
+func (b *BosCli) initSrcBosClient(bucketName string, toBos bool) (bosClientInterface, error) {
+	bosClient, err := initBosClientForBucket("", "", bucketName)
+	if err != nil || b.sse == nil {
+		return bosClient, err
+	}
+	key := b.sse.customerKey
+	if toBos {
+		key = b.sse.copySourceKey
+	}
+	if key == nil {
+		return bosClient, nil
+	}
+	return newSseBosClient(bosClient, &sseArgs{customerKey: key}), nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+package boscli
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/baidubce/bce-sdk-go/bce"
+	"utils/util"
+)
+
+// fake bce client which records the headers of the last request instead of sending it
+type fakeSseClient struct {
+	*fakeBosClient
+	headers map[string]string
+}
+
+func (f *fakeSseClient) SendRequest(req *bce.BceRequest, resp *bce.BceResponse) error {
+	f.headers = req.Headers()
+	return fmt.Errorf("fake send")
+}
+
+func (f *fakeSseClient) SendRequestFromBytes(req *bce.BceRequest, resp *bce.BceResponse,
+	content []byte) error {
+	f.headers = req.Headers()
+	return fmt.Errorf("fake send")
+}
+
+func (f *fakeSseClient) GetBceClientConfig() *bce.BceClientConfiguration {
+	return &bce.BceClientConfiguration{Endpoint: "bj.bcebos.com"}
+}
+
+type newSseArgsType struct {
+	opts          SseOptions
+	srcIsBos      bool
+	dstIsBos      bool
+	code          BosCliErrorCode
+	isNil         bool
+	algorithm     string
+	customerKey   string
+	copySourceKey string
+}
+
+func TestNewSseArgs(t *testing.T) {
+	localDir := "./test_sse_args"
+	util.TryMkdir(localDir)
+	defer os.RemoveAll(localDir)
+
+	rawKey := strings.Repeat("k", SSE_CUSTOMER_KEY_LEN_BYTES)
+	rawKeyFile := filepath.Join(localDir, "raw.key")
+	base64KeyFile := filepath.Join(localDir, "base64.key")
+	shortKeyFile := filepath.Join(localDir, "short.key")
+	ioutil.WriteFile(rawKeyFile, []byte(rawKey), 0644)
+	ioutil.WriteFile(base64KeyFile, []byte(base64.StdEncoding.EncodeToString([]byte(rawKey))+
+		"\n"), 0644)
+	ioutil.WriteFile(shortKeyFile, []byte("short"), 0644)
+
+	testCases := []newSseArgsType{
+		//1 nothing is set
+		newSseArgsType{dstIsBos: true, code: BOSCLI_OK, isNil: true},
+		//2 only AES256
+		newSseArgsType{
+			opts:     SseOptions{Algorithm: "SM4"},
+			dstIsBos: true,
+			code:     BOSCLI_SSE_ALGORITHM_INVALID,
+		},
+		//3 keys managed by BOS and customer-provided key
+		newSseArgsType{
+			opts:     SseOptions{Algorithm: "AES256", CustomerKeyFile: rawKeyFile},
+			dstIsBos: true,
+			code:     BOSCLI_SSE_ARGS_CONFLICT,
+		},
+		//4 --sse when downloading
+		newSseArgsType{
+			opts:     SseOptions{Algorithm: "AES256"},
+			srcIsBos: true,
+			code:     BOSCLI_SSE_ARGS_CONFLICT,
+		},
+		//5 copy source key when uploading
+		newSseArgsType{
+			opts:     SseOptions{CopySourceKeyFile: rawKeyFile},
+			dstIsBos: true,
+			code:     BOSCLI_SSE_ARGS_CONFLICT,
+		},
+		//6 key file doesn't exist
+		newSseArgsType{
+			opts:     SseOptions{CustomerKeyFile: filepath.Join(localDir, "none")},
+			dstIsBos: true,
+			code:     BOSCLI_SSE_CUSTOMER_KEY_INVALID,
+		},
+		//7 key is too short
+		newSseArgsType{
+			opts:     SseOptions{CustomerKeyFile: shortKeyFile},
+			srcIsBos: true,
+			code:     BOSCLI_SSE_CUSTOMER_KEY_INVALID,
+		},
+		//8 algorithm is case insensitive
+		newSseArgsType{
+			opts:      SseOptions{Algorithm: "aes256"},
+			dstIsBos:  true,
+			code:      BOSCLI_OK,
+			algorithm: "AES256",
+		},
+		//9 raw key when downloading
+		newSseArgsType{
+			opts:        SseOptions{CustomerKeyFile: rawKeyFile},
+			srcIsBos:    true,
+			code:        BOSCLI_OK,
+			customerKey: rawKey,
+		},
+		//10 base64 key and copy source key when copying
+		newSseArgsType{
+			opts: SseOptions{CustomerKeyFile: base64KeyFile,
+				CopySourceKeyFile: rawKeyFile},
+			srcIsBos:      true,
+			dstIsBos:      true,
+			code:          BOSCLI_OK,
+			customerKey:   rawKey,
+			copySourceKey: rawKey,
+		},
+	}
+	for i, tCase := range testCases {
+		sse, code, err := newSseArgs(tCase.opts, tCase.srcIsBos, tCase.dstIsBos)
+		util.ExpectEqual("sse.go newSseArgs I", i+1, t.Errorf, tCase.code, code)
+		if code != BOSCLI_OK {
+			util.ExpectEqual("sse.go newSseArgs II", i+1, t.Errorf, true, err != nil)
+			continue
+		}
+		util.ExpectEqual("sse.go newSseArgs III", i+1, t.Errorf, tCase.isNil, sse == nil)
+		if sse != nil {
+			util.ExpectEqual("sse.go newSseArgs IV", i+1, t.Errorf, tCase.algorithm,
+				sse.algorithm)
+			util.ExpectEqual("sse.go newSseArgs V", i+1, t.Errorf, tCase.customerKey,
+				string(sse.customerKey))
+			util.ExpectEqual("sse.go newSseArgs VI", i+1, t.Errorf, tCase.copySourceKey,
+				string(sse.copySourceKey))
+		}
+	}
+}
+
+func TestSseArgsHeaders(t *testing.T) {
+	key := []byte(strings.Repeat("k", SSE_CUSTOMER_KEY_LEN_BYTES))
+	srcKey := []byte(strings.Repeat("s", SSE_CUSTOMER_KEY_LEN_BYTES))
+
+	// keys managed by BOS
+	sse := &sseArgs{algorithm: "AES256"}
+	util.ExpectEqual("sse.go writeHeaders I", 1, t.Errorf,
+		map[string]string{SSE_HEADER: "AES256"}, sse.writeHeaders())
+	util.ExpectEqual("sse.go customerKeyHeaders I", 1, t.Errorf, 0,
+		len(sse.customerKeyHeaders()))
+	util.ExpectEqual("sse.go withCopySourceHeaders I", 1, t.Errorf, 1,
+		len(sse.withCopySourceHeaders(sse.writeHeaders(), true)))
+
+	// customer-provided key
+	sse = &sseArgs{customerKey: key}
+	headers := sse.writeHeaders()
+	util.ExpectEqual("sse.go writeHeaders II", 1, t.Errorf, 3, len(headers))
+	util.ExpectEqual("sse.go writeHeaders III", 1, t.Errorf, "AES256",
+		headers[SSE_CUSTOMER_ALGORITHM])
+	util.ExpectEqual("sse.go writeHeaders IV", 1, t.Errorf,
+		base64.StdEncoding.EncodeToString(key), headers[SSE_CUSTOMER_KEY])
+	util.ExpectEqual("sse.go writeHeaders V", 1, t.Errorf, "mT2HRsMGJ5IX5C+0rreZ8Q==",
+		headers[SSE_CUSTOMER_KEY_MD5])
+
+	// the customer key is the key of source when an object is copied to itself
+	headers = sse.withCopySourceHeaders(sse.writeHeaders(), true)
+	util.ExpectEqual("sse.go withCopySourceHeaders II", 1, t.Errorf,
+		headers[SSE_CUSTOMER_KEY], headers[SSE_COPY_SOURCE_KEY])
+	headers = sse.withCopySourceHeaders(sse.writeHeaders(), false)
+	util.ExpectEqual("sse.go withCopySourceHeaders III", 1, t.Errorf, "",
+		headers[SSE_COPY_SOURCE_KEY])
+
+	// copy source key
+	sse = &sseArgs{customerKey: key, copySourceKey: srcKey}
+	headers = sse.withCopySourceHeaders(sse.writeHeaders(), true)
+	util.ExpectEqual("sse.go withCopySourceHeaders IV", 1, t.Errorf, 6, len(headers))
+	util.ExpectEqual("sse.go withCopySourceHeaders V", 1, t.Errorf,
+		base64.StdEncoding.EncodeToString(srcKey), headers[SSE_COPY_SOURCE_KEY])
+}
+
+func TestSseBosClient(t *testing.T) {
+	key := []byte(strings.Repeat("k", SSE_CUSTOMER_KEY_LEN_BYTES))
+	srcKey := []byte(strings.Repeat("s", SSE_CUSTOMER_KEY_LEN_BYTES))
+	fake := &fakeSseClient{fakeBosClient: &fakeBosClient{}}
+
+	// uploads
+	sseClient := newSseBosClient(fake, &sseArgs{algorithm: "AES256"})
+	sseClient.PutObjectFromBytes("bucket", "object", []byte("content"), nil)
+	util.ExpectEqual("sse.go PutObjectFromBytes", 1, t.Errorf, "AES256", fake.headers[SSE_HEADER])
+	sseClient.InitiateMultipartUpload("bucket", "object", "", nil)
+	util.ExpectEqual("sse.go InitiateMultipartUpload", 1, t.Errorf, "AES256",
+		fake.headers[SSE_HEADER])
+	sseClient.UploadPartFromBytes("bucket", "object", "id", 1, []byte("content"), nil)
+	util.ExpectEqual("sse.go UploadPartFromBytes I", 1, t.Errorf, "",
+		fake.headers[SSE_HEADER])
+
+	sseClient = newSseBosClient(fake, &sseArgs{customerKey: key, copySourceKey: srcKey})
+	sseClient.UploadPartFromBytes("bucket", "object", "id", 1, []byte("content"), nil)
+	util.ExpectEqual("sse.go UploadPartFromBytes II", 1, t.Errorf,
+		base64.StdEncoding.EncodeToString(key), fake.headers[SSE_CUSTOMER_KEY])
+
+	// part copy sends both keys
+	sseClient.UploadPartCopy("bucket", "object", "src", "srcObject", "id", 1, nil)
+	util.ExpectEqual("sse.go UploadPartCopy I", 1, t.Errorf,
+		base64.StdEncoding.EncodeToString(key), fake.headers[SSE_CUSTOMER_KEY])
+	util.ExpectEqual("sse.go UploadPartCopy II", 1, t.Errorf,
+		base64.StdEncoding.EncodeToString(srcKey), fake.headers[SSE_COPY_SOURCE_KEY])
+
+	// ranged download
+	sseClient = newSseBosClient(fake, &sseArgs{customerKey: key})
+	_, err := sseClient.GetObject("bucket", "object", nil, 0, 99)
+	util.ExpectEqual("sse.go GetObject I", 1, t.Errorf, "fake send", err.Error())
+	util.ExpectEqual("sse.go GetObject II", 1, t.Errorf,
+		base64.StdEncoding.EncodeToString(key), fake.headers[SSE_CUSTOMER_KEY])
+	util.ExpectEqual("sse.go GetObject III", 1, t.Errorf, "bytes=0-99", fake.headers["Range"])
+
+	// other requests are sent by the decorated client
+	_, err = sseClient.ListBuckets()
+	util.ExpectEqual("sse.go ListBuckets", 1, t.Errorf, "test", err.Error())
+
+	// client which can't send requests with headers
+	sseClient = newSseBosClient(&fakeBosClient{}, &sseArgs{customerKey: key})
+	_, err = sseClient.GetObjectMeta("bucket", "object")
+	util.ExpectEqual("sse.go GetObjectMeta", 1, t.Errorf, true, err != nil)
+}
@@ -0,0 +1,156 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+// This module creates symlink objects, by ln or by uploading local symbolic links.
+
+package boscli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+import (
+	"bcecmd/boscmd"
+)
+
+type linkArgs struct {
+	bucketName string
+	targetKey  string
+	linkKey    string
+}
+
+// ln: create a symlink object pointing to the target object, BOS only supports symlinks whose
+// target is in the same bucket.
+// PARAMS:
+//   targetPath : bos path of the target object, it doesn't need to exist
+//   linkPath   : bos path of the symlink, the name of target is used when it ends with "/"
+func (b *BosCli) Link(targetPath, linkPath string, quiet bool) {
+	defer finishRecords()
+	Quiet = quiet
+
+	args, retCode, err := b.linkPreProcess(targetPath, linkPath)
+	if retCode != BOSCLI_OK {
+		bcecliAbnormalExistCodeErr(retCode, err)
+	}
+	if err := b.putSymlink(args.bucketName, args.targetKey, args.linkKey); err != nil {
+		bcecliAbnormalExistErr(err)
+	}
+}
+
+// check the request of ln
+func (b *BosCli) linkPreProcess(targetPath, linkPath string) (*linkArgs, BosCliErrorCode,
+	error) {
+
+	for _, bosPath := range []string{targetPath, linkPath} {
+		if retCode, err := checkBosPath(bosPath); err != nil {
+			return nil, retCode, err
+		}
+	}
+
+	bucketName, targetKey := splitBosBucketKey(targetPath)
+	linkBucketName, linkKey := splitBosBucketKey(linkPath)
+	if bucketName == "" || linkBucketName == "" {
+		return nil, BOSCLI_BUCKETNAME_IS_EMPTY, fmt.Errorf("bucket name is empty")
+	}
+	if bucketName != linkBucketName {
+		return nil, BOSCLI_LN_NOT_SAME_BUCKET, fmt.Errorf("the target and the symlink must be "+
+			"in the same bucket, but they are in %s and %s", bucketName, linkBucketName)
+	}
+	if targetKey == "" || strings.HasSuffix(targetKey, boscmd.BOS_PATH_SEPARATOR) {
+		return nil, BOSCLI_LN_TARGET_IS_DIR, fmt.Errorf("the target %s is not an object",
+			targetPath)
+	}
+
+	// like ln, the symlink is created in the directory with the name of target
+	if linkKey == "" || strings.HasSuffix(linkKey, boscmd.BOS_PATH_SEPARATOR) {
+		linkKey += getObjectNameFromObjectKey(targetKey)
+	}
+	if linkKey == targetKey {
+		return nil, BOSCLI_LN_LINK_TO_ITSELF, fmt.Errorf("the symlink can't point to itself")
+	}
+	return &linkArgs{
+		bucketName: bucketName,
+		targetKey:  targetKey,
+		linkKey:    linkKey,
+	}, BOSCLI_OK, nil
+}
+
+// create the symlink and print the operation
+func (b *BosCli) putSymlink(bucketName, targetKey, linkKey string) error {
+	targetPath := BOS_PATH_PREFIX + bucketName + "/" + targetKey
+	linkPath := BOS_PATH_PREFIX + bucketName + "/" + linkKey
+	if err := b.bosClient.PutSymlink(bucketName, targetKey, linkKey, nil); err != nil {
+		printOperationRecord(SYNC_OP_LINK, OPERATION_STATUS_FAILED, targetPath, linkPath, err)
+		return err
+	}
+	printIfNotQuiet("%s: %s -> %s\n", SYNC_OP_LINK, linkPath, targetPath)
+	printOperationRecord(SYNC_OP_LINK, OPERATION_STATUS_SUCCESS, targetPath, linkPath, nil)
+	return nil
+}
+
+// get the target of a symlink object, it is resolved by get symlink when it isn't returned in
+// the headers of head object
+func (b *BosCli) getSymlinkTarget(stat *objectStat) string {
+	if !stat.isSymlink || stat.symlinkTarget != "" {
+		return stat.symlinkTarget
+	}
+	target, err := b.bosClient.GetSymlink(stat.bucketName, stat.key)
+	if err != nil {
+		return ""
+	}
+	return target
+}
+
+// symlinkMapper maps local symbolic links to symlink objects when a directory is uploaded by cp
+// or sync. Only links to files in the uploaded directory are mapped, since their targets are
+// uploaded too, other links are still followed. A nil *symlinkMapper maps nothing.
+type symlinkMapper struct {
+	srcDir     string // absolute path of the uploaded directory
+	realSrcDir string // srcDir with symbolic links resolved
+}
+
+func newSymlinkMapper(srcDir string) (*symlinkMapper, error) {
+	realSrcDir, err := filepath.EvalSymlinks(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	return &symlinkMapper{
+		srcDir:     filepath.Clean(srcDir),
+		realSrcDir: realSrcDir,
+	}, nil
+}
+
+// get the key of the target object of a local symbolic link which is uploaded to linkKey. The
+// relative path of the link at the end of linkKey is replaced with the relative path of target.
+// RETURN:
+//   false when the file isn't a symbolic link or its target is out of the uploaded directory
+func (m *symlinkMapper) targetKey(file *fileDetail, linkKey string) (string, bool) {
+	if m == nil || !file.isSymlink {
+		return "", false
+	}
+	linkRel, err := filepath.Rel(m.srcDir, file.path)
+	if err != nil {
+		return "", false
+	}
+	linkRel = filepath.ToSlash(linkRel)
+	if !strings.HasSuffix(linkKey, linkRel) {
+		return "", false
+	}
+	targetRel, err := filepath.Rel(m.realSrcDir, file.realPath)
+	if err != nil || targetRel == ".." ||
+		strings.HasPrefix(targetRel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return linkKey[:len(linkKey)-len(linkRel)] + filepath.ToSlash(targetRel), true
+}
@@ -0,0 +1,286 @@
+// Copyright 2017 Baidu, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the specific language governing permissions
+// and limitations under the License.
+
+package boscli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+import (
+	"utils/util"
+)
+
+type linkPreProcessType struct {
+	targetPath string
+	linkPath   string
+	out        *linkArgs
+	code       BosCliErrorCode
+}
+
+func TestLinkPreProcess(t *testing.T) {
+	testCases := []linkPreProcessType{
+		//1
+		linkPreProcessType{
+			targetPath: "/bucket/a",
+			linkPath:   "bos:/bucket/b",
+			code:       BOSCLI_BOSPATH_IS_INVALID,
+		},
+		//2
+		linkPreProcessType{
+			targetPath: "bos:/",
+			linkPath:   "bos:/bucket/b",
+			code:       BOSCLI_BUCKETNAME_IS_EMPTY,
+		},
+		//3
+		linkPreProcessType{
+			targetPath: "bos:/bucket/a",
+			linkPath:   "bos:/other/b",
+			code:       BOSCLI_LN_NOT_SAME_BUCKET,
+		},
+		//4
+		linkPreProcessType{
+			targetPath: "bos:/bucket/dir/",
+			linkPath:   "bos:/bucket/b",
+			code:       BOSCLI_LN_TARGET_IS_DIR,
+		},
+		//5
+		linkPreProcessType{
+			targetPath: "bos:/bucket",
+			linkPath:   "bos:/bucket/b",
+			code:       BOSCLI_LN_TARGET_IS_DIR,
+		},
+		//6
+		linkPreProcessType{
+			targetPath: "bos:/bucket/dir/a",
+			linkPath:   "bos:/bucket/dir/a",
+			code:       BOSCLI_LN_LINK_TO_ITSELF,
+		},
+		//7
+		linkPreProcessType{
+			targetPath: "bos:/bucket/dir/a",
+			linkPath:   "bos:/bucket/dir/",
+			code:       BOSCLI_LN_LINK_TO_ITSELF,
+		},
+		//8
+		linkPreProcessType{
+			targetPath: "bos:/bucket/dir/a",
+			linkPath:   "bos:/bucket/b",
+			out:        &linkArgs{bucketName: "bucket", targetKey: "dir/a", linkKey: "b"},
+			code:       BOSCLI_OK,
+		},
+		//9 the name of target is used
+		linkPreProcessType{
+			targetPath: "bos:/bucket/dir/a",
+			linkPath:   "bos:/bucket/other/",
+			out:        &linkArgs{bucketName: "bucket", targetKey: "dir/a", linkKey: "other/a"},
+			code:       BOSCLI_OK,
+		},
+		//10
+		linkPreProcessType{
+			targetPath: "bos:/bucket/dir/a",
+			linkPath:   "bos:/bucket",
+			out:        &linkArgs{bucketName: "bucket", targetKey: "dir/a", linkKey: "a"},
+			code:       BOSCLI_OK,
+		},
+	}
+	for i, tCase := range testCases {
+		ret, code, err := testBosCli.linkPreProcess(tCase.targetPath, tCase.linkPath)
+		util.ExpectEqual("symlink.go linkPreProcess I", i+1, t.Errorf, tCase.code, code)
+		if code != BOSCLI_OK {
+			util.ExpectEqual("symlink.go linkPreProcess II", i+1, t.Errorf, true, err != nil)
+			continue
+		}
+		util.ExpectEqual("symlink.go linkPreProcess III", i+1, t.Errorf, tCase.out, ret)
+	}
+}
+
+type putSymlinkType struct {
+	targetKey string
+	linkKey   string
+	symlinks  []string
+	isSuc     bool
+}
+
+func TestPutSymlink(t *testing.T) {
+	testCases := []putSymlinkType{
+		//1
+		putSymlinkType{
+			targetKey: "dir/a",
+			linkKey:   "b",
+			symlinks:  []string{"bucket/b->dir/a"},
+			isSuc:     true,
+		},
+		//2
+		putSymlinkType{
+			targetKey: "dir/a",
+			linkKey:   "error",
+			isSuc:     false,
+		},
+	}
+	fakeClient, ok := testBosCli.bosClient.(*fakeBosClientForBos)
+	if !ok {
+		t.Errorf("symlink.go putSymlink: bos client is not fake")
+		return
+	}
+	for i, tCase := range testCases {
+		fakeClient.symlinks = nil
+		err := testBosCli.putSymlink("bucket", tCase.targetKey, tCase.linkKey)
+		util.ExpectEqual("symlink.go putSymlink I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		util.ExpectEqual("symlink.go putSymlink II", i+1, t.Errorf, tCase.symlinks,
+			fakeClient.symlinks)
+	}
+}
+
+type getSymlinkTargetType struct {
+	stat   *objectStat
+	target string
+}
+
+func TestGetSymlinkTarget(t *testing.T) {
+	testCases := []getSymlinkTargetType{
+		//1 not a symlink
+		getSymlinkTargetType{
+			stat: &objectStat{bucketName: "bucket", key: "link"},
+		},
+		//2 target is returned by head object
+		getSymlinkTargetType{
+			stat: &objectStat{bucketName: "bucket", key: "link", isSymlink: true,
+				symlinkTarget: "dir/a"},
+			target: "dir/a",
+		},
+		//3
+		getSymlinkTargetType{
+			stat:   &objectStat{bucketName: "bucket", key: "link", isSymlink: true},
+			target: "target",
+		},
+		//4 failed to get symlink
+		getSymlinkTargetType{
+			stat: &objectStat{bucketName: "bucket", key: "404", isSymlink: true},
+		},
+	}
+	for i, tCase := range testCases {
+		ret := testBosCli.getSymlinkTarget(tCase.stat)
+		util.ExpectEqual("symlink.go getSymlinkTarget I", i+1, t.Errorf, tCase.target, ret)
+	}
+}
+
+func TestSetSymlinkTargets(t *testing.T) {
+	listResults := []*listFileResult{
+		&listFileResult{dir: &dirDetail{path: "dir/"}, isDir: true},
+		&listFileResult{file: &fileDetail{path: "link"}},
+		&listFileResult{file: &fileDetail{path: "a/b"}},
+		// deleted after it is listed
+		&listFileResult{file: &fileDetail{path: "404"}},
+		&listFileResult{file: &fileDetail{path: "head-error"}},
+		&listFileResult{file: &fileDetail{path: "link"}},
+	}
+	targets := []string{"", "target", "", "", "", "target"}
+	testBosCli.setSymlinkTargets("bucket", listResults)
+	for i, listResult := range listResults {
+		if listResult.isDir {
+			continue
+		}
+		util.ExpectEqual("symlink.go setSymlinkTargets", i+1, t.Errorf, targets[i],
+			listResult.file.linkTarget)
+	}
+}
+
+type symlinkMapperTargetKeyType struct {
+	file      *fileDetail
+	linkKey   string
+	targetKey string
+	ok        bool
+}
+
+func TestSymlinkMapperTargetKey(t *testing.T) {
+	pathPrefix, err := filepath.Abs("./test_symlink_mapper")
+	if err != nil {
+		t.Errorf("symlink.go targetKey: %s", err)
+		return
+	}
+	outPath, err := filepath.Abs("./test_symlink_mapper_out")
+	if err != nil {
+		t.Errorf("symlink.go targetKey: %s", err)
+		return
+	}
+	defer func() {
+		os.RemoveAll(pathPrefix)
+		os.RemoveAll(outPath)
+	}()
+	for _, dir := range []string{pathPrefix + "/dir", outPath} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Errorf("symlink.go targetKey: %s", err)
+			return
+		}
+	}
+	for _, name := range []string{pathPrefix + "/dir/a", outPath + "/b"} {
+		if err := ioutil.WriteFile(name, []byte("symlink"), 0644); err != nil {
+			t.Errorf("symlink.go targetKey: %s", err)
+			return
+		}
+	}
+
+	mapper, err := newSymlinkMapper(pathPrefix)
+	if err != nil {
+		t.Errorf("symlink.go targetKey: %s", err)
+		return
+	}
+	testCases := []symlinkMapperTargetKeyType{
+		//1 not symlink
+		symlinkMapperTargetKeyType{
+			file:    &fileDetail{path: pathPrefix + "/dir/a", realPath: pathPrefix + "/dir/a"},
+			linkKey: "prefix/dir/a",
+		},
+		//2
+		symlinkMapperTargetKeyType{
+			file: &fileDetail{path: pathPrefix + "/link", realPath: pathPrefix + "/dir/a",
+				isSymlink: true},
+			linkKey:   "prefix/link",
+			targetKey: "prefix/dir/a",
+			ok:        true,
+		},
+		//3
+		symlinkMapperTargetKeyType{
+			file: &fileDetail{path: pathPrefix + "/dir/link", realPath: pathPrefix + "/dir/a",
+				isSymlink: true},
+			linkKey:   "dir/link",
+			targetKey: "dir/a",
+			ok:        true,
+		},
+		//4 target is out of the directory
+		symlinkMapperTargetKeyType{
+			file: &fileDetail{path: pathPrefix + "/link", realPath: outPath + "/b",
+				isSymlink: true},
+			linkKey: "prefix/link",
+		},
+		//5 link key doesn't end with the relative path of link
+		symlinkMapperTargetKeyType{
+			file: &fileDetail{path: pathPrefix + "/link", realPath: pathPrefix + "/dir/a",
+				isSymlink: true},
+			linkKey: "prefix/other",
+		},
+	}
+	for i, tCase := range testCases {
+		key, ok := mapper.targetKey(tCase.file, tCase.linkKey)
+		util.ExpectEqual("symlink.go targetKey I", i+1, t.Errorf, tCase.ok, ok)
+		util.ExpectEqual("symlink.go targetKey II", i+1, t.Errorf, tCase.targetKey, key)
+	}
+
+	// nil mapper maps nothing
+	var nilMapper *symlinkMapper
+	_, ok := nilMapper.targetKey(testCases[1].file, testCases[1].linkKey)
+	util.ExpectEqual("symlink.go targetKey III", 1, t.Errorf, false, ok)
+}
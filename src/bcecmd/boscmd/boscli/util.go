@@ -21,10 +21,12 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 import (
 	"bcecmd/boscmd"
+	"bceconf"
 	"github.com/baidubce/bce-sdk-go/services/bos/api"
 	"utils/util"
 )
@@ -32,11 +34,27 @@ import (
 // Print msg if not quiet
 func printIfNotQuiet(format string, args ...interface{}) (int, error) {
 	if !Quiet {
-		return fmt.Printf(format, args...)
+		return fmt.Fprintf(msgOutput, format, args...)
 	}
 	return 0, nil
 }
 
+// Get the number of concurrent operations, use sync_processing_num when concurrency is 0
+func getConcurrency(concurrency int) (int, BosCliErrorCode, error) {
+	if concurrency < 0 {
+		return 0, BOSCLI_CONCURRENCY_LESS_ZERO, fmt.Errorf("concurrency must be greater " +
+			"than zero")
+	} else if concurrency > 0 {
+		return concurrency, BOSCLI_OK, nil
+	}
+	concurrency, ok := bceconf.ServerConfigProvider.GetSyncProcessingNum()
+	if !ok {
+		return 0, BOSCLI_GET_SYNC_PROCESSING_NUM_FAILED, fmt.Errorf("There is no info " +
+			"about sync processing num found!")
+	}
+	return concurrency, BOSCLI_OK, nil
+}
+
 // Check whether storage class is correct
 func getStorageClassFromStr(str string) (string, BosCliErrorCode) {
 	switch strings.ToUpper(str) {
@@ -48,6 +66,8 @@ func getStorageClassFromStr(str string) (string, BosCliErrorCode) {
 		return api.STORAGE_CLASS_STANDARD_IA, BOSCLI_OK
 	case api.STORAGE_CLASS_COLD:
 		return api.STORAGE_CLASS_COLD, BOSCLI_OK
+	case api.STORAGE_CLASS_ARCHIVE:
+		return api.STORAGE_CLASS_ARCHIVE, BOSCLI_OK
 	}
 	return "", BOSCLI_UNSUPPORT_STORAGE_CLASS
 }
@@ -335,6 +355,21 @@ func (l *LocalFileIterator) listAllFiles(localPath string, lInfo os.FileInfo) {
 				gtime:    time.Now().Unix(),
 			},
 		}
+		// the real path is needed to upload the link as a symlink object
+		if isSymbol {
+			linkDetail, err := l.getRelSizeAndMtimeOfLink(localPath)
+			if err != nil {
+				l.filesChan <- listFileResult{
+					file: &fileDetail{
+						path: localPath,
+						err:  err,
+					},
+				}
+				return
+			}
+			linkDetail.isSymlink = true
+			result.file = linkDetail
+		}
 		// Filter file by patterns
 		if l.filter != nil {
 			if filtered, err := l.filter.PatternFilter(localPath); filtered || err != nil {
@@ -485,3 +520,268 @@ func getCrc32OfLocalFile(localPath string) (string, error) {
 	crc32Val := hash.Sum32()
 	return strconv.FormatUint(uint64(crc32Val), 10), nil
 }
+
+// parse byte range in the format of START-END or START-, both are offsets starting from 0.
+// RETURN:
+//    start, end of the range, end is -1 when the range is not closed
+func parseByteRange(byteRange string) (int64, int64, error) {
+	items := strings.SplitN(strings.TrimSpace(byteRange), "-", 2)
+	if len(items) != 2 || items[0] == "" {
+		return 0, 0, fmt.Errorf("Invalid range %s, it must be START-END or START-", byteRange)
+	}
+	start, err := strconv.ParseInt(items[0], 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("Invalid range start %s", items[0])
+	}
+	if items[1] == "" {
+		return start, -1, nil
+	}
+	end, err := strconv.ParseInt(items[1], 10, 64)
+	if err != nil || end < start {
+		return 0, 0, fmt.Errorf("Invalid range end %s", items[1])
+	}
+	return start, end, nil
+}
+
+// parse condition of find in format [+|-]N[unit], unit is case insensitive and defaultUnit is
+// used when it is omitted.
+func parseFindCondition(cond string, units map[string]int64, defaultUnit string) (
+	*findCondition, error) {
+
+	condition := &findCondition{}
+	val := strings.TrimSpace(cond)
+	if strings.HasPrefix(val, "+") || strings.HasPrefix(val, "-") {
+		condition.sign = val[0]
+		val = val[1:]
+	}
+
+	unitName := defaultUnit
+	numEnd := len(val)
+	for numEnd > 0 && (val[numEnd-1] < '0' || val[numEnd-1] > '9') {
+		numEnd--
+	}
+	if numEnd < len(val) {
+		unitName = strings.ToUpper(val[numEnd:])
+	}
+	unit, ok := units[unitName]
+	if !ok {
+		return nil, fmt.Errorf("Invalid unit of %s", cond)
+	}
+	num, err := strconv.ParseInt(val[:numEnd], 10, 64)
+	if err != nil || num < 0 {
+		return nil, fmt.Errorf("Invalid number of %s", cond)
+	}
+	condition.value = num * unit
+	condition.unit = unit
+	return condition, nil
+}
+
+// split command into words as sh does, words are separated by white spaces which are not
+// quoted. Characters in single quotes are kept as they are, a backslash in double quotes only
+// escapes '"' and '\', and a backslash out of quotes escapes any character.
+func splitCommandWords(command string) ([]string, error) {
+	var (
+		words  = []string{}
+		word   []rune
+		inWord bool
+		quote  rune
+		escape bool
+	)
+	for _, c := range command {
+		switch {
+		case escape:
+			if quote == '"' && c != '"' && c != '\\' {
+				word = append(word, '\\')
+			}
+			word = append(word, c)
+			escape = false
+		case c == '\\' && quote != '\'':
+			escape = true
+			inWord = true
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			word = append(word, c)
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case unicode.IsSpace(c):
+			if inWord {
+				words = append(words, string(word))
+				word = word[:0]
+				inWord = false
+			}
+		default:
+			word = append(word, c)
+			inWord = true
+		}
+	}
+	if escape || quote != 0 {
+		return nil, fmt.Errorf("unterminated quote or escape in command: %s", command)
+	}
+	if inWord {
+		words = append(words, string(word))
+	}
+	return words, nil
+}
+
+// transform size to human readable format, e.g., 100B, 1.5K, 20.0M
+func getHumanReadableSize(size int64) string {
+	units := []string{"B", "K", "M", "G", "T", "P", "E"}
+	if size < 1024 {
+		return fmt.Sprintf("%d%s", size, units[0])
+	}
+	val := float64(size)
+	i := 0
+	for val >= 1024 && i < len(units)-1 {
+		val /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f%s", val, units[i])
+}
+
+// parse the value of header x-bce-restore, e.g. ongoing-request="true" while the object is being
+// restored, ongoing-request="false", expiry-date="Wed, 07 Nov 2019 00:00:00 GMT" after restored.
+// RETURN:
+//    status of restore and the expiry date of restored object
+func parseRestoreStatus(restore string) (string, string) {
+	if restore == "" {
+		return RESTORE_STATUS_NONE, ""
+	}
+	if strings.Contains(strings.ToLower(restore), `ongoing-request="true"`) {
+		return RESTORE_STATUS_ONGOING, ""
+	}
+	expiry := ""
+	const expiryPrefix = `expiry-date="`
+	if start := strings.Index(strings.ToLower(restore), expiryPrefix); start != -1 {
+		expiry = restore[start+len(expiryPrefix):]
+		if end := strings.Index(expiry, `"`); end != -1 {
+			expiry = expiry[:end]
+		}
+	}
+	return RESTORE_STATUS_RESTORED, expiry
+}
+
+// get the restore tier from the case insensitive tier name
+func getRestoreTierFromStr(tier string) (string, BosCliErrorCode) {
+	switch strings.ToLower(tier) {
+	case strings.ToLower(api.RESTORE_TIER_STANDARD):
+		return api.RESTORE_TIER_STANDARD, BOSCLI_OK
+	case strings.ToLower(api.RESTORE_TIER_EXPEDITED):
+		return api.RESTORE_TIER_EXPEDITED, BOSCLI_OK
+	}
+	return "", BOSCLI_RESTORE_TIER_INVALID
+}
+
+// parse the http headers and user metadata set by cp and sync.
+// When any header or user metadata is set, the metadata directive of copy is replace by
+// default, as BOS ignores them when the directive is copy.
+func newObjectMetaArgs(opts ObjectMetaOptions) (*objectMetaArgs, BosCliErrorCode, error) {
+	directive := strings.ToLower(opts.MetadataDirective)
+	if directive != "" && directive != api.METADATA_DIRECTIVE_COPY &&
+		directive != api.METADATA_DIRECTIVE_REPLACE {
+		return nil, BOSCLI_METADATA_DIRECTIVE_INVALID, fmt.Errorf(
+			"metadata directive must be copy or replace, but got %s", opts.MetadataDirective)
+	}
+
+	userMeta, err := parseUserMeta(opts.Meta)
+	if err != nil {
+		return nil, BOSCLI_USER_META_INVALID, err
+	}
+	if opts.CannedAcl != "" && opts.CannedAcl != api.CANNED_ACL_PRIVATE &&
+		opts.CannedAcl != api.CANNED_ACL_PUBLIC_READ {
+		return nil, BOSCLI_PUT_OBJECT_ACL_CANNED_DONT_SUPPORT, fmt.Errorf(
+			"unsupported canned ACL of object: %s", opts.CannedAcl)
+	}
+
+	meta := &objectMetaArgs{
+		contentType:        opts.ContentType,
+		cacheControl:       opts.CacheControl,
+		contentDisposition: opts.ContentDisposition,
+		contentEncoding:    opts.ContentEncoding,
+		expires:            opts.Expires,
+		userMeta:           userMeta,
+		metadataDirective:  directive,
+		cannedAcl:          opts.CannedAcl,
+	}
+	hasHeaders := meta.contentType != "" || meta.cacheControl != "" ||
+		meta.contentDisposition != "" || meta.contentEncoding != "" || meta.expires != "" ||
+		len(meta.userMeta) > 0
+
+	if !hasHeaders {
+		if directive == "" && meta.cannedAcl == "" {
+			return nil, BOSCLI_OK, nil
+		}
+		return meta, BOSCLI_OK, nil
+	}
+	if directive == api.METADATA_DIRECTIVE_COPY {
+		return nil, BOSCLI_METADATA_DIRECTIVE_INVALID, fmt.Errorf("Can not set headers or " +
+			"user metadata when metadata directive is copy")
+	}
+	meta.metadataDirective = api.METADATA_DIRECTIVE_REPLACE
+	return meta, BOSCLI_OK, nil
+}
+
+// parse user metadata in the format of key=value, the later one wins when a key is repeated
+func parseUserMeta(metas []string) (map[string]string, error) {
+	if len(metas) == 0 {
+		return nil, nil
+	}
+	userMeta := make(map[string]string, len(metas))
+	for _, kv := range metas {
+		pos := strings.Index(kv, "=")
+		if pos <= 0 {
+			return nil, fmt.Errorf("user metadata must be in the format of key=value, but got %s",
+				kv)
+		}
+		key := strings.TrimSpace(kv[:pos])
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid user metadata key: %s", kv[:pos])
+		}
+		userMeta[key] = kv[pos+1:]
+	}
+	return userMeta, nil
+}
+
+// args of put object, a nil meta only sets the storage class
+func (m *objectMetaArgs) putObjectArgs(storageClass string) *api.PutObjectArgs {
+	args := &api.PutObjectArgs{StorageClass: storageClass}
+	if m != nil {
+		args.ContentType = m.contentType
+		args.CacheControl = m.cacheControl
+		args.ContentDisposition = m.contentDisposition
+		args.Expires = m.expires
+		args.UserMeta = m.userMeta
+	}
+	return args
+}
+
+// content type and args of initiate multipart upload
+func (m *objectMetaArgs) initiateMultipartUploadArgs(storageClass string) (string,
+	*api.InitiateMultipartUploadArgs) {
+
+	args := &api.InitiateMultipartUploadArgs{StorageClass: storageClass}
+	if m == nil {
+		return "", args
+	}
+	args.CacheControl = m.cacheControl
+	args.ContentDisposition = m.contentDisposition
+	args.Expires = m.expires
+	return m.contentType, args
+}
+
+// args of copy object, a nil meta only sets the storage class
+func (m *objectMetaArgs) copyObjectArgs(storageClass string) *api.CopyObjectArgs {
+	args := new(api.CopyObjectArgs)
+	args.StorageClass = storageClass
+	if m != nil {
+		args.MetadataDirective = m.metadataDirective
+		args.ContentType = m.contentType
+		args.CacheControl = m.cacheControl
+		args.ContentDisposition = m.contentDisposition
+		args.ContentEncoding = m.contentEncoding
+		args.Expires = m.expires
+		args.UserMeta = m.userMeta
+	}
+	return args
+}
@@ -54,6 +54,40 @@ func TestPrintIfNotQuiet(t *testing.T) {
 	printIfNotQuiet("%s")
 }
 
+type getConcurrencyType struct {
+	input  int
+	output int
+	code   BosCliErrorCode
+}
+
+func TestGetConcurrency(t *testing.T) {
+	syncProcessingNum, _ := bceconf.ServerConfigProvider.GetSyncProcessingNum()
+	testCases := []getConcurrencyType{
+		getConcurrencyType{
+			input:  -1,
+			output: 0,
+			code:   BOSCLI_CONCURRENCY_LESS_ZERO,
+		},
+		getConcurrencyType{
+			input:  0,
+			output: syncProcessingNum,
+			code:   BOSCLI_OK,
+		},
+		getConcurrencyType{
+			input:  20,
+			output: 20,
+			code:   BOSCLI_OK,
+		},
+	}
+	for i, tCase := range testCases {
+		ret, code, err := getConcurrency(tCase.input)
+		util.ExpectEqual("util.go getConcurrency I", i+1, t.Errorf, tCase.output, ret)
+		util.ExpectEqual("util.go getConcurrency II", i+1, t.Errorf, tCase.code, code)
+		util.ExpectEqual("util.go getConcurrency III", i+1, t.Errorf, tCase.code == BOSCLI_OK,
+			err == nil)
+	}
+}
+
 type getStorageClassFromStrType struct {
 	input  string
 	output string
@@ -87,6 +121,11 @@ func TestGetStorageClassFromStr(t *testing.T) {
 			output: "COLD",
 			code:   BOSCLI_OK,
 		},
+		getStorageClassFromStrType{
+			input:  "archive",
+			output: "ARCHIVE",
+			code:   BOSCLI_OK,
+		},
 	}
 	for i, tCase := range testCases {
 		ret, retCode := getStorageClassFromStr(tCase.input)
@@ -1032,3 +1071,311 @@ func TestReplaceToOsPath(t *testing.T) {
 		util.ExpectEqual("tools.go replaceToOsPathType I", i+1, t.Errorf, eRet, ret)
 	}
 }
+
+type parseByteRangeType struct {
+	byteRange string
+	start     int64
+	end       int64
+	isSuc     bool
+}
+
+func TestParseByteRange(t *testing.T) {
+	testCases := []parseByteRangeType{
+		parseByteRangeType{byteRange: "0-99", start: 0, end: 99, isSuc: true},
+		parseByteRangeType{byteRange: "100-", start: 100, end: -1, isSuc: true},
+		parseByteRangeType{byteRange: " 5-5 ", start: 5, end: 5, isSuc: true},
+		parseByteRangeType{byteRange: "-100", isSuc: false},
+		parseByteRangeType{byteRange: "100", isSuc: false},
+		parseByteRangeType{byteRange: "10-9", isSuc: false},
+		parseByteRangeType{byteRange: "a-9", isSuc: false},
+		parseByteRangeType{byteRange: "0-b", isSuc: false},
+	}
+	for i, tCase := range testCases {
+		start, end, err := parseByteRange(tCase.byteRange)
+		util.ExpectEqual("util.go parseByteRange I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if tCase.isSuc {
+			util.ExpectEqual("util.go parseByteRange II", i+1, t.Errorf, tCase.start, start)
+			util.ExpectEqual("util.go parseByteRange III", i+1, t.Errorf, tCase.end, end)
+		}
+	}
+}
+
+type parseFindConditionType struct {
+	cond      string
+	timeUnits bool
+	sign      byte
+	value     int64
+	unit      int64
+	isSuc     bool
+}
+
+func TestParseFindCondition(t *testing.T) {
+	testCases := []parseFindConditionType{
+		parseFindConditionType{cond: "100", sign: 0, value: 100, unit: 1, isSuc: true},
+		parseFindConditionType{cond: "+100M", sign: '+', value: 100 << 20, unit: 1 << 20,
+			isSuc: true},
+		parseFindConditionType{cond: "-1k", sign: '-', value: 1 << 10, unit: 1 << 10,
+			isSuc: true},
+		parseFindConditionType{cond: "-7", timeUnits: true, sign: '-', value: 7 * 86400,
+			unit: 86400, isSuc: true},
+		parseFindConditionType{cond: "+2h", timeUnits: true, sign: '+', value: 7200,
+			unit: 3600, isSuc: true},
+		parseFindConditionType{cond: "30m", timeUnits: true, value: 1800, unit: 60,
+			isSuc: true},
+		parseFindConditionType{cond: "", isSuc: false},
+		parseFindConditionType{cond: "+", isSuc: false},
+		parseFindConditionType{cond: "M", isSuc: false},
+		parseFindConditionType{cond: "100X", isSuc: false},
+		parseFindConditionType{cond: "1.5M", isSuc: false},
+		parseFindConditionType{cond: "+-1", isSuc: false},
+	}
+	for i, tCase := range testCases {
+		units, defaultUnit := findSizeUnits, "B"
+		if tCase.timeUnits {
+			units, defaultUnit = findTimeUnits, "D"
+		}
+		cond, err := parseFindCondition(tCase.cond, units, defaultUnit)
+		util.ExpectEqual("util.go parseFindCondition I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if tCase.isSuc {
+			util.ExpectEqual("util.go parseFindCondition II", i+1, t.Errorf, tCase.sign,
+				cond.sign)
+			util.ExpectEqual("util.go parseFindCondition III", i+1, t.Errorf, tCase.value,
+				cond.value)
+			util.ExpectEqual("util.go parseFindCondition IV", i+1, t.Errorf, tCase.unit,
+				cond.unit)
+		}
+	}
+}
+
+type splitCommandWordsType struct {
+	command string
+	words   []string
+	isSuc   bool
+}
+
+func TestSplitCommandWords(t *testing.T) {
+	testCases := []splitCommandWordsType{
+		//1
+		splitCommandWordsType{command: "", words: []string{}, isSuc: true},
+		//2
+		splitCommandWordsType{command: "  bcecmd bos\tstat  {} ",
+			words: []string{"bcecmd", "bos", "stat", "{}"}, isSuc: true},
+		//3
+		splitCommandWordsType{command: "sh -c 'echo {} | wc -c'",
+			words: []string{"sh", "-c", "echo {} | wc -c"}, isSuc: true},
+		//4
+		splitCommandWordsType{command: `echo "a \"b\" \c" a\ b '\n' ""`,
+			words: []string{"echo", `a "b" \c`, "a b", `\n`, ""}, isSuc: true},
+		//5
+		splitCommandWordsType{command: `x"y"'z'`, words: []string{"xyz"}, isSuc: true},
+		//6
+		splitCommandWordsType{command: "sh -c 'echo {}", isSuc: false},
+		//7
+		splitCommandWordsType{command: `echo "a`, isSuc: false},
+		//8
+		splitCommandWordsType{command: `echo \`, isSuc: false},
+	}
+	for i, tCase := range testCases {
+		words, err := splitCommandWords(tCase.command)
+		util.ExpectEqual("util.go splitCommandWords I", i+1, t.Errorf, tCase.isSuc, err == nil)
+		if tCase.isSuc {
+			util.ExpectEqual("util.go splitCommandWords II", i+1, t.Errorf, tCase.words, words)
+		}
+	}
+}
+
+type getHumanReadableSizeType struct {
+	size int64
+	ret  string
+}
+
+func TestGetHumanReadableSize(t *testing.T) {
+	testCases := []getHumanReadableSizeType{
+		getHumanReadableSizeType{size: 0, ret: "0B"},
+		getHumanReadableSizeType{size: 1023, ret: "1023B"},
+		getHumanReadableSizeType{size: 1024, ret: "1.0K"},
+		getHumanReadableSizeType{size: 1536, ret: "1.5K"},
+		getHumanReadableSizeType{size: 20 << 20, ret: "20.0M"},
+		getHumanReadableSizeType{size: 3 << 40, ret: "3.0T"},
+	}
+	for i, tCase := range testCases {
+		ret := getHumanReadableSize(tCase.size)
+		util.ExpectEqual("util.go getHumanReadableSize", i+1, t.Errorf, tCase.ret, ret)
+	}
+}
+
+type parseRestoreStatusType struct {
+	restore string
+	status  string
+	expiry  string
+}
+
+func TestParseRestoreStatus(t *testing.T) {
+	testCases := []parseRestoreStatusType{
+		parseRestoreStatusType{restore: "", status: RESTORE_STATUS_NONE},
+		parseRestoreStatusType{restore: "ongoing-request=\"true\"", status: RESTORE_STATUS_ONGOING},
+		parseRestoreStatusType{
+			restore: "ongoing-request=\"false\", expiry-date=\"Wed, 07 Nov 2019 00:00:00 GMT\"",
+			status:  RESTORE_STATUS_RESTORED,
+			expiry:  "Wed, 07 Nov 2019 00:00:00 GMT",
+		},
+		parseRestoreStatusType{restore: "ongoing-request=\"false\"", status: RESTORE_STATUS_RESTORED},
+	}
+	for i, tCase := range testCases {
+		status, expiry := parseRestoreStatus(tCase.restore)
+		util.ExpectEqual("util.go parseRestoreStatus I", i+1, t.Errorf, tCase.status, status)
+		util.ExpectEqual("util.go parseRestoreStatus II", i+1, t.Errorf, tCase.expiry, expiry)
+	}
+}
+
+type getRestoreTierFromStrType struct {
+	tier string
+	out  string
+	code BosCliErrorCode
+}
+
+func TestGetRestoreTierFromStr(t *testing.T) {
+	testCases := []getRestoreTierFromStrType{
+		getRestoreTierFromStrType{tier: "Standard", out: "Standard", code: BOSCLI_OK},
+		getRestoreTierFromStrType{tier: "expedited", out: "Expedited", code: BOSCLI_OK},
+		getRestoreTierFromStrType{tier: "Bulk", code: BOSCLI_RESTORE_TIER_INVALID},
+		getRestoreTierFromStrType{tier: "", code: BOSCLI_RESTORE_TIER_INVALID},
+	}
+	for i, tCase := range testCases {
+		ret, code := getRestoreTierFromStr(tCase.tier)
+		util.ExpectEqual("util.go getRestoreTierFromStr I", i+1, t.Errorf, tCase.code, code)
+		util.ExpectEqual("util.go getRestoreTierFromStr II", i+1, t.Errorf, tCase.out, ret)
+	}
+}
+
+type newObjectMetaArgsType struct {
+	opts ObjectMetaOptions
+	out  *objectMetaArgs
+	code BosCliErrorCode
+}
+
+func TestNewObjectMetaArgs(t *testing.T) {
+	testCases := []newObjectMetaArgsType{
+		//1 nothing is set
+		newObjectMetaArgsType{code: BOSCLI_OK},
+		//2 headers and user metadata, directive is replace by default
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{ContentType: "text/html", CacheControl: "max-age=60",
+				Meta: []string{"a=1", "b=x=y"}},
+			out: &objectMetaArgs{contentType: "text/html", cacheControl: "max-age=60",
+				userMeta: map[string]string{"a": "1", "b": "x=y"}, metadataDirective: "replace"},
+			code: BOSCLI_OK,
+		},
+		//3 only directive
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{MetadataDirective: "COPY"},
+			out:  &objectMetaArgs{metadataDirective: "copy"},
+			code: BOSCLI_OK,
+		},
+		//4 replace without headers clears metadata
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{MetadataDirective: "replace"},
+			out:  &objectMetaArgs{metadataDirective: "replace"},
+			code: BOSCLI_OK,
+		},
+		//5 invalid directive
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{MetadataDirective: "keep"},
+			code: BOSCLI_METADATA_DIRECTIVE_INVALID,
+		},
+		//6 headers can not be set with copy
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{ContentEncoding: "gzip", MetadataDirective: "copy"},
+			code: BOSCLI_METADATA_DIRECTIVE_INVALID,
+		},
+		//7 invalid user metadata
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{Meta: []string{"a"}},
+			code: BOSCLI_USER_META_INVALID,
+		},
+		//8 empty key
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{Meta: []string{"=1"}},
+			code: BOSCLI_USER_META_INVALID,
+		},
+		//9 key with space
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{Meta: []string{"a b=1"}},
+			code: BOSCLI_USER_META_INVALID,
+		},
+		//10 only canned acl, metadata of source objects is copied
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{CannedAcl: "public-read"},
+			out:  &objectMetaArgs{cannedAcl: "public-read"},
+			code: BOSCLI_OK,
+		},
+		//11 object doesn't support public-read-write
+		newObjectMetaArgsType{
+			opts: ObjectMetaOptions{CannedAcl: "public-read-write"},
+			code: BOSCLI_PUT_OBJECT_ACL_CANNED_DONT_SUPPORT,
+		},
+	}
+	for i, tCase := range testCases {
+		ret, code, err := newObjectMetaArgs(tCase.opts)
+		util.ExpectEqual("util.go newObjectMetaArgs I", i+1, t.Errorf, tCase.code, code)
+		util.ExpectEqual("util.go newObjectMetaArgs II", i+1, t.Errorf, code != BOSCLI_OK,
+			err != nil)
+		util.ExpectEqual("util.go newObjectMetaArgs III", i+1, t.Errorf, tCase.out, ret)
+	}
+}
+
+type objectMetaArgsType struct {
+	meta        *objectMetaArgs
+	contentType string
+	directive   string
+}
+
+func TestObjectMetaArgs(t *testing.T) {
+	testCases := []objectMetaArgsType{
+		//1 nil meta only sets storage class
+		objectMetaArgsType{},
+		//2 content encoding and user metadata are sent by metaBosClient when uploading
+		objectMetaArgsType{
+			meta: &objectMetaArgs{contentType: "text/css", cacheControl: "no-cache",
+				contentDisposition: "inline", expires: "Thu, 01 Dec 2022 16:00:00 GMT",
+				metadataDirective: "replace"},
+			contentType: "text/css",
+			directive:   "replace",
+		},
+		//3
+		objectMetaArgsType{
+			meta: &objectMetaArgs{userMeta: map[string]string{"a": "1"}},
+		},
+		//4
+		objectMetaArgsType{
+			meta: &objectMetaArgs{contentEncoding: "gzip"},
+		},
+	}
+	for i, tCase := range testCases {
+		putArgs := tCase.meta.putObjectArgs("COLD")
+		util.ExpectEqual("util.go objectMetaArgs I", i+1, t.Errorf, "COLD", putArgs.StorageClass)
+		util.ExpectEqual("util.go objectMetaArgs II", i+1, t.Errorf, tCase.contentType,
+			putArgs.ContentType)
+
+		contentType, initArgs := tCase.meta.initiateMultipartUploadArgs("COLD")
+		util.ExpectEqual("util.go objectMetaArgs III", i+1, t.Errorf, tCase.contentType,
+			contentType)
+		util.ExpectEqual("util.go objectMetaArgs IV", i+1, t.Errorf, "COLD", initArgs.StorageClass)
+
+		copyArgs := tCase.meta.copyObjectArgs("COLD")
+		util.ExpectEqual("util.go objectMetaArgs V", i+1, t.Errorf, "COLD", copyArgs.StorageClass)
+		util.ExpectEqual("util.go objectMetaArgs VI", i+1, t.Errorf, tCase.directive,
+			copyArgs.MetadataDirective)
+		util.ExpectEqual("util.go objectMetaArgs VII", i+1, t.Errorf, tCase.contentType,
+			copyArgs.ContentType)
+
+		if tCase.meta != nil {
+			util.ExpectEqual("util.go objectMetaArgs VIII", i+1, t.Errorf, tCase.meta.cacheControl,
+				initArgs.CacheControl)
+			util.ExpectEqual("util.go objectMetaArgs IX", i+1, t.Errorf, tCase.meta.userMeta,
+				putArgs.UserMeta)
+			util.ExpectEqual("util.go objectMetaArgs X", i+1, t.Errorf,
+				tCase.meta.contentEncoding, copyArgs.ContentEncoding)
+		}
+	}
+}
@@ -39,6 +39,7 @@ var (
 	configPath                  string
 	bucktEndpointCachePath      string
 	MultiuploadFolder           string
+	MimeTypesPath               string // optional user mime types file, in the format of mime.types
 	credentialFileProvider      *FileCredentialProvider
 	defaCredentialProvider      *DefaultCredentialProvider
 	CredentialProvider          *ChainCredentialProvider
@@ -89,6 +90,7 @@ func InitConfig(configDirPath string) error {
 	configPath = filepath.Join(configDirPath, "config")
 	bucktEndpointCachePath = filepath.Join(configDirPath, "bucket_endpoint_cache")
 	MultiuploadFolder = filepath.Join(configDirPath, "multiupload_infos", "ak", "")
+	MimeTypesPath = filepath.Join(configDirPath, "mime.types")
 
 	// generate credential provider
 	credentialFileProvider, err = NewFileCredentialProvider(credentialPath)
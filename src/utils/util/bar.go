@@ -14,7 +14,9 @@ package util
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -45,10 +47,12 @@ type Bar struct {
 	haveShow       bool
 	barId          string
 	prefix         string
+	output         io.Writer
 }
 
 func (b *Bar) init(total int, prefix string, quiet bool) error {
 	b.quiet = quiet
+	b.output = os.Stdout
 	if quiet {
 		return nil
 	}
@@ -75,12 +79,19 @@ func (b *Bar) show(isComplete bool) {
 	showBar := fmt.Sprintf("%s |%s%s| %d/%d", b.prefix, strings.Repeat(BAR_TYPE, b.outputNum),
 		strings.Repeat(" ", b.totalBar-b.outputNum), b.finish, b.total)
 	if isComplete {
-		fmt.Printf("\r%s\n", showBar)
+		fmt.Fprintf(b.output, "\r%s\n", showBar)
 	} else {
-		fmt.Printf("\r%s", showBar)
+		fmt.Fprintf(b.output, "\r%s", showBar)
 	}
 }
 
+// Set where to show the bar, default is stdout
+func (b *Bar) SetOutput(output io.Writer) {
+	b.rwmutex.Lock()
+	defer b.rwmutex.Unlock()
+	b.output = output
+}
+
 func (b *Bar) Finish(num int) {
 	if b.quiet {
 		return
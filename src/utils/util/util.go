@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -34,6 +35,9 @@ const (
 
 var (
 	OsPathSeparator = fmt.Sprintf("%c", os.PathSeparator)
+
+	// prompts of concurrent operations are shown one by one
+	promptLock sync.Mutex
 )
 
 func init() {
@@ -258,6 +262,9 @@ func Abs(localPath string) (string, error) {
 }
 
 func PromptConfirm(format string, args ...interface{}) bool {
+	promptLock.Lock()
+	defer promptLock.Unlock()
+
 	fmt.Printf(format+" (Y/N)", args...)
 	scanner := bufio.NewScanner(os.Stdin)
 	for {